@@ -0,0 +1,313 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// State is the state of a Breaker.
+type State int
+
+const (
+	// StateClosed lets all requests pass and counts their failures.
+	StateClosed State = iota
+	// StateOpen rejects all requests until Settings.CoolDown is passed.
+	StateOpen
+	// StateHalfOpen lets Settings.HalfOpenMaxRequests probe requests pass to decide whether to close or open again.
+	StateHalfOpen
+)
+
+const (
+	DefaultFailureRatio        = 0.5
+	DefaultMinRequests         = 10
+	DefaultWindow              = 10 * time.Second
+	DefaultCoolDown            = 30 * time.Second
+	DefaultHalfOpenMaxRequests = 1
+
+	// StateChangeEventName is the name of span event added on each state transition.
+	StateChangeEventName = "circuit_breaker.state_change"
+)
+
+// String casts the state enum to its string value.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Settings specifies the behaviour of a Breaker. zero values are replaced with defaults.
+type Settings struct {
+	// Name identifies the breaker in errors and span events. e.g. `eta`.
+	Name string
+	// FailureRatio is the ratio of failed requests in a window that opens the breaker. default is 0.5.
+	FailureRatio float64
+	// MinRequests is the minimum number of requests in a window before the breaker can open. default is 10.
+	MinRequests int
+	// Window is the duration after which the counts of a closed breaker are reset. default is 10s.
+	Window time.Duration
+	// CoolDown is how long the breaker stays open before letting probe requests pass. default is 30s.
+	CoolDown time.Duration
+	// HalfOpenMaxRequests is the number of successful probe requests needed to close the breaker. default is 1.
+	HalfOpenMaxRequests int
+	// OnStateChange is called after each state transition, if not nil.
+	OnStateChange func(name string, from, to State)
+}
+
+// Counts holds the number of requests of the current window.
+type Counts struct {
+	Requests  int
+	Successes int
+	Failures  int
+}
+
+// OpenError is returned for requests rejected by an open Breaker. it matches smapperrors.ErrCircuitOpen
+// using errors.Is.
+type OpenError struct {
+	// Name is the name of the breaker.
+	Name string
+	// State is the state of the breaker when the request is rejected.
+	State State
+	// RetryAt is the time after which the breaker lets probe requests pass.
+	RetryAt time.Time
+}
+
+func (e *OpenError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%s: state %s", smapperrors.ErrCircuitOpen, e.State)
+	}
+	return fmt.Sprintf("%s: %s: state %s", e.Name, smapperrors.ErrCircuitOpen, e.State)
+}
+
+// Is reports whether target is smapperrors.ErrCircuitOpen.
+func (e *OpenError) Is(target error) bool {
+	return target == smapperrors.ErrCircuitOpen
+}
+
+// Breaker is a circuit breaker with closed, open and half-open states. it is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+	now      func() time.Time
+
+	mu               sync.Mutex
+	state            State
+	generation       uint64
+	counts           Counts
+	expiry           time.Time
+	halfOpenInFlight int
+}
+
+// New creates a closed Breaker with the given settings.
+func New(settings Settings) *Breaker {
+	if settings.FailureRatio <= 0 || settings.FailureRatio > 1 {
+		settings.FailureRatio = DefaultFailureRatio
+	}
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = DefaultMinRequests
+	}
+	if settings.Window <= 0 {
+		settings.Window = DefaultWindow
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = DefaultCoolDown
+	}
+	if settings.HalfOpenMaxRequests <= 0 {
+		settings.HalfOpenMaxRequests = DefaultHalfOpenMaxRequests
+	}
+
+	b := &Breaker{
+		settings: settings,
+		now:      time.Now,
+	}
+	b.expiry = b.now().Add(settings.Window)
+	return b
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.settings.Name
+}
+
+// State returns the current state of the breaker. it can be used in health checks.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	state, transition := b.currentState(b.now())
+	b.mu.Unlock()
+
+	b.notify(context.Background(), transition)
+	return state
+}
+
+// Counts returns the counts of the current window.
+func (b *Breaker) Counts() Counts {
+	b.mu.Lock()
+	_, transition := b.currentState(b.now())
+	counts := b.counts
+	b.mu.Unlock()
+
+	b.notify(context.Background(), transition)
+	return counts
+}
+
+// Allow checks whether a request can pass. if it can, the returned function must be called with the result of the request.
+// otherwise an *OpenError is returned. state transitions are recorded as events of the span in ctx.
+func (b *Breaker) Allow(ctx context.Context) (func(success bool), error) {
+	generation, err := b.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(success bool) {
+		if success {
+			b.done(ctx, generation, outcomeSuccess)
+		} else {
+			b.done(ctx, generation, outcomeFailure)
+		}
+	}, nil
+}
+
+type outcome int
+
+const (
+	outcomeIgnored outcome = iota
+	outcomeSuccess
+	outcomeFailure
+)
+
+func (b *Breaker) allow(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	now := b.now()
+	state, transition := b.currentState(now)
+
+	if state == StateOpen || (state == StateHalfOpen && b.halfOpenInFlight >= b.settings.HalfOpenMaxRequests) {
+		err := &OpenError{Name: b.settings.Name, State: state, RetryAt: b.expiry}
+		if state == StateHalfOpen {
+			err.RetryAt = now
+		}
+		b.mu.Unlock()
+		b.notify(ctx, transition)
+		return 0, err
+	}
+
+	if state == StateHalfOpen {
+		b.halfOpenInFlight++
+	}
+	generation := b.generation
+	b.mu.Unlock()
+	b.notify(ctx, transition)
+
+	return generation, nil
+}
+
+func (b *Breaker) done(ctx context.Context, generation uint64, result outcome) {
+	b.mu.Lock()
+	now := b.now()
+	state, transition := b.currentState(now)
+	if generation != b.generation {
+		b.mu.Unlock()
+		b.notify(ctx, transition)
+		return
+	}
+
+	if state == StateHalfOpen {
+		b.halfOpenInFlight--
+	}
+
+	var next *change
+	switch result {
+	case outcomeSuccess:
+		b.counts.Requests++
+		b.counts.Successes++
+		if state == StateHalfOpen && b.counts.Successes >= b.settings.HalfOpenMaxRequests {
+			next = b.setState(StateClosed, now)
+		}
+	case outcomeFailure:
+		b.counts.Requests++
+		b.counts.Failures++
+		switch state {
+		case StateClosed:
+			if b.counts.Requests >= b.settings.MinRequests &&
+				float64(b.counts.Failures)/float64(b.counts.Requests) >= b.settings.FailureRatio {
+				next = b.setState(StateOpen, now)
+			}
+		case StateHalfOpen:
+			next = b.setState(StateOpen, now)
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(ctx, transition)
+	b.notify(ctx, next)
+}
+
+type change struct {
+	from, to State
+}
+
+// currentState moves the breaker to the next window or to half-open state if needed. b.mu must be held.
+func (b *Breaker) currentState(now time.Time) (State, *change) {
+	switch b.state {
+	case StateClosed:
+		if now.After(b.expiry) {
+			b.newGeneration(now)
+		}
+	case StateOpen:
+		if now.After(b.expiry) {
+			return b.state, b.setState(StateHalfOpen, now)
+		}
+	}
+	return b.state, nil
+}
+
+// setState changes the state and resets the counts. b.mu must be held.
+func (b *Breaker) setState(state State, now time.Time) *change {
+	if b.state == state {
+		return nil
+	}
+	c := &change{from: b.state, to: state}
+	b.state = state
+	b.newGeneration(now)
+	return c
+}
+
+func (b *Breaker) newGeneration(now time.Time) {
+	b.generation++
+	b.counts = Counts{}
+	b.halfOpenInFlight = 0
+	switch b.state {
+	case StateClosed:
+		b.expiry = now.Add(b.settings.Window)
+	case StateOpen:
+		b.expiry = now.Add(b.settings.CoolDown)
+	default:
+		b.expiry = time.Time{}
+	}
+}
+
+func (b *Breaker) notify(ctx context.Context, c *change) {
+	if c == nil {
+		return
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(StateChangeEventName, trace.WithAttributes(
+		attribute.String("circuit_breaker.name", b.settings.Name),
+		attribute.String("circuit_breaker.from", c.from.String()),
+		attribute.String("circuit_breaker.to", c.to.String()),
+	))
+
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.settings.Name, c.from, c.to)
+	}
+}
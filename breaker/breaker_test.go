@@ -0,0 +1,205 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestBreaker(settings Settings) (*Breaker, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	b := New(settings)
+	b.now = clock.Now
+	b.expiry = clock.now.Add(b.settings.Window)
+	return b, clock
+}
+
+func record(t *testing.T, b *Breaker, success bool) {
+	t.Helper()
+	done, err := b.Allow(context.Background())
+	if err != nil {
+		t.Fatalf("request should be allowed: %s", err.Error())
+	}
+	done(success)
+}
+
+func TestNew(t *testing.T) {
+	b := New(Settings{Name: "eta"})
+
+	if b.State() != StateClosed {
+		t.Fatalf("State should be closed but it is %s", b.State())
+	}
+	if b.Name() != "eta" {
+		t.Fatalf("Name should be eta but it is %s", b.Name())
+	}
+	if b.settings.FailureRatio != DefaultFailureRatio {
+		t.Fatalf("FailureRatio should be %f but it is %f", DefaultFailureRatio, b.settings.FailureRatio)
+	}
+	if b.settings.MinRequests != DefaultMinRequests {
+		t.Fatalf("MinRequests should be %d but it is %d", DefaultMinRequests, b.settings.MinRequests)
+	}
+	if b.settings.CoolDown != DefaultCoolDown {
+		t.Fatalf("CoolDown should be %s but it is %s", DefaultCoolDown, b.settings.CoolDown)
+	}
+}
+
+func TestBreaker_Allow(t *testing.T) {
+	settings := Settings{
+		Name:         "eta",
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		Window:       time.Minute,
+		CoolDown:     10 * time.Second,
+	}
+
+	t.Run("stays_closed_below_min_requests", func(t *testing.T) {
+		b, _ := newTestBreaker(settings)
+		for i := 0; i < 3; i++ {
+			record(t, b, false)
+		}
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+	})
+
+	t.Run("stays_closed_below_failure_ratio", func(t *testing.T) {
+		b, _ := newTestBreaker(settings)
+		record(t, b, false)
+		for i := 0; i < 4; i++ {
+			record(t, b, true)
+		}
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+		if c := b.Counts(); c.Requests != 5 || c.Failures != 1 {
+			t.Fatalf("Counts should be 5 requests and 1 failure but it is %+v", c)
+		}
+	})
+
+	t.Run("window_resets_counts", func(t *testing.T) {
+		b, clock := newTestBreaker(settings)
+		for i := 0; i < 3; i++ {
+			record(t, b, false)
+		}
+		clock.Add(2 * time.Minute)
+		record(t, b, false)
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+		if c := b.Counts(); c.Requests != 1 {
+			t.Fatalf("Requests should be 1 but it is %d", c.Requests)
+		}
+	})
+
+	t.Run("open_half_open_closed", func(t *testing.T) {
+		var changes []string
+		s := settings
+		s.OnStateChange = func(name string, from, to State) {
+			changes = append(changes, name+":"+from.String()+"->"+to.String())
+		}
+		b, clock := newTestBreaker(s)
+
+		for i := 0; i < 4; i++ {
+			record(t, b, false)
+		}
+		if b.State() != StateOpen {
+			t.Fatalf("State should be open but it is %s", b.State())
+		}
+
+		_, err := b.Allow(context.Background())
+		if !errors.Is(err, smapperrors.ErrCircuitOpen) {
+			t.Fatalf("err should be ErrCircuitOpen but it is %v", err)
+		}
+		var openErr *OpenError
+		if !errors.As(err, &openErr) {
+			t.Fatalf("errors.As should find *OpenError")
+		}
+		if !openErr.RetryAt.Equal(clock.now.Add(10 * time.Second)) {
+			t.Fatalf("RetryAt should be %s but it is %s", clock.now.Add(10*time.Second), openErr.RetryAt)
+		}
+
+		clock.Add(11 * time.Second)
+		if b.State() != StateHalfOpen {
+			t.Fatalf("State should be half-open but it is %s", b.State())
+		}
+
+		done, err := b.Allow(context.Background())
+		if err != nil {
+			t.Fatalf("probe request should be allowed: %s", err.Error())
+		}
+		if _, err := b.Allow(context.Background()); !errors.Is(err, smapperrors.ErrCircuitOpen) {
+			t.Fatalf("second probe request should be rejected but err is %v", err)
+		}
+		done(true)
+
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+
+		expected := []string{"eta:closed->open", "eta:open->half-open", "eta:half-open->closed"}
+		if len(changes) != len(expected) {
+			t.Fatalf("state changes should be %v but it is %v", expected, changes)
+		}
+		for i := range expected {
+			if changes[i] != expected[i] {
+				t.Fatalf("state changes should be %v but it is %v", expected, changes)
+			}
+		}
+	})
+
+	t.Run("half_open_failure_reopens", func(t *testing.T) {
+		b, clock := newTestBreaker(settings)
+		for i := 0; i < 4; i++ {
+			record(t, b, false)
+		}
+		clock.Add(11 * time.Second)
+
+		record(t, b, false)
+		if b.State() != StateOpen {
+			t.Fatalf("State should be open but it is %s", b.State())
+		}
+	})
+
+	t.Run("stale_result_ignored", func(t *testing.T) {
+		b, clock := newTestBreaker(settings)
+		done, err := b.Allow(context.Background())
+		if err != nil {
+			t.Fatalf("request should be allowed: %s", err.Error())
+		}
+		clock.Add(2 * time.Minute)
+		done(false)
+
+		if c := b.Counts(); c.Requests != 0 {
+			t.Fatalf("Requests should be 0 but it is %d", c.Requests)
+		}
+	})
+}
+
+func TestState_String(t *testing.T) {
+	cases := map[State]string{
+		StateClosed:   "closed",
+		StateOpen:     "open",
+		StateHalfOpen: "half-open",
+		State(10):     "unknown",
+	}
+	for state, expected := range cases {
+		if state.String() != expected {
+			t.Fatalf("String should be %s but it is %s", expected, state.String())
+		}
+	}
+}
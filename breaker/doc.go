@@ -0,0 +1,4 @@
+// Package breaker contains an opt-in circuit breaker for service clients.
+// a Breaker is attached to a client using the `WithCircuitBreaker` constructor option of each service. when the
+// breaker is open, requests fail immediately with an *OpenError, which matches smapperrors.ErrCircuitOpen.
+package breaker
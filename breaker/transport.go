@@ -0,0 +1,59 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// Transport is a http.RoundTripper that sends requests through a Breaker.
+// Network errors and 5xx responses are counted as failures. requests cancelled by the caller
+// or rejected by a client-side rate limiter are not counted.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+	// Breaker is the circuit breaker guarding Base.
+	Breaker *Breaker
+}
+
+// NewTransport wraps base with a Transport guarded by the given breaker.
+func NewTransport(base http.RoundTripper, breaker *Breaker) *Transport {
+	return &Transport{
+		Base:    base,
+		Breaker: breaker,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx := req.Context()
+	generation, err := t.Breaker.allow(ctx)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	response, err := base.RoundTrip(req)
+	switch {
+	case err != nil && (errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, smapperrors.ErrRateLimited)):
+		// the caller gave up or the request is never sent, which says nothing about the health of the server.
+		t.Breaker.done(ctx, generation, outcomeIgnored)
+	case err != nil:
+		t.Breaker.done(ctx, generation, outcomeFailure)
+	case response.StatusCode >= http.StatusInternalServerError:
+		t.Breaker.done(ctx, generation, outcomeFailure)
+	default:
+		t.Breaker.done(ctx, generation, outcomeSuccess)
+	}
+
+	return response, err
+}
@@ -0,0 +1,91 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Run("opens_on_server_errors", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer sv.Close()
+
+		b := New(Settings{Name: "test", MinRequests: 2, CoolDown: time.Minute})
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, b)}
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(sv.URL)
+			if err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+			_ = resp.Body.Close()
+		}
+
+		_, err := client.Get(sv.URL)
+		if !errors.Is(err, smapperrors.ErrCircuitOpen) {
+			t.Fatalf("err should be ErrCircuitOpen but it is %v", err)
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+	})
+
+	t.Run("client_errors_are_success", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer sv.Close()
+
+		b := New(Settings{Name: "test", MinRequests: 2})
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, b)}
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(sv.URL)
+			if err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+			_ = resp.Body.Close()
+		}
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+		if c := b.Counts(); c.Successes != 3 {
+			t.Fatalf("Successes should be 3 but it is %d", c.Successes)
+		}
+	})
+
+	t.Run("canceled_requests_not_counted", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer sv.Close()
+
+		b := New(Settings{Name: "test", MinRequests: 1})
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, b)}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+		time.AfterFunc(10*time.Millisecond, cancel)
+		if _, err := client.Do(req); err == nil {
+			t.Fatalf("should return error")
+		}
+
+		if b.State() != StateClosed {
+			t.Fatalf("State should be closed but it is %s", b.State())
+		}
+		if c := b.Counts(); c.Requests != 0 {
+			t.Fatalf("Requests should be 0 but it is %d", c.Requests)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache stores encoded responses by key. implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value of key and whether it is found. a missing or expired key is not an error.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value for key. the value expires after ttl. a zero ttl means no expiry.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// DefaultMaxEntries is the size of an LRU if a non-positive size is passed to NewLRU.
+const DefaultMaxEntries = 10000
+
+// Stats holds the counters of an LRU.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// LRU is an in-memory Cache that evicts the least recently used entry when it is full. expired entries are removed
+// when they are read or evicted.
+type LRU struct {
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	stats   Stats
+}
+
+type entry struct {
+	key    string
+	value  []byte
+	expiry time.Time
+}
+
+// Force LRU to implement Cache at compile time
+var _ Cache = (*LRU)(nil)
+
+// NewLRU creates an LRU holding at most maxEntries entries.
+func NewLRU(maxEntries int) *LRU {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &LRU{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements Cache. it never returns an error.
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false, nil
+	}
+
+	e := element.Value.(*entry)
+	if !e.expiry.IsZero() && !c.now().Before(e.expiry) {
+		c.remove(element)
+		c.stats.Misses++
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	return e.value, true, nil
+}
+
+// Set implements Cache. it never returns an error.
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiry time.Time
+	if ttl > 0 {
+		expiry = c.now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry)
+		e.value = value
+		e.expiry = expiry
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiry: expiry})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+	return nil
+}
+
+// Delete removes key from the cache.
+func (c *LRU) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns the number of entries, including expired ones not removed yet.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Stats returns the counters of the cache.
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// remove removes element from the cache. c.mu must be held.
+func (c *LRU) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLRU(maxEntries int) (*LRU, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	c := NewLRU(maxEntries)
+	c.now = clock.Now
+	return c, clock
+}
+
+func TestNewLRU(t *testing.T) {
+	if c := NewLRU(0); c.maxEntries != DefaultMaxEntries {
+		t.Fatalf("maxEntries should be %d but it is %d", DefaultMaxEntries, c.maxEntries)
+	}
+}
+
+func TestLRU_GetSet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("hit_and_miss", func(t *testing.T) {
+		c, _ := newTestLRU(10)
+		if _, ok, _ := c.Get(ctx, "a"); ok {
+			t.Fatal("a should not be found")
+		}
+		_ = c.Set(ctx, "a", []byte("1"), 0)
+		value, ok, err := c.Get(ctx, "a")
+		if err != nil || !ok || string(value) != "1" {
+			t.Fatalf("a should be 1 but it is %q, %v, %v", value, ok, err)
+		}
+		if s := c.Stats(); s.Hits != 1 || s.Misses != 1 {
+			t.Fatalf("stats should be 1 hit and 1 miss but it is %+v", s)
+		}
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		c, clock := newTestLRU(10)
+		_ = c.Set(ctx, "a", []byte("1"), time.Minute)
+		clock.now = clock.now.Add(59 * time.Second)
+		if _, ok, _ := c.Get(ctx, "a"); !ok {
+			t.Fatal("a should be found before its ttl")
+		}
+		clock.now = clock.now.Add(time.Second)
+		if _, ok, _ := c.Get(ctx, "a"); ok {
+			t.Fatal("a should be expired")
+		}
+		if c.Len() != 0 {
+			t.Fatalf("expired entry should be removed but Len is %d", c.Len())
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		c, _ := newTestLRU(3)
+		for i := 0; i < 3; i++ {
+			_ = c.Set(ctx, strconv.Itoa(i), []byte{byte(i)}, 0)
+		}
+		// 0 becomes the most recently used entry, so 1 is evicted.
+		_, _, _ = c.Get(ctx, "0")
+		_ = c.Set(ctx, "3", []byte{3}, 0)
+
+		if _, ok, _ := c.Get(ctx, "1"); ok {
+			t.Fatal("1 should be evicted")
+		}
+		for _, key := range []string{"0", "2", "3"} {
+			if _, ok, _ := c.Get(ctx, key); !ok {
+				t.Fatalf("%s should be found", key)
+			}
+		}
+		if s := c.Stats(); s.Evictions != 1 {
+			t.Fatalf("Evictions should be 1 but it is %d", s.Evictions)
+		}
+	})
+
+	t.Run("overwrite_and_delete", func(t *testing.T) {
+		c, _ := newTestLRU(3)
+		_ = c.Set(ctx, "a", []byte("1"), 0)
+		_ = c.Set(ctx, "a", []byte("2"), 0)
+		if value, _, _ := c.Get(ctx, "a"); string(value) != "2" {
+			t.Fatalf("a should be 2 but it is %q", value)
+		}
+		if c.Len() != 1 {
+			t.Fatalf("Len should be 1 but it is %d", c.Len())
+		}
+		c.Delete("a")
+		if _, ok, _ := c.Get(ctx, "a"); ok {
+			t.Fatal("a should be deleted")
+		}
+	})
+}
@@ -0,0 +1,3 @@
+// Package cache contains the Cache interface used by response caches of service clients and LRU, an in-memory
+// implementation with a size limit and per-entry TTL. other stores, like Redis, can be used by implementing Cache.
+package cache
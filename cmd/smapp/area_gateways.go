@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+)
+
+// runAreaGateways runs `smapp area-gateways`.
+func runAreaGateways(e *env, args []string) error {
+	f := &common{}
+	var language string
+	fs := newFlagSet(e, "area-gateways", "lat,lon")
+	f.register(fs, string(area_gateways.V1))
+	fs.StringVar(&language, "language", "", "language of the response, fa or en")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("expected one lat,lon argument")
+	}
+	location, err := geo.ParseLatLng(rest[0])
+	if err != nil {
+		return usagef("%s", err)
+	}
+
+	options := area_gateways.NewDefaultCallOptions(area_gateways.WithHeaders(f.headers), area_gateways.WithTimeout(f.callTimeout))
+	if language != "" {
+		options.UseLanguage = true
+		options.Language = area_gateways.Language(language)
+	}
+
+	cfg, transport, d, err := f.setup()
+	if err != nil {
+		return err
+	}
+	client, err := area_gateways.NewAreaGatewaysClient(cfg, area_gateways.Version(f.version), f.timeout, area_gateways.WithTransport(transport))
+	if err != nil {
+		return err
+	}
+
+	area, err := client.GetGatewaysWithContext(context.Background(), location.Lat, location.Lon, options)
+	return f.write(e, d, err, area, func() table {
+		t := table{header: []string{"AREA", "GATE", "TYPE", "LAT", "LON"}}
+		if len(area.Gates) == 0 && area.Name != "" {
+			t.rows = append(t.rows, []string{area.Name, "", "", "", ""})
+		}
+		for _, gate := range area.Gates {
+			lat, lon := "", ""
+			if len(gate.Coordinates) >= 2 {
+				lat, lon = formatFloat(gate.Coordinates[1]), formatFloat(gate.Coordinates[0])
+			}
+			t.rows = append(t.rows, []string{area.Name, gate.Name, gate.Type, lat, lon})
+		}
+		return t
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+)
+
+// apiKeyVariable replaces the API key in printed curl commands, so they can be shared.
+const apiKeyVariable = "$SMAPP_API_KEY"
+
+// errDryRun is returned by dryRun instead of sending requests.
+var errDryRun = errors.New("dry run")
+
+// dryRun is an http.RoundTripper that records the first request instead of sending it.
+type dryRun struct {
+	apiKey  string
+	request *http.Request
+	body    []byte
+}
+
+// newDryRun creates a dryRun that hides apiKey in curl commands.
+func newDryRun(apiKey string) *dryRun {
+	return &dryRun{apiKey: apiKey}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (d *dryRun) RoundTrip(req *http.Request) (*http.Response, error) {
+	if d.request == nil {
+		d.request = req
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			d.body = body
+		}
+	}
+	return nil, errDryRun
+}
+
+// writeCurl writes the curl command of the recorded request to w.
+func (d *dryRun) writeCurl(w io.Writer) error {
+	_, err := fmt.Fprintln(w, d.curl())
+	return err
+}
+
+// curl returns the curl command of the recorded request. the API key is replaced with $SMAPP_API_KEY and the
+// deadline budget header is dropped, since it is only meaningful to the call that sets it.
+func (d *dryRun) curl() string {
+	words := []string{"curl", "-X", d.request.Method, d.quote(d.request.URL.String())}
+
+	keys := make([]string, 0, len(d.request.Header))
+	for key := range d.request.Header {
+		if http.CanonicalHeaderKey(key) != deadline.Header {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range d.request.Header[key] {
+			words = append(words, "-H", d.quote(key+": "+value))
+		}
+	}
+
+	if len(d.body) > 0 {
+		words = append(words, "--data-raw", d.quote(string(d.body)))
+	}
+	return strings.Join(words, " ")
+}
+
+// quote quotes word for a POSIX shell, with occurrences of the API key replaced by $SMAPP_API_KEY.
+func (d *dryRun) quote(word string) string {
+	parts := []string{word}
+	if d.apiKey != "" {
+		for _, key := range []string{d.apiKey, url.QueryEscape(d.apiKey)} {
+			var split []string
+			for _, part := range parts {
+				split = append(split, strings.Split(part, key)...)
+			}
+			parts = split
+			if len(parts) > 1 {
+				break
+			}
+		}
+	}
+
+	if word == "" {
+		return "''"
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			b.WriteString(`"` + apiKeyVariable + `"`)
+		}
+		if part != "" {
+			b.WriteString("'" + strings.ReplaceAll(part, "'", `'\''`) + "'")
+		}
+	}
+	return b.String()
+}
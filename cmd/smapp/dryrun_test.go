@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapptest"
+)
+
+func TestRun_DryRun(t *testing.T) {
+	sv := newServer(t)
+
+	t.Run("get", func(t *testing.T) {
+		stdout := mustRun(t, nil, "reverse", "-dry-run", "-header", "X-Request-ID=42", "-language", "en", "35.7,51.4")
+		if !strings.HasPrefix(stdout, "curl -X GET '"+sv.URL()+"/reverse/v1?") {
+			t.Fatalf("unexpected curl command: %s", stdout)
+		}
+		if !strings.Contains(stdout, "language=en") || !strings.Contains(stdout, "-H 'X-Request-Id: 42'") {
+			t.Fatalf("curl command should have call options: %s", stdout)
+		}
+		if strings.Contains(stdout, smapptest.DefaultAPIKey) || !strings.Contains(stdout, `-H 'X-Smapp-Key: '"$SMAPP_API_KEY"`) {
+			t.Fatalf("api key should be replaced by $SMAPP_API_KEY: %s", stdout)
+		}
+	})
+
+	t.Run("post", func(t *testing.T) {
+		stdout := mustRun(t, nil, "matrix", "-dry-run", "-post", "-source", "35.7,51.4", "-target", "35.8,51.5")
+		if !strings.HasPrefix(stdout, "curl -X POST") || !strings.Contains(stdout, `--data-raw '{"json":{"sources":[{"lat":35.7,"lon":51.4}]`) {
+			t.Fatalf("unexpected curl command: %s", stdout)
+		}
+	})
+
+	t.Run("query_api_key", func(t *testing.T) {
+		t.Setenv("SMAPP_API_KEY_SOURCE", "query")
+		stdout := mustRun(t, nil, "eta", "-dry-run", "35.7,51.4", "35.8,51.5")
+		if strings.Contains(stdout, smapptest.DefaultAPIKey) || !strings.Contains(stdout, `monshi_key='"$SMAPP_API_KEY"`) {
+			t.Fatalf("api key should be replaced by $SMAPP_API_KEY: %s", stdout)
+		}
+	})
+
+	t.Run("smappshot", func(t *testing.T) {
+		stdout := mustRun(t, nil, "smappshot", "sign", "preview", "-dry-run", "-secret", testSecret,
+			"-base-url", "https://smappshot.example.com", "-center", "35.7,51.4")
+		if !strings.HasPrefix(stdout, "curl -X GET 'https://smappshot.example.com/api/v1/photo/preview?") {
+			t.Fatalf("unexpected curl command: %s", stdout)
+		}
+	})
+
+	total := 0
+	for _, endpoint := range []smapptest.Endpoint{smapptest.EndpointReverse, smapptest.EndpointMatrix, smapptest.EndpointETA} {
+		total += sv.Requests(endpoint)
+	}
+	if total != 0 {
+		t.Fatalf("dry runs should not send requests, but %d requests are sent", total)
+	}
+}
+
+func TestDryRun_Quote(t *testing.T) {
+	d := newDryRun("secret key")
+
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "", want: "''"},
+		{word: "plain", want: "'plain'"},
+		{word: "it's", want: `'it'\''s'`},
+		{word: "X-Key: secret key", want: `'X-Key: '"$SMAPP_API_KEY"`},
+		{word: "https://a.b/?k=secret+key&x=1", want: `'https://a.b/?k='"$SMAPP_API_KEY"'&x=1'`},
+	}
+	for _, tt := range tests {
+		if got := d.quote(tt.word); got != tt.want {
+			t.Errorf("quote(%q) = %s, want %s", tt.word, got, tt.want)
+		}
+	}
+}
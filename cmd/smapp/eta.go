@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+)
+
+// runETA runs `smapp eta`.
+func runETA(e *env, args []string) error {
+	f := &common{}
+	var noTraffic bool
+	var departure, engine, polyline string
+	var precision int
+	metadata := keyValues{}
+	fs := newFlagSet(e, "eta", "lat,lon lat,lon...")
+	f.register(fs, string(eta.V1))
+	fs.BoolVar(&noTraffic, "no-traffic", false, "ignore traffic, or use it with -no-traffic=false")
+	fs.StringVar(&departure, "departure", "", "departure date time of the route")
+	fs.StringVar(&engine, "engine", "", "engine of the request, e.g. v2, nostradamus or ocelot")
+	fs.Var(metadata, "meta", "metadata of the request as key=value, can be repeated")
+	fs.StringVar(&polyline, "polyline", "", "encoded polyline of the route instead of lat,lon arguments")
+	fs.IntVar(&precision, "precision", geo.PolylinePrecision, "precision of -polyline, 5 or 6")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	var route points
+	if polyline != "" {
+		if len(rest) != 0 {
+			return usagef("unexpected arguments %q with -polyline", rest)
+		}
+		if route, err = geo.DecodePolyline(polyline, precision); err != nil {
+			return usagef("%s", err)
+		}
+	}
+	for _, arg := range rest {
+		if err := route.Set(arg); err != nil {
+			return usagef("%s", err)
+		}
+	}
+	if len(route) < 2 {
+		return usagef("expected at least two lat,lon arguments or points of -polyline")
+	}
+
+	setters := []eta.CallOptionSetter{eta.WithHeaders(f.headers), eta.WithTimeout(f.callTimeout)}
+	if isSet(fs, "no-traffic") {
+		if noTraffic {
+			setters = append(setters, eta.WithNoTraffic())
+		} else {
+			setters = append(setters, eta.WithTraffic())
+		}
+	}
+	if departure != "" {
+		setters = append(setters, eta.WithDepartureDateTime(departure))
+	}
+	if engine != "" {
+		setters = append(setters, eta.WithEngineStr(engine))
+	}
+
+	cfg, transport, d, err := f.setup()
+	if err != nil {
+		return err
+	}
+	client, err := eta.NewETAClient(cfg, eta.Version(f.version), f.timeout, eta.WithTransport(transport))
+	if err != nil {
+		return err
+	}
+
+	var meta map[string]string
+	if len(metadata) > 0 {
+		meta = metadata
+	}
+	result, err := client.GetETAWithInputMeta(context.Background(), eta.PointsFromLatLngs(route), eta.NewDefaultCallOptions(setters...), meta)
+	return f.write(e, d, err, result, func() table {
+		t := table{header: []string{"LEG", "TIME", "LENGTH"}}
+		totalTime, totalLength := 0, 0
+		for i, leg := range result.Trip.Legs {
+			t.rows = append(t.rows, []string{strconv.Itoa(i), strconv.Itoa(leg.Time), strconv.Itoa(leg.Length)})
+			totalTime += leg.Time
+			totalLength += leg.Length
+		}
+		t.rows = append(t.rows, []string{"total", strconv.Itoa(totalTime), strconv.Itoa(totalLength)})
+		return t
+	})
+}
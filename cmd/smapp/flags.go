@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// common is the flags shared by commands calling smapp services.
+type common struct {
+	output      string
+	dryRun      bool
+	timeout     time.Duration
+	callTimeout time.Duration
+	version     string
+	headers     keyValues
+}
+
+// newFlagSet creates the flag set of a command, whose errors and usage are written to e.stderr.
+func newFlagSet(e *env, name, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet("smapp "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: smapp %s [flags] %s\n\nFlags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// register defines the common flags in fs. defaultVersion is the default version of the service.
+func (c *common) register(fs *flag.FlagSet, defaultVersion string) {
+	c.headers = keyValues{}
+	fs.StringVar(&c.output, "output", outputJSON, "output format, json or table")
+	fs.BoolVar(&c.dryRun, "dry-run", false, "print the equivalent curl command instead of sending the request")
+	fs.DurationVar(&c.timeout, "timeout", defaultTimeout, "timeout of the client")
+	fs.DurationVar(&c.callTimeout, "call-timeout", 0, "timeout of the call, overriding the timeout of the client")
+	fs.StringVar(&c.version, "api-version", defaultVersion, "version of the service")
+	fs.Var(c.headers, "header", "custom header of the request as key=value, can be repeated")
+}
+
+// parse parses args into fs and validates the common flags. it returns the positional arguments.
+func (c *common) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if c.output != outputJSON && c.output != outputTable {
+		return nil, usagef("invalid output %q, should be %s or %s", c.output, outputJSON, outputTable)
+	}
+	return fs.Args(), nil
+}
+
+// setup reads the config from the environment and returns the transport for the clients of the command, which
+// records the request instead of sending it if -dry-run is set.
+func (c *common) setup() (*config.Config, http.RoundTripper, *dryRun, error) {
+	cfg, err := config.ReadFromEnvironment()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not read config from environment: %w", err)
+	}
+	if !c.dryRun {
+		return cfg, http.DefaultTransport, nil, nil
+	}
+	d := newDryRun(cfg.APIKey)
+	return cfg, d, d, nil
+}
+
+// write writes the result of a call: the curl command of the request if it is a dry run, otherwise v as JSON or
+// the table returned by t.
+func (c *common) write(e *env, d *dryRun, err error, v any, t func() table) error {
+	if d != nil && d.request != nil {
+		return d.writeCurl(e.stdout)
+	}
+	if err != nil {
+		return err
+	}
+	return writeOutput(e.stdout, c.output, v, t)
+}
+
+// parseFlags parses args into fs, with usage errors returned as *usageError.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return usagef("%s", err)
+	}
+	return nil
+}
+
+// isSet reports whether the flag name is given in the command line of fs.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// point is a flag of a location given as `lat,lon`.
+type point geo.LatLng
+
+// String implements flag.Value.
+func (p *point) String() string {
+	if p == nil {
+		return ""
+	}
+	return geo.LatLng(*p).String()
+}
+
+// Set implements flag.Value.
+func (p *point) Set(value string) error {
+	ll, err := geo.ParseLatLng(value)
+	if err != nil {
+		return err
+	}
+	*p = point(ll)
+	return nil
+}
+
+// points is a repeatable flag of `lat,lon` locations.
+type points []geo.LatLng
+
+// String implements flag.Value.
+func (ps *points) String() string {
+	if ps == nil {
+		return ""
+	}
+	values := make([]string, 0, len(*ps))
+	for _, ll := range *ps {
+		values = append(values, ll.String())
+	}
+	return strings.Join(values, " ")
+}
+
+// Set implements flag.Value.
+func (ps *points) Set(value string) error {
+	ll, err := geo.ParseLatLng(value)
+	if err != nil {
+		return err
+	}
+	*ps = append(*ps, ll)
+	return nil
+}
+
+// keyValues is a repeatable flag of `key=value` pairs.
+type keyValues map[string]string
+
+// String implements flag.Value.
+func (kv keyValues) String() string {
+	pairs := make([]string, 0, len(kv))
+	for key, value := range kv {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value.
+func (kv keyValues) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("invalid pair %q, should be key=value", value)
+	}
+	kv[key] = val
+	return nil
+}
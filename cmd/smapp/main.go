@@ -0,0 +1,114 @@
+// Command smapp calls smapp services from the command line.
+//
+// the config of services is read from environment variables, see config.ReadFromEnvironment. results are written as
+// JSON or as a table, and with -dry-run the equivalent curl command of a call is written instead of sending it.
+//
+// Usage:
+//
+//	smapp <command> [flags]
+//
+// run `smapp help` for the list of commands and `smapp <command> -h` for the flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// command is a subcommand of smapp.
+type command struct {
+	name    string
+	summary string
+	run     func(e *env, args []string) error
+}
+
+// env is the environment a command runs in.
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// usageError is returned by commands that are called with invalid arguments.
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// usagef returns a usageError with a formatted message.
+func usagef(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// commands returns the subcommands of smapp in the order they are listed in the usage.
+func commands() []command {
+	return []command{
+		{name: "reverse", summary: "get the address of a location", run: runReverse},
+		{name: "reverse-batch", summary: "get the addresses of a list of locations", run: runReverseBatch},
+		{name: "frequent", summary: "get the frequent address of a location", run: runFrequent},
+		{name: "search", summary: "search cities and places (cities, city, autocomplete, details)", run: runSearch},
+		{name: "eta", summary: "get the eta of a route", run: runETA},
+		{name: "matrix", summary: "get the eta matrix of sources and targets", run: runMatrix},
+		{name: "area-gateways", summary: "get the area and gates containing a location", run: runAreaGateways},
+		{name: "smappshot", summary: "sign and verify smappshot urls (sign ride, sign preview, verify)", run: runSmappShot},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command of args and returns the exit code: 0 on success, 1 on failures and 2 on invalid usage.
+func run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage(stdout)
+		return 0
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != name {
+			continue
+		}
+		err := cmd.run(e, args[1:])
+		var usageErr *usageError
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.As(err, &usageErr):
+			_, _ = fmt.Fprintf(stderr, "smapp %s: %s\n", name, err)
+			return 2
+		default:
+			_, _ = fmt.Fprintf(stderr, "smapp %s: %s\n", name, err)
+			return 1
+		}
+	}
+
+	_, _ = fmt.Fprintf(stderr, "smapp: unknown command %q\n", name)
+	usage(stderr)
+	return 2
+}
+
+// usage writes the usage of smapp to w.
+func usage(w io.Writer) {
+	_, _ = fmt.Fprint(w, "Usage: smapp <command> [flags]\n\nCommands:\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands() {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprint(w, "\nConfig is read from SMAPP_API_KEY, SMAPP_API_KEY_SOURCE, SMAPP_API_KEY_NAME, SMAPP_API_REGION\n"+
+		"and SMAPP_API_BASE_URL environment variables. run `smapp <command> -h` for the flags of a command.\n")
+}
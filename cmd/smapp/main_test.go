@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/smapptest"
+)
+
+const testSecret = "test-secret"
+
+// newServer starts a fake smapp server and points the config environment variables to it.
+func newServer(t *testing.T) *smapptest.Server {
+	t.Helper()
+	sv := smapptest.NewServer(t)
+	t.Setenv("SMAPP_API_KEY", smapptest.DefaultAPIKey)
+	t.Setenv("SMAPP_API_KEY_SOURCE", "")
+	t.Setenv("SMAPP_API_KEY_NAME", "")
+	t.Setenv("SMAPP_API_REGION", "")
+	t.Setenv("SMAPP_API_BASE_URL", sv.URL())
+	return sv
+}
+
+// runCommand runs smapp with args and returns its exit code and outputs.
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// mustRun runs smapp with args, fails t if it does not succeed and decodes its JSON output into v if it is not nil.
+func mustRun(t *testing.T, v any, args ...string) string {
+	t.Helper()
+	code, stdout, stderr := runCommand(args...)
+	if code != 0 {
+		t.Fatalf("smapp %s exited with %d: %s", strings.Join(args, " "), code, stderr)
+	}
+	if v != nil {
+		if err := json.Unmarshal([]byte(stdout), v); err != nil {
+			t.Fatalf("output of smapp %s is not json: %s\n%s", strings.Join(args, " "), err, stdout)
+		}
+	}
+	return stdout
+}
+
+func TestRun_Usage(t *testing.T) {
+	if code, _, stderr := runCommand(); code != 2 || !strings.Contains(stderr, "Usage: smapp") {
+		t.Fatalf("smapp without command should print usage and exit with 2, got %d: %s", code, stderr)
+	}
+	if code, stdout, _ := runCommand("help"); code != 0 || !strings.Contains(stdout, "area-gateways") {
+		t.Fatalf("smapp help should list commands and exit with 0, got %d: %s", code, stdout)
+	}
+	if code, _, stderr := runCommand("geocode"); code != 2 || !strings.Contains(stderr, `unknown command "geocode"`) {
+		t.Fatalf("unknown command should exit with 2, got %d: %s", code, stderr)
+	}
+	if code, _, _ := runCommand("eta", "-h"); code != 0 {
+		t.Fatalf("-h should exit with 0, got %d", code)
+	}
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	newServer(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "invalid output", args: []string{"reverse", "-output", "xml", "35.7,51.4"}},
+		{name: "unknown flag", args: []string{"reverse", "-unknown", "35.7,51.4"}},
+		{name: "missing point", args: []string{"reverse"}},
+		{name: "invalid point", args: []string{"area-gateways", "35.7"}},
+		{name: "invalid frequent type", args: []string{"frequent", "-type", "driver", "35.7,51.4"}},
+		{name: "single eta point", args: []string{"eta", "35.7,51.4"}},
+		{name: "matrix without targets", args: []string{"matrix", "-source", "35.7,51.4"}},
+		{name: "unknown search subcommand", args: []string{"search", "places"}},
+		{name: "unknown smappshot subcommand", args: []string{"smappshot", "sign"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _, stderr := runCommand(tt.args...); code != 2 {
+				t.Fatalf("expected exit code 2 but got %d: %s", code, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_MissingAPIKey(t *testing.T) {
+	newServer(t)
+	t.Setenv("SMAPP_API_KEY", "")
+
+	code, _, stderr := runCommand("reverse", "35.7,51.4")
+	if code != 1 || !strings.Contains(stderr, "could not read config") {
+		t.Fatalf("expected config error with exit code 1 but got %d: %s", code, stderr)
+	}
+}
+
+func TestRun_Reverse(t *testing.T) {
+	sv := newServer(t)
+	sv.AddAddress(35.7, 51.4, smapptest.Address{
+		Components: []reverse.Component{{Name: "Tehran", Type: "city"}, {Name: "Azadi", Type: "primary"}},
+		Frequent:   &reverse.FrequentAddress{Address: "Azadi Square", Strategy: reverse.PopularPOI},
+	})
+
+	t.Run("components", func(t *testing.T) {
+		var components []reverse.Component
+		mustRun(t, &components, "reverse", "-language", "en", "-zoom", "17", "35.7,51.4")
+		if len(components) != 2 || components[1].Name != "Azadi" {
+			t.Fatalf("unexpected components: %+v", components)
+		}
+	})
+
+	t.Run("display_name_table", func(t *testing.T) {
+		stdout := mustRun(t, nil, "reverse", "-display", "-output", "table", "35.7,51.4")
+		if !strings.Contains(stdout, "DISPLAY NAME") || !strings.Contains(stdout, "Tehran، Azadi") {
+			t.Fatalf("unexpected table: %s", stdout)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		var results []reverse.ResultWithDisplayName
+		mustRun(t, &results, "reverse-batch", "-display", "35.7,51.4", "35.9,51.9")
+		if len(results) != 2 || results[0].DisplayName.DisplayName != "Tehran، Azadi" || results[1].DisplayName.DisplayName != "" {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("frequent", func(t *testing.T) {
+		var address reverse.FrequentAddress
+		mustRun(t, &address, "frequent", "-type", "frequent-v2", "35.7,51.4")
+		if address.Address != "Azadi Square" {
+			t.Fatalf("unexpected frequent address: %+v", address)
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		if code, _, stderr := runCommand("reverse", "30,50"); code != 1 {
+			t.Fatalf("expected exit code 1 for missing address but got %d: %s", code, stderr)
+		}
+	})
+}
+
+func TestRun_Search(t *testing.T) {
+	sv := newServer(t)
+	sv.AddCity(smapptest.City(1, "Tehran", 35.7, 51.4), smapptest.City(2, "Karaj", 35.8, 50.9))
+	sv.AddPlaces("azadi", smapptest.Place("p1", "Azadi Tower", 35.6997, 51.338))
+
+	var cities []search.City
+	mustRun(t, &cities, "search", "cities")
+	if len(cities) != 2 {
+		t.Fatalf("expected all cities but got %+v", cities)
+	}
+
+	mustRun(t, &cities, "search", "city", "kar")
+	if len(cities) != 1 || cities[0].Name != "Karaj" {
+		t.Fatalf("unexpected cities: %+v", cities)
+	}
+
+	stdout := mustRun(t, nil, "search", "autocomplete", "-output", "table", "-location", "35.7,51.4", "-city-id", "1", "azadi")
+	if !strings.Contains(stdout, "PLACE ID") || !strings.Contains(stdout, "Azadi Tower") {
+		t.Fatalf("unexpected table: %s", stdout)
+	}
+
+	var detail search.Detail
+	mustRun(t, &detail, "search", "details", "p1")
+	if detail.Name != "Azadi Tower" || detail.Geometry.Location.Lat != 35.6997 {
+		t.Fatalf("unexpected detail: %+v", detail)
+	}
+}
+
+func TestRun_ETA(t *testing.T) {
+	newServer(t)
+
+	var result eta.ETA
+	mustRun(t, &result, "eta", "-no-traffic", "-engine", "ocelot", "-meta", "ride=1", "35.7,51.4", "35.75,51.45", "35.8,51.5")
+	if len(result.Trip.Legs) != 2 || result.Trip.Legs[0].Time == 0 {
+		t.Fatalf("unexpected eta: %+v", result)
+	}
+
+	stdout := mustRun(t, nil, "eta", "-output", "table", "35.7,51.4", "35.75,51.45")
+	if !strings.Contains(stdout, "LENGTH") || !strings.Contains(stdout, "total") {
+		t.Fatalf("unexpected table: %s", stdout)
+	}
+
+	polyline, _ := geo.EncodePolyline([]geo.LatLng{{Lat: 35.7, Lon: 51.4}, {Lat: 35.75, Lon: 51.45}, {Lat: 35.8, Lon: 51.5}}, geo.PolylinePrecision6)
+	mustRun(t, &result, "eta", "-polyline", polyline, "-precision", "6")
+	if len(result.Trip.Legs) != 2 {
+		t.Fatalf("unexpected eta of polyline: %+v", result)
+	}
+	if code, _, _ := runCommand("eta", "-polyline", "_p~iF"); code != 2 {
+		t.Fatalf("invalid polyline should be a usage error but exit code is %d", code)
+	}
+}
+
+func TestRun_Matrix(t *testing.T) {
+	sv := newServer(t)
+
+	var output matrix.Output
+	mustRun(t, &output, "matrix", "-post", "-source", "35.7,51.4", "-source", "35.71,51.41", "-target", "35.8,51.5")
+	if len(output.SourcesToTargets) != 2 || len(output.SourcesToTargets[0]) != 1 {
+		t.Fatalf("unexpected matrix: %+v", output)
+	}
+	if sv.Requests(smapptest.EndpointMatrix) != 1 {
+		t.Fatalf("expected one matrix request but got %d", sv.Requests(smapptest.EndpointMatrix))
+	}
+}
+
+func TestRun_AreaGateways(t *testing.T) {
+	sv := newServer(t)
+	sv.AddArea(area_gateways.Area{
+		ID:          "1",
+		Name:        "Hospital",
+		Coordinates: [][][]float64{{{51.40, 35.69}, {51.42, 35.69}, {51.42, 35.71}, {51.40, 35.71}, {51.40, 35.69}}},
+		Gates:       []area_gateways.Gate{{Name: "Main", Type: "Point", Coordinates: []float64{51.41, 35.70}}},
+	})
+
+	var area area_gateways.Area
+	mustRun(t, &area, "area-gateways", "-language", "en", "35.7,51.41")
+	if area.Name != "Hospital" || len(area.Gates) != 1 {
+		t.Fatalf("unexpected area: %+v", area)
+	}
+
+	stdout := mustRun(t, nil, "area-gateways", "-output", "table", "35.7,51.41")
+	if !strings.Contains(stdout, "Main") || !strings.Contains(stdout, "35.7") {
+		t.Fatalf("unexpected table: %s", stdout)
+	}
+}
+
+func TestRun_SmappShot(t *testing.T) {
+	t.Setenv(smappShotSecretVariable, testSecret)
+	t.Setenv(smappShotBaseURLVariable, "https://smappshot.example.com")
+
+	var signed map[string]string
+	mustRun(t, &signed, "smappshot", "sign", "ride", "-origin", "35.7,51.4", "-destination", "35.8,51.5", "-language", "en")
+	if !strings.Contains(signed["url"], "origin=51.4%2C35.7") {
+		t.Fatalf("signed url should have lon-first origin: %s", signed["url"])
+	}
+
+	route, _ := geo.EncodePolyline([]geo.LatLng{{Lat: 35.7, Lon: 51.4}, {Lat: 35.75, Lon: 51.4501}, {Lat: 35.8, Lon: 51.5}}, geo.PolylinePrecision)
+	mustRun(t, &signed, "smappshot", "sign", "ride", "-route", route, "-max-points", "2")
+	if !strings.Contains(signed["url"], "origin=51.4%2C35.7") || !strings.Contains(signed["url"], "destinations=51.5%2C35.8&") {
+		t.Fatalf("route should be simplified to its origin and last destination: %s", signed["url"])
+	}
+
+	var verified map[string]bool
+	mustRun(t, &verified, "smappshot", "verify", signed["url"])
+	if !verified["valid"] {
+		t.Fatalf("signed url should be valid: %+v", verified)
+	}
+
+	if code, _, stderr := runCommand("smappshot", "verify", "-secret", "another-secret", signed["url"]); code != 1 || !strings.Contains(stderr, "invalid signature") {
+		t.Fatalf("url signed by another secret should be invalid, got %d: %s", code, stderr)
+	}
+
+	mustRun(t, &signed, "smappshot", "sign", "preview", "-center", "35.7,51.4", "-zoom", "14")
+	if !strings.Contains(signed["url"], "zoom=14") {
+		t.Fatalf("unexpected preview url: %s", signed["url"])
+	}
+
+	for _, args := range [][]string{{"smappshot", "sign", "ride", "-h"}, {"smappshot", "sign", "preview", "-h"}, {"smappshot", "verify", "-h"}} {
+		code, stdout, stderr := runCommand(args...)
+		if code != 0 || strings.Contains(stdout+stderr, testSecret) {
+			t.Fatalf("help of %q should not print the secret of the environment, got %d: %s%s", args, code, stdout, stderr)
+		}
+	}
+
+	if code, _, stderr := runCommand("smappshot", "sign", "ride"); code != 1 {
+		t.Fatalf("ride without locations should fail, got %d: %s", code, stderr)
+	}
+}
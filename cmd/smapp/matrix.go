@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+)
+
+// runMatrix runs `smapp matrix`.
+func runMatrix(e *env, args []string) error {
+	f := &common{}
+	var sources, targets points
+	var noTraffic, usePost bool
+	var engine string
+	metadata := keyValues{}
+	fs := newFlagSet(e, "matrix", "")
+	f.register(fs, string(matrix.V1))
+	fs.Var(&sources, "source", "source of the matrix as lat,lon, can be repeated")
+	fs.Var(&targets, "target", "target of the matrix as lat,lon, can be repeated")
+	fs.BoolVar(&noTraffic, "no-traffic", false, "ignore traffic, or use it with -no-traffic=false")
+	fs.StringVar(&engine, "engine", "", "engine of the request, e.g. v2, nostradamus or ocelot")
+	fs.BoolVar(&usePost, "post", false, "send the request using POST, for bigger matrices")
+	fs.Var(metadata, "meta", "metadata of the request as key=value, can be repeated")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return usagef("unexpected arguments %q, use -source and -target", rest)
+	}
+	if len(sources) == 0 || len(targets) == 0 {
+		return usagef("expected at least one -source and one -target")
+	}
+
+	setters := []matrix.CallOptionSetter{matrix.WithHeaders(f.headers), matrix.WithTimeout(f.callTimeout)}
+	if isSet(fs, "no-traffic") {
+		if noTraffic {
+			setters = append(setters, matrix.WithNoTraffic())
+		} else {
+			setters = append(setters, matrix.WithTraffic())
+		}
+	}
+	if engine != "" {
+		setters = append(setters, matrix.WithEngineStr(engine))
+	}
+	if usePost {
+		setters = append(setters, matrix.WithUsePost())
+	}
+
+	cfg, transport, d, err := f.setup()
+	if err != nil {
+		return err
+	}
+	client, err := matrix.NewMatrixClient(cfg, matrix.Version(f.version), f.timeout, matrix.WithTransport(transport))
+	if err != nil {
+		return err
+	}
+
+	var meta map[string]string
+	if len(metadata) > 0 {
+		meta = metadata
+	}
+	output, err := client.GetMatrixWithInputMeta(context.Background(), matrix.PointsFromLatLngs(sources), matrix.PointsFromLatLngs(targets),
+		matrix.NewDefaultCallOptions(setters...), meta)
+	return f.write(e, d, err, output, func() table {
+		t := table{header: []string{"FROM", "TO", "TIME", "DISTANCE", "STATUS"}}
+		for _, row := range output.SourcesToTargets {
+			for _, cell := range row {
+				t.rows = append(t.rows, []string{strconv.Itoa(cell.FromIndex), strconv.Itoa(cell.ToIndex),
+					strconv.Itoa(cell.Time), strconv.Itoa(cell.Distance), cell.Status})
+			}
+		}
+		return t
+	})
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+)
+
+// reverseFlags is the flags of reverse commands.
+type reverseFlags struct {
+	common
+	zoom         int
+	responseType string
+	language     string
+	normalize    bool
+	display      bool
+}
+
+// register defines the reverse flags in fs.
+func (f *reverseFlags) register(fs *flag.FlagSet) {
+	f.common.register(fs, string(reverse.V1))
+	fs.IntVar(&f.zoom, "zoom", 16, "zoom level of the request")
+	fs.StringVar(&f.responseType, "type", "", "response type, e.g. driver, passenger, verbose, biker, origin or destination")
+	fs.StringVar(&f.language, "language", "", "language of the response, fa, en, ar or ckb")
+	fs.BoolVar(&f.normalize, "normalize", false, "normalize the response")
+}
+
+// callOptions returns the call options of the flags. zoom is used only if it is set in fs.
+func (f *reverseFlags) callOptions(fs *flag.FlagSet) reverse.CallOptions {
+	options := reverse.NewDefaultCallOptions(reverse.WithHeaders(f.headers), reverse.WithTimeout(f.callTimeout))
+	if isSet(fs, "zoom") {
+		options.UseZoomLevel = true
+		options.ZoomLevel = f.zoom
+	}
+	if f.responseType != "" {
+		options.UseResponseType = true
+		options.ResponseType = reverse.ResponseType(f.responseType)
+	}
+	if f.language != "" {
+		options.UseLanguage = true
+		options.Language = reverse.Language(f.language)
+	}
+	options.Normalize = f.normalize
+	return options
+}
+
+// client creates the reverse client of the flags.
+func (f *reverseFlags) client() (*reverse.Client, *dryRun, error) {
+	cfg, transport, d, err := f.setup()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := reverse.NewReverseClient(cfg, reverse.Version(f.version), f.timeout, reverse.WithTransport(transport))
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, d, nil
+}
+
+// runReverse runs `smapp reverse`.
+func runReverse(e *env, args []string) error {
+	f := &reverseFlags{}
+	var location point
+	fs := newFlagSet(e, "reverse", "lat,lon")
+	f.register(fs)
+	fs.BoolVar(&f.display, "display", false, "get the display name of the address instead of its components")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("expected one lat,lon argument")
+	}
+	if err := location.Set(rest[0]); err != nil {
+		return usagef("%s", err)
+	}
+
+	client, d, err := f.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	options := f.callOptions(fs)
+
+	if f.display {
+		displayName, err := client.GetDisplayNameWithContext(ctx, location.Lat, location.Lon, options)
+		return f.write(e, d, err, reverse.DisplayName{DisplayName: displayName}, func() table {
+			return table{header: []string{"DISPLAY NAME"}, rows: [][]string{{displayName}}}
+		})
+	}
+
+	components, err := client.GetComponentsWithContext(ctx, location.Lat, location.Lon, options)
+	return f.write(e, d, err, components, func() table {
+		return componentsTable(components)
+	})
+}
+
+// runReverseBatch runs `smapp reverse-batch`.
+func runReverseBatch(e *env, args []string) error {
+	f := &reverseFlags{}
+	fs := newFlagSet(e, "reverse-batch", "lat,lon...")
+	f.register(fs)
+	fs.BoolVar(&f.display, "display", false, "get the display names of the addresses instead of their components")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return usagef("expected at least one lat,lon argument")
+	}
+	var locations points
+	for _, arg := range rest {
+		if err := locations.Set(arg); err != nil {
+			return usagef("%s", err)
+		}
+	}
+
+	client, d, err := f.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	options := f.callOptions(fs)
+
+	request := reverse.BatchReverseRequest{Requests: make([]reverse.Request, 0, len(locations))}
+	for i, location := range locations {
+		req := reverse.Request{Lat: location.Lat, Lon: location.Lon, ID: int32(i), Display: strconv.FormatBool(f.display)}
+		if options.UseResponseType {
+			req.Type = options.ResponseType
+		}
+		if options.UseLanguage {
+			req.Language = options.Language
+		}
+		if options.UseZoomLevel {
+			req.Zoom = float64(options.ZoomLevel)
+		}
+		if options.Normalize {
+			req.Normalize = "true"
+		}
+		request.Requests = append(request.Requests, req)
+	}
+
+	if f.display {
+		results, err := client.GetBatchDisplayNameWithContext(ctx, request)
+		return f.write(e, d, err, results, func() table {
+			t := table{header: []string{"ID", "DISPLAY NAME"}}
+			for _, result := range results {
+				t.rows = append(t.rows, []string{strconv.Itoa(result.ID), result.DisplayName.DisplayName})
+			}
+			return t
+		})
+	}
+
+	results, err := client.GetBatchWithContext(ctx, request)
+	return f.write(e, d, err, results, func() table {
+		t := table{header: []string{"ID", "COMPONENTS"}}
+		for _, result := range results {
+			names := make([]string, 0, len(result.Result.Components))
+			for _, component := range result.Result.Components {
+				names = append(names, component.Name)
+			}
+			t.rows = append(t.rows, []string{strconv.Itoa(result.ID), strings.Join(names, ", ")})
+		}
+		return t
+	})
+}
+
+// runFrequent runs `smapp frequent`.
+func runFrequent(e *env, args []string) error {
+	f := &reverseFlags{}
+	var location point
+	fs := newFlagSet(e, "frequent", "lat,lon")
+	f.register(fs)
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("expected one lat,lon argument")
+	}
+	if err := location.Set(rest[0]); err != nil {
+		return usagef("%s", err)
+	}
+	if f.responseType == "" {
+		f.responseType = string(reverse.Frequent)
+	}
+	if !reverse.ResponseType(f.responseType).IsValidFrequentType() {
+		return usagef("invalid type %q, should be %s or %s", f.responseType, reverse.Frequent, reverse.Frequent_V2)
+	}
+
+	client, d, err := f.client()
+	if err != nil {
+		return err
+	}
+
+	address, err := client.GetFrequentWithContext(context.Background(), location.Lat, location.Lon, f.callOptions(fs))
+	return f.write(e, d, err, address, func() table {
+		return table{
+			header: []string{"FIELD", "VALUE"},
+			rows: [][]string{
+				{"address", address.Address},
+				{"address_en", address.EnglishAddress},
+				{"address_ckb", address.KurdishAddress},
+				{"shortname", address.Shortname},
+				{"shortname_en", address.EnglishShortname},
+				{"shortname_ckb", address.KurdishShortname},
+				{"strategy", string(address.Strategy)},
+			},
+		}
+	})
+}
+
+// componentsTable returns the table of address components.
+func componentsTable(components []reverse.Component) table {
+	t := table{header: []string{"TYPE", "NAME"}}
+	for _, component := range components {
+		t.rows = append(t.rows, []string{component.Type, component.Name})
+	}
+	return t
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+)
+
+// searchSubcommands is the subcommands of `smapp search` and their positional arguments.
+var searchSubcommands = map[string]string{
+	"cities":       "",
+	"city":         "input",
+	"autocomplete": "input",
+	"details":      "place-id",
+}
+
+// runSearch runs `smapp search`.
+func runSearch(e *env, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected a subcommand: cities, city, autocomplete or details")
+	}
+	sub := args[0]
+	arguments, ok := searchSubcommands[sub]
+	if !ok {
+		return usagef("unknown subcommand %q, should be cities, city, autocomplete or details", sub)
+	}
+
+	f := &common{}
+	var location, userLocation point
+	var language, requestContext string
+	var cityID int
+	fs := newFlagSet(e, "search "+sub, arguments)
+	f.register(fs, search.V1)
+	fs.Var(&location, "location", "location of the request as lat,lon")
+	fs.StringVar(&language, "language", "", "language of the response, fa or en")
+	fs.StringVar(&requestContext, "context", "", "context of the request, origin, favourite, destination1 or destination2")
+	fs.Var(&userLocation, "user-location", "location of the user as lat,lon")
+	fs.IntVar(&cityID, "city-id", 0, "id of the city of the request")
+	rest, err := f.parse(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if arguments == "" && len(rest) != 0 {
+		return usagef("unexpected arguments %q", rest)
+	}
+	if arguments != "" && len(rest) == 0 {
+		return usagef("expected %s argument", arguments)
+	}
+	input := strings.Join(rest, " ")
+
+	setters := []search.CallOptionSetter{search.WithHeaders(f.headers), search.WithTimeout(f.callTimeout)}
+	if isSet(fs, "location") {
+		setters = append(setters, search.WithLocation(location.Lat, location.Lon))
+	}
+	if isSet(fs, "user-location") {
+		setters = append(setters, search.WithUserLocation(userLocation.Lat, userLocation.Lon))
+	}
+	if isSet(fs, "city-id") {
+		setters = append(setters, search.WithCityId(cityID))
+	}
+	options := search.NewDefaultCallOptions(setters...)
+	if language != "" {
+		options.UseLanguage = true
+		options.Language = search.Language(language)
+	}
+	if requestContext != "" {
+		options.UseRequestContext = true
+		options.RequestContext = search.RequestContext(requestContext)
+	}
+
+	cfg, transport, d, err := f.setup()
+	if err != nil {
+		return err
+	}
+	client, err := search.NewSearchClient(cfg, search.Version(f.version), f.timeout, search.WithTransport(transport))
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch sub {
+	case "cities", "city":
+		var cities []search.City
+		if sub == "cities" {
+			cities, err = client.GetCitiesWithContext(ctx, options)
+		} else {
+			cities, err = client.SearchCityWithContext(ctx, input, options)
+		}
+		return f.write(e, d, err, cities, func() table {
+			t := table{header: []string{"ID", "NAME", "LAT", "LON"}}
+			for _, city := range cities {
+				t.rows = append(t.rows, []string{strconv.Itoa(city.ID), city.Name, city.Centroid.Latitude, city.Centroid.Longitude})
+			}
+			return t
+		})
+	case "autocomplete":
+		results, err := client.AutoCompleteWithContext(ctx, input, options)
+		return f.write(e, d, err, results, func() table {
+			t := table{header: []string{"PLACE ID", "NAME", "DESCRIPTION", "LAT", "LON"}}
+			for _, result := range results {
+				t.rows = append(t.rows, []string{result.PlaceID, result.Name, result.Description, result.Location.Latitude, result.Location.Longitude})
+			}
+			return t
+		})
+	default:
+		detail, err := client.DetailsWithContext(ctx, input, options)
+		return f.write(e, d, err, detail, func() table {
+			return table{
+				header: []string{"NAME", "LAT", "LON"},
+				rows:   [][]string{{detail.Name, formatFloat(detail.Geometry.Location.Lat), formatFloat(detail.Geometry.Location.Lng)}},
+			}
+		})
+	}
+}
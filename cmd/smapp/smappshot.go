@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	"github.com/snapp-incubator/smapp-sdk-go/services/smappshot"
+)
+
+const (
+	// smappShotBaseURLVariable is the environment variable of the default base url of smappshot commands.
+	smappShotBaseURLVariable = "SMAPP_SHOT_BASE_URL"
+	// smappShotSecretVariable is the environment variable of the default secret of smappshot commands.
+	smappShotSecretVariable = "SMAPP_SHOT_SECRET"
+)
+
+// smappShotFlags is the flags of smappshot commands.
+type smappShotFlags struct {
+	output  string
+	dryRun  bool
+	baseURL string
+	secret  string
+	version string
+	width   int
+	height  int
+	expiry  time.Duration
+	lang    string
+	style   string
+	tenant  string
+}
+
+// register defines the smappshot flags in fs. builder flags are defined only if withBuilder is true.
+func (f *smappShotFlags) register(fs *flag.FlagSet, withBuilder bool) {
+	fs.StringVar(&f.output, "output", outputJSON, "output format, json or table")
+	// the secret of the environment is read in parse, so it is not printed as the default in the help of commands.
+	fs.StringVar(&f.secret, "secret", "", "secret of signatures, default is $"+smappShotSecretVariable)
+	if !withBuilder {
+		return
+	}
+	fs.BoolVar(&f.dryRun, "dry-run", false, "print the curl command of the signed url")
+	fs.StringVar(&f.baseURL, "base-url", os.Getenv(smappShotBaseURLVariable), "base url of smappshot, default is $"+smappShotBaseURLVariable)
+	fs.StringVar(&f.version, "api-version", string(smappshot.V1), "version of smappshot, v1 or v2")
+	fs.IntVar(&f.width, "width", 0, "width of the image, default is 512")
+	fs.IntVar(&f.height, "height", 0, "height of the image, default is 285")
+	fs.DurationVar(&f.expiry, "expiry", 10*time.Minute, "how long the signed url remains valid")
+	fs.StringVar(&f.lang, "language", "", "language of the map, fa, en, ar or ku")
+	fs.StringVar(&f.style, "style", "", "style of the map")
+	fs.StringVar(&f.tenant, "tenant", "", "tenant of smappshot, baly-iq or baly-lbn")
+}
+
+// parse parses args into fs and validates the flags. it returns the positional arguments.
+func (f *smappShotFlags) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if f.output != outputJSON && f.output != outputTable {
+		return nil, usagef("invalid output %q, should be %s or %s", f.output, outputJSON, outputTable)
+	}
+	if f.secret == "" {
+		f.secret = os.Getenv(smappShotSecretVariable)
+	}
+	if f.secret == "" {
+		return nil, usagef("secret is empty, set -secret or $%s", smappShotSecretVariable)
+	}
+	return fs.Args(), nil
+}
+
+// writeURL writes a signed url, or its curl command if it is a dry run.
+func (f *smappShotFlags) writeURL(e *env, signedURL string) error {
+	if f.dryRun {
+		_, err := fmt.Fprintf(e.stdout, "curl -X GET %s\n", (&dryRun{}).quote(signedURL))
+		return err
+	}
+	return writeOutput(e.stdout, f.output, map[string]string{"url": signedURL}, func() table {
+		return table{header: []string{"URL"}, rows: [][]string{{signedURL}}}
+	})
+}
+
+// runSmappShot runs `smapp smappshot`.
+func runSmappShot(e *env, args []string) error {
+	switch {
+	case len(args) >= 2 && args[0] == "sign" && args[1] == "ride":
+		return runSmappShotRide(e, args[2:])
+	case len(args) >= 2 && args[0] == "sign" && args[1] == "preview":
+		return runSmappShotPreview(e, args[2:])
+	case len(args) >= 1 && args[0] == "verify":
+		return runSmappShotVerify(e, args[1:])
+	}
+	return usagef("expected a subcommand: sign ride, sign preview or verify")
+}
+
+// runSmappShotRide runs `smapp smappshot sign ride`.
+func runSmappShotRide(e *env, args []string) error {
+	f := &smappShotFlags{}
+	var here, origin point
+	var destinations points
+	var markerType, precision, maxPoints int
+	var route string
+	fs := newFlagSet(e, "smappshot sign ride", "")
+	f.register(fs, true)
+	fs.Var(&here, "here", "single location of the image as lat,lon")
+	fs.Var(&origin, "origin", "origin of the route as lat,lon")
+	fs.Var(&destinations, "destination", "destination of the route as lat,lon, can be repeated")
+	fs.StringVar(&route, "route", "", "encoded polyline of the route, its first point is the origin and others are destinations")
+	fs.IntVar(&precision, "precision", geo.PolylinePrecision, "precision of -route, 5 or 6")
+	fs.IntVar(&maxPoints, "max-points", 0, "simplify -route to at most this many points, default is no simplification")
+	fs.IntVar(&markerType, "marker-type", int(smappshot.MarkerTypeRideHistory), "marker type, 0 for ride history and 1 for location share")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return usagef("unexpected arguments %q", rest)
+	}
+
+	builder := smappshot.NewRideRequestBuilder(f.baseURL, f.secret, smappshot.Version(f.version)).
+		WithWidth(f.width).
+		WithHeight(f.height).
+		WithExpiry(f.expiry).
+		WithLanguage(smappshot.Language(f.lang)).
+		WithStyle(f.style).
+		WithTenant(smappshot.Tenant(f.tenant)).
+		WithMarkerType(smappshot.MarkerType(markerType))
+	if isSet(fs, "here") {
+		builder.WithHere(smappshot.LocationFromLatLng(geo.LatLng(here)))
+	}
+	if isSet(fs, "origin") {
+		builder.WithOrigin(smappshot.LocationFromLatLng(geo.LatLng(origin)))
+	}
+	if len(destinations) > 0 {
+		builder.WithDestinations(smappshot.LocationsFromLatLngs(destinations))
+	}
+	if route != "" {
+		if maxPoints > 0 {
+			lls, err := geo.DecodePolyline(route, precision)
+			if err != nil {
+				return usagef("%s", err)
+			}
+			if route, err = geo.EncodePolyline(geo.SimplifyN(lls, maxPoints), precision); err != nil {
+				return err
+			}
+		}
+		builder.WithRoutePolyline(route, precision)
+	}
+
+	signedURL, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	return f.writeURL(e, signedURL)
+}
+
+// runSmappShotPreview runs `smapp smappshot sign preview`.
+func runSmappShotPreview(e *env, args []string) error {
+	f := &smappShotFlags{}
+	var center point
+	var zoom int
+	fs := newFlagSet(e, "smappshot sign preview", "")
+	f.register(fs, true)
+	fs.Var(&center, "center", "center of the image as lat,lon")
+	fs.IntVar(&zoom, "zoom", 0, "zoom level of the image, default is 12")
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return usagef("unexpected arguments %q", rest)
+	}
+
+	builder := smappshot.NewPreviewRequestBuilder(f.baseURL, f.secret, smappshot.Version(f.version)).
+		WithWidth(f.width).
+		WithHeight(f.height).
+		WithExpiry(f.expiry).
+		WithLanguage(smappshot.Language(f.lang)).
+		WithStyle(f.style).
+		WithTenant(smappshot.Tenant(f.tenant)).
+		WithZoom(zoom)
+	if isSet(fs, "center") {
+		builder.WithCenter(smappshot.LocationFromLatLng(geo.LatLng(center)))
+	}
+
+	signedURL, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	return f.writeURL(e, signedURL)
+}
+
+// runSmappShotVerify runs `smapp smappshot verify`.
+func runSmappShotVerify(e *env, args []string) error {
+	f := &smappShotFlags{}
+	fs := newFlagSet(e, "smappshot verify", "url")
+	f.register(fs, false)
+	rest, err := f.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("expected one url argument")
+	}
+
+	if err := smappshot.Verify(strings.TrimSpace(rest[0]), f.secret, time.Now()); err != nil {
+		return err
+	}
+	return writeOutput(e.stdout, f.output, map[string]bool{"valid": true}, func() table {
+		return table{header: []string{"VALID"}, rows: [][]string{{"true"}}}
+	})
+}
@@ -0,0 +1,4 @@
+// Package coalesce contains a http.RoundTripper that collapses identical concurrent requests into one.
+// it is enabled using the `WithRequestCoalescing` constructor option of each service. only idempotent requests, as
+// reported by retry.IsIdempotent, are coalesced.
+package coalesce
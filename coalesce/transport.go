@@ -0,0 +1,214 @@
+package coalesce
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+// SharedEventName is the name of the span event added to requests answered by the response of another request.
+const SharedEventName = "coalesce.shared"
+
+// errNoGetBody is returned for requests whose body can not be read without consuming it.
+var errNoGetBody = errors.New("coalesce: request body can not be read again")
+
+// Transport is a http.RoundTripper that sends identical concurrent requests once and gives each of them a copy of
+// the response. requests are identical if their keys are equal. by default, the key is RequestKey, so requests are
+// identical if their method, url, headers and body are equal.
+//
+// the shared request is not cancelled when the caller that started it gives up, as long as other callers are waiting
+// for it. it is cancelled once all callers have given up, or when the deadline of the caller that started it is
+// exceeded. each caller returns as soon as its own context is done.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+	// Key returns the key of a request. it can put different requests in one bucket, e.g. requests of nearby
+	// locations using geo.CellKey, and they are answered by the response of the request that started the bucket.
+	// requests whose key can not be computed are sent without coalescing. RequestKey is used if it is nil.
+	Key func(req *http.Request) (string, error)
+
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// NewTransport wraps base with a Transport.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		Base: base,
+	}
+}
+
+type call struct {
+	done    chan struct{}
+	waiters int
+	cancel  context.CancelFunc
+
+	response *http.Response
+	body     []byte
+	err      error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if !retry.IsIdempotent(req) {
+		return base.RoundTrip(req)
+	}
+
+	keyFunc := t.Key
+	if keyFunc == nil {
+		keyFunc = RequestKey
+	}
+	key, err := keyFunc(req)
+	if err != nil {
+		return base.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	if t.calls == nil {
+		t.calls = make(map[string]*call)
+	}
+	c, shared := t.calls[key]
+	if !shared {
+		ctx, cancel := sharedContext(req.Context())
+		sharedReq := req.Clone(ctx)
+		if req.Body != nil && req.Body != http.NoBody {
+			// the body of req is closed below, so the shared request needs its own.
+			sharedReq.Body, err = req.GetBody()
+			if err != nil {
+				t.mu.Unlock()
+				cancel()
+				return base.RoundTrip(req)
+			}
+		}
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		t.calls[key] = c
+		go t.do(base, key, c, sharedReq)
+	}
+	c.waiters++
+	t.mu.Unlock()
+
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	select {
+	case <-c.done:
+		if c.err != nil {
+			return nil, c.err
+		}
+		if shared {
+			trace.SpanFromContext(req.Context()).AddEvent(SharedEventName)
+		}
+		return c.copyResponse(req), nil
+	case <-req.Context().Done():
+		t.leave(key, c)
+		return nil, req.Context().Err()
+	}
+}
+
+// do sends the shared request and reads its whole body, so it can be copied for each caller.
+func (t *Transport) do(base http.RoundTripper, key string, c *call, req *http.Request) {
+	defer c.cancel()
+
+	response, err := base.RoundTrip(req)
+	if err == nil {
+		c.body, err = io.ReadAll(response.Body)
+		_ = response.Body.Close()
+	}
+	c.response, c.err = response, err
+
+	t.mu.Lock()
+	if t.calls[key] == c {
+		delete(t.calls, key)
+	}
+	t.mu.Unlock()
+	close(c.done)
+}
+
+// leave removes a caller who gave up from c, and cancels c if no one is waiting for it.
+func (t *Transport) leave(key string, c *call) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	if t.calls[key] == c {
+		delete(t.calls, key)
+	}
+	c.cancel()
+}
+
+// sharedContext returns the context of a shared request started by a caller with ctx. it keeps the values and the
+// deadline of ctx, so the request is bounded by the budget it is sent with, but it is not cancelled with ctx.
+func sharedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	shared := context.WithoutCancel(ctx)
+	if d, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(shared, d)
+	}
+	return context.WithCancel(shared)
+}
+
+func (c *call) copyResponse(req *http.Request) *http.Response {
+	response := *c.response
+	response.Header = c.response.Header.Clone()
+	response.Trailer = c.response.Trailer.Clone()
+	response.Body = io.NopCloser(bytes.NewReader(c.body))
+	response.ContentLength = int64(len(c.body))
+	response.Request = req
+	return &response
+}
+
+// RequestKey returns a key that is equal for requests with equal method, url, headers and body. deadline.Header is left
+// out, as it is different for almost every caller, and the shared request is sent with the budget of the caller that
+// started it. it returns an error if the body can not be read without consuming it.
+func RequestKey(req *http.Request) (string, error) {
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.Method+" "+req.URL.String()+"\n")
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if http.CanonicalHeaderKey(name) != deadline.Header {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = io.WriteString(h, name+": "+strings.Join(req.Header[name], ",")+"\n")
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return "", errNoGetBody
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, body)
+		_ = body.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
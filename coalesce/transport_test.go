@@ -0,0 +1,392 @@
+package coalesce
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+// waitForWaiters waits until the only in-flight call of tr has n waiters.
+func waitForWaiters(t *testing.T, tr *Transport, n int) {
+	t.Helper()
+	until := time.Now().Add(2 * time.Second)
+	for time.Now().Before(until) {
+		tr.mu.Lock()
+		waiters := 0
+		for _, c := range tr.calls {
+			waiters = c.waiters
+		}
+		tr.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("call should have %d waiters", n)
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Run("identical_requests", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			w.Header().Set("X-Path", r.URL.Path)
+			_, _ = w.Write([]byte(`shared body`))
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		const callers = 10
+		var wg sync.WaitGroup
+		bodies := make([]string, callers)
+		errs := make([]error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp, err := client.Get(sv.URL + "/a?x=1")
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer resp.Body.Close()
+				body, _ := io.ReadAll(resp.Body)
+				bodies[i] = string(body) + resp.Header.Get("X-Path")
+			}(i)
+		}
+		waitForWaiters(t, tr, callers)
+		close(release)
+		wg.Wait()
+
+		for i := 0; i < callers; i++ {
+			if errs[i] != nil {
+				t.Fatalf("should not return error: %s", errs[i].Error())
+			}
+			if bodies[i] != "shared body/a" {
+				t.Fatalf("each caller should receive the whole response but it is %q", bodies[i])
+			}
+		}
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+		if len(tr.calls) != 0 {
+			t.Fatalf("finished calls should be removed but there are %d calls", len(tr.calls))
+		}
+	})
+
+	t.Run("different_deadline_budgets", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		const callers = 5
+		var wg sync.WaitGroup
+		errs := make([]error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				req, _ := http.NewRequest(http.MethodGet, sv.URL+"/a?x=1", nil)
+				req.Header.Set(deadline.Header, strconv.Itoa(1000-i*10))
+				resp, err := client.Do(req)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				_ = resp.Body.Close()
+			}(i)
+		}
+		waitForWaiters(t, tr, callers)
+		close(release)
+		wg.Wait()
+
+		for i := 0; i < callers; i++ {
+			if errs[i] != nil {
+				t.Fatalf("should not return error: %s", errs[i].Error())
+			}
+		}
+		if calls != 1 {
+			t.Fatalf("requests differing only in %s should be sent once but server is called %d times", deadline.Header, calls)
+		}
+	})
+
+	t.Run("key", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			_, _ = w.Write([]byte(r.URL.RawQuery))
+		}))
+		defer sv.Close()
+
+		// requests of locations in the same cell share a request.
+		tr := NewTransport(http.DefaultTransport)
+		tr.Key = func(req *http.Request) (string, error) {
+			ll, err := geo.ParseLatLng(req.URL.Query().Get("location"))
+			if err != nil {
+				return "", err
+			}
+			return req.URL.Path + ":" + geo.CellKey(ll, 7), nil
+		}
+		client := http.Client{Transport: tr}
+
+		get := func(location string, result chan<- string) {
+			resp, err := client.Get(sv.URL + "/reverse?location=" + location)
+			if err != nil {
+				result <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			result <- string(b)
+		}
+
+		results := make(chan string, 3)
+		go get("35.70001,51.40001", results)
+		waitForWaiters(t, tr, 1)
+		go get("35.70002,51.40002", results)
+		waitForWaiters(t, tr, 2)
+		// the key can not be computed, so it is sent as is.
+		go get("invalid", results)
+		close(release)
+
+		bodies := map[string]int{}
+		for i := 0; i < 3; i++ {
+			bodies[<-results]++
+		}
+		if bodies["location=35.70001,51.40001"] != 2 || bodies["location=invalid"] != 1 {
+			t.Fatalf("callers in the same cell should get the response of the first one but bodies are %v", bodies)
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+	})
+
+	t.Run("different_requests", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport)}
+		for _, path := range []string{"/a", "/b", "/a?x=2"} {
+			resp, err := client.Get(sv.URL + path)
+			if err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+			_ = resp.Body.Close()
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, sv.URL+"/a", nil)
+		req.Header.Set("Accept-Language", "en")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+
+		if calls != 4 {
+			t.Fatalf("server should be called 4 times but it is called %d times", calls)
+		}
+	})
+
+	t.Run("first_caller_cancelled", func(t *testing.T) {
+		release := make(chan struct{})
+		serverCancelled := make(chan struct{}, 1)
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+				_, _ = w.Write([]byte(`ok`))
+			case <-r.Context().Done():
+				serverCancelled <- struct{}{}
+			}
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+			_, err := client.Do(req)
+			firstErr <- err
+		}()
+		waitForWaiters(t, tr, 1)
+
+		secondBody := make(chan string, 1)
+		go func() {
+			resp, err := client.Get(sv.URL)
+			if err != nil {
+				secondBody <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			secondBody <- string(body)
+		}()
+		waitForWaiters(t, tr, 2)
+
+		cancel()
+		if err := <-firstErr; !errors.Is(err, context.Canceled) {
+			t.Fatalf("cancelled caller should return context.Canceled but it is %v", err)
+		}
+
+		close(release)
+		if body := <-secondBody; body != "ok" {
+			t.Fatalf("waiting caller should receive the response but it is %q", body)
+		}
+		select {
+		case <-serverCancelled:
+			t.Fatal("shared request should not be cancelled while a caller is waiting")
+		default:
+		}
+	})
+
+	t.Run("all_callers_cancelled", func(t *testing.T) {
+		serverCancelled := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			close(serverCancelled)
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+		if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be context.DeadlineExceeded but it is %v", err)
+		}
+
+		select {
+		case <-serverCancelled:
+		case <-time.After(2 * time.Second):
+			t.Fatal("shared request should be cancelled when all callers give up")
+		}
+	})
+
+	t.Run("first_caller_deadline", func(t *testing.T) {
+		serverCancelled := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+				close(serverCancelled)
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		firstErr := make(chan error, 1)
+		go func() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+			_, err := client.Do(req)
+			firstErr <- err
+		}()
+		waitForWaiters(t, tr, 1)
+
+		// the second caller has no deadline, but the shared request is bounded by the deadline of the first one.
+		secondErr := make(chan error, 1)
+		go func() {
+			_, err := client.Get(sv.URL)
+			secondErr <- err
+		}()
+		waitForWaiters(t, tr, 2)
+
+		if err := <-firstErr; !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be context.DeadlineExceeded but it is %v", err)
+		}
+		select {
+		case err := <-secondErr:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("waiting caller should get the error of the shared request but it is %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("shared request should end at the deadline of the caller that started it")
+		}
+		select {
+		case <-serverCancelled:
+		case <-time.After(2 * time.Second):
+			t.Fatal("shared request should be cancelled at the deadline of the caller that started it")
+		}
+	})
+
+	t.Run("post", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			body, _ := io.ReadAll(r.Body)
+			<-release
+			_, _ = w.Write(body)
+		}))
+		defer sv.Close()
+
+		tr := NewTransport(http.DefaultTransport)
+		client := http.Client{Transport: tr}
+
+		post := func(ctx context.Context, body string, result chan<- string) {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, sv.URL, bytes.NewBufferString(body))
+			resp, err := client.Do(req)
+			if err != nil {
+				result <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			result <- string(b)
+		}
+
+		results := make(chan string, 4)
+		idempotent := retry.WithIdempotent(context.Background())
+		go post(idempotent, "a", results)
+		waitForWaiters(t, tr, 1)
+		go post(idempotent, "a", results)
+		waitForWaiters(t, tr, 2)
+		// not idempotent, so it is sent as is.
+		go post(context.Background(), "a", results)
+		close(release)
+
+		for i := 0; i < 3; i++ {
+			if body := <-results; body != "a" {
+				t.Fatalf("body should be a but it is %q", body)
+			}
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+	})
+}
@@ -3,6 +3,11 @@ package config
 import (
 	"os"
 	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/credentials"
+	"github.com/snapp-incubator/smapp-sdk-go/failover"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
 )
 
 type APIKeySource string
@@ -27,12 +32,26 @@ type Config struct {
 	Region string
 	// APIKey is the key required to authenticating to different services
 	APIKey string
+	// CredentialProvider, if set, provides the API key of each request instead of APIKey, so keys can be rotated
+	// without rebuilding clients.
+	CredentialProvider credentials.Provider
 	// APIKeySource is for defining the source of APIKey in each request. it can be header or query params.
 	APIKeySource APIKeySource
 	// APIKeyName is used as key of authentication in requests.
 	APIKeyName string
 	// APIBaseURL is the base url of all smapp services.
 	APIBaseURL string
+	// RetryPolicy is the default retry policy of clients built from this config. nil disables retries.
+	RetryPolicy *retry.Policy
+	// RateLimiter is the rate limiter shared by clients built from this config. nil disables rate limiting.
+	RateLimiter *ratelimit.Limiter
+	// Regions is the ordered list of regions to fail over between. if set, the first one overrides Region and APIBaseURL.
+	Regions []failover.Region
+	// FailoverSettings specifies when a region of Regions is considered unhealthy.
+	FailoverSettings failover.Settings
+	// Failover keeps the health of Regions and is shared by clients built from this config. it is built by the
+	// constructors of config if Regions is set.
+	Failover *failover.Failover
 }
 
 func (c *Config) setDefaults() error {
@@ -53,6 +72,14 @@ func (c *Config) setDefaults() error {
 		}
 	}
 
+	if len(c.Regions) > 0 {
+		c.Region = c.Regions[0].Name
+		c.APIBaseURL = c.Regions[0].BaseURL
+		if c.Failover == nil {
+			c.Failover = failover.New(c.Regions, c.FailoverSettings)
+		}
+	}
+
 	if c.APIBaseURL == "" {
 		if c.Region == "" {
 			c.Region = DefaultRegion
@@ -64,7 +91,7 @@ func (c *Config) setDefaults() error {
 }
 
 // ReadFromEnvironment helps reading a config from Environment variables. you can pass different options to override each field of config.
-// This function returns an error if no APIKey is defined
+// This function returns an error if no APIKey or CredentialProvider is defined
 // these Environment variables are used to fill a config:
 //
 //	`SMAPP_API_KEY` for APIKey
@@ -90,7 +117,7 @@ func ReadFromEnvironment(opts ...Option) (*Config, error) {
 		opt(config)
 	}
 
-	if apiKey == "" {
+	if config.APIKey == "" && config.CredentialProvider == nil {
 		return nil, ErrEmptyAPIKey
 	}
 
@@ -103,7 +130,7 @@ func ReadFromEnvironment(opts ...Option) (*Config, error) {
 }
 
 // NewDefaultConfig creates a default config. you can pass different options to override each field of config.
-// This function returns an error if no apiKey is defined
+// This function returns an error if no apiKey or CredentialProvider is defined
 // these are default values of each config field:
 //
 //	Region: teh-1
@@ -111,10 +138,6 @@ func ReadFromEnvironment(opts ...Option) (*Config, error) {
 //	APIKeyName: X-Monshi-Key
 //	APIBaseURL: http://smapp-api.apps.inter-dc.teh-1.snappcloud.io
 func NewDefaultConfig(apiKey string, opts ...Option) (*Config, error) {
-	if apiKey == "" {
-		return nil, ErrEmptyAPIKey
-	}
-
 	config := &Config{
 		Region:       DefaultRegion,
 		APIKey:       apiKey,
@@ -127,6 +150,10 @@ func NewDefaultConfig(apiKey string, opts ...Option) (*Config, error) {
 		opt(config)
 	}
 
+	if config.APIKey == "" && config.CredentialProvider == nil {
+		return nil, ErrEmptyAPIKey
+	}
+
 	err := config.setDefaults()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEnvironmentPrefix is the prefix of environment variables used by LoadEnvironment if no prefix is given.
+const DefaultEnvironmentPrefix = "SMAPP"
+
+// LoadEnvironment reads a File from environment variables with the given prefix and validates it.
+// see File.ApplyEnvironment for the names of variables.
+func LoadEnvironment(prefix string) (*File, error) {
+	f := &File{}
+	if err := f.ApplyEnvironment(prefix); err != nil {
+		return nil, err
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ApplyEnvironment overrides the settings of f by environment variables with the given prefix, so secrets and
+// per-deployment values can be kept out of config files. `SMAPP` is used if prefix is empty.
+// these environment variables are used, e.g. with the `SMAPP` prefix:
+//
+//	`SMAPP_API_KEY` for api_key
+//	`SMAPP_API_KEY_SOURCE` for api_key_source
+//	`SMAPP_API_KEY_NAME` for api_key_name
+//	`SMAPP_API_REGION` for region
+//	`SMAPP_API_REGIONS` for regions, separated by commas
+//	`SMAPP_API_URL_PATTERN` for url_pattern
+//	`SMAPP_API_BASE_URL` for api_base_url
+//	`SMAPP_TIMEOUT` for timeout
+//	`SMAPP_TRACER_NAME` and `SMAPP_METER_NAME` for tracing
+//	`SMAPP_RETRY_*` for retry, e.g. `SMAPP_RETRY_MAX_ATTEMPTS`
+//	`SMAPP_<SERVICE>_*` for services.<service>, e.g. `SMAPP_ETA_TIMEOUT` or `SMAPP_REVERSE_CACHE_TTL`
+//
+// services are `REVERSE`, `SEARCH`, `ETA`, `MATRIX` and `AREA_GATEWAYS`, and their variables are `TIMEOUT`,
+// `VERSION`, `URL`, `ENGINE`, `RETRY_*`, `CACHE_TTL`, `CACHE_PRECISION` and `CACHE_MAX_ENTRIES`.
+// retry variables are `MAX_ATTEMPTS`, `INITIAL_BACKOFF`, `MAX_BACKOFF`, `MULTIPLIER`, `JITTER`,
+// `RETRYABLE_STATUS_CODES`, separated by commas, `RESPECT_RETRY_AFTER` and `MAX_RETRY_AFTER`.
+// values that can not be parsed are returned as *FieldError matching ErrInvalidConfig.
+func (f *File) ApplyEnvironment(prefix string) error {
+	if prefix == "" {
+		prefix = DefaultEnvironmentPrefix
+	}
+	e := &environment{prefix: strings.TrimSuffix(prefix, "_") + "_"}
+
+	e.string("API_KEY", &f.APIKey)
+	e.string("API_KEY_SOURCE", &f.APIKeySource)
+	e.string("API_KEY_NAME", &f.APIKeyName)
+	e.string("API_REGION", &f.Region)
+	if value, ok := e.lookup("API_REGIONS"); ok {
+		f.Regions = splitList(value)
+	}
+	e.string("API_URL_PATTERN", &f.URLPattern)
+	e.string("API_BASE_URL", &f.APIBaseURL)
+	e.duration("TIMEOUT", &f.Timeout)
+	e.string("TRACER_NAME", &f.Tracing.TracerName)
+	e.string("METER_NAME", &f.Tracing.MeterName)
+	e.retry("RETRY_", &f.Retry)
+
+	services := []struct {
+		name    string
+		section *ServiceSection
+	}{
+		{name: "REVERSE_", section: &f.Services.Reverse},
+		{name: "SEARCH_", section: &f.Services.Search},
+		{name: "ETA_", section: &f.Services.ETA},
+		{name: "MATRIX_", section: &f.Services.Matrix},
+		{name: "AREA_GATEWAYS_", section: &f.Services.AreaGateways},
+	}
+	for _, service := range services {
+		s := service.section
+		e.duration(service.name+"TIMEOUT", &s.Timeout)
+		e.string(service.name+"VERSION", &s.Version)
+		e.string(service.name+"URL", &s.URL)
+		e.string(service.name+"ENGINE", &s.Engine)
+		e.retry(service.name+"RETRY_", &s.Retry)
+		e.cache(service.name+"CACHE_", &s.Cache)
+	}
+
+	return errors.Join(e.errs...)
+}
+
+type environment struct {
+	prefix string
+	errs   []error
+}
+
+func (e *environment) lookup(name string) (string, bool) {
+	value, ok := os.LookupEnv(e.prefix + name)
+	return strings.TrimSpace(value), ok && strings.TrimSpace(value) != ""
+}
+
+func (e *environment) invalid(name, value string, err error) {
+	e.errs = append(e.errs, &FieldError{
+		Field:   "$" + e.prefix + name,
+		Message: fmt.Sprintf("could not parse %q: %v", value, err),
+	})
+}
+
+func (e *environment) string(name string, target *string) {
+	if value, ok := e.lookup(name); ok {
+		*target = value
+	}
+}
+
+func (e *environment) duration(name string, target *Duration) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		e.invalid(name, value, err)
+		return false
+	}
+	*target = Duration(d)
+	return true
+}
+
+func (e *environment) int(name string, target *int) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		e.invalid(name, value, err)
+		return false
+	}
+	*target = n
+	return true
+}
+
+func (e *environment) float(name string, target *float64) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.invalid(name, value, err)
+		return false
+	}
+	*target = n
+	return true
+}
+
+func (e *environment) bool(name string, target *bool) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		e.invalid(name, value, err)
+		return false
+	}
+	*target = b
+	return true
+}
+
+// retry overrides the retry section with the given name prefix, creating it if any of its variables is set.
+func (e *environment) retry(name string, target **RetrySection) {
+	s := RetrySection{}
+	if *target != nil {
+		s = **target
+	}
+
+	set := e.int(name+"MAX_ATTEMPTS", &s.MaxAttempts)
+	set = e.duration(name+"INITIAL_BACKOFF", &s.InitialBackoff) || set
+	set = e.duration(name+"MAX_BACKOFF", &s.MaxBackoff) || set
+	set = e.float(name+"MULTIPLIER", &s.Multiplier) || set
+
+	var jitter float64
+	if e.float(name+"JITTER", &jitter) {
+		s.Jitter = &jitter
+		set = true
+	}
+
+	if value, ok := e.lookup(name + "RETRYABLE_STATUS_CODES"); ok {
+		codes := make([]int, 0)
+		for _, item := range splitList(value) {
+			code, err := strconv.Atoi(item)
+			if err != nil {
+				e.invalid(name+"RETRYABLE_STATUS_CODES", value, err)
+				codes = nil
+				break
+			}
+			codes = append(codes, code)
+		}
+		if codes != nil {
+			s.RetryableStatusCodes = codes
+			set = true
+		}
+	}
+
+	var respectRetryAfter bool
+	if e.bool(name+"RESPECT_RETRY_AFTER", &respectRetryAfter) {
+		s.RespectRetryAfter = &respectRetryAfter
+		set = true
+	}
+	set = e.duration(name+"MAX_RETRY_AFTER", &s.MaxRetryAfter) || set
+
+	if set {
+		*target = &s
+	}
+}
+
+// cache overrides the cache section with the given name prefix, creating it if any of its variables is set.
+func (e *environment) cache(name string, target **CacheSection) {
+	s := CacheSection{}
+	if *target != nil {
+		s = **target
+	}
+
+	set := e.duration(name+"TTL", &s.TTL)
+	var precision int
+	if e.int(name+"PRECISION", &precision) {
+		s.Precision = &precision
+		set = true
+	}
+	set = e.int(name+"MAX_ENTRIES", &s.MaxEntries) || set
+
+	if set {
+		*target = &s
+	}
+}
+
+func splitList(value string) []string {
+	items := strings.Split(value, ",")
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -4,3 +4,20 @@ import "errors"
 
 var ErrEmptyAPIKey = errors.New("api key is required")
 var ErrInvalidAPIKeySource = errors.New("api key source is invalid: should be header or query")
+var ErrInvalidConfig = errors.New("config is invalid")
+
+// FieldError describes an invalid field of a File. it matches ErrInvalidConfig.
+type FieldError struct {
+	// Field is the path of the field, e.g. `services.eta.timeout`.
+	Field string
+	// Message describes the problem.
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return "config: " + e.Field + " " + e.Message
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
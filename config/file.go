@@ -0,0 +1,367 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+const (
+	// InternalURLPattern selects InternalBaseURLPattern in File.URLPattern.
+	InternalURLPattern = "internal"
+	// PublicURLPattern selects PublicBaseURLPattern in File.URLPattern.
+	PublicURLPattern = "public"
+)
+
+var versionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// Duration is a time.Duration read from strings like `1.5s` in config files and environment variables.
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration should be a string like \"1.5s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Duration returns d as a time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// File holds the settings of a config and of the clients built from it. it can be read from a JSON file using LoadFile
+// and from prefixed environment variables using LoadEnvironment. zero values keep the defaults of NewDefaultConfig and
+// of each service.
+type File struct {
+	// APIKey is the key required to authenticating to different services.
+	APIKey string `json:"api_key,omitempty"`
+	// APIKeySource is `header` or `query`.
+	APIKeySource string `json:"api_key_source,omitempty"`
+	// APIKeyName is used as key of authentication in requests.
+	APIKeyName string `json:"api_key_name,omitempty"`
+	// Region is the region of services. its base url is built using URLPattern, unless APIBaseURL is set.
+	Region string `json:"region,omitempty"`
+	// Regions is the ordered list of regions to fail over between. base urls are built using URLPattern.
+	Regions []string `json:"regions,omitempty"`
+	// URLPattern is `internal`, `public` or a custom pattern containing `{REGION}`. default is `internal`.
+	URLPattern string `json:"url_pattern,omitempty"`
+	// APIBaseURL is the base url of all smapp services.
+	APIBaseURL string `json:"api_base_url,omitempty"`
+	// Timeout is the timeout of all service clients.
+	Timeout Duration `json:"timeout,omitempty"`
+	// Retry is the default retry policy of all service clients.
+	Retry *RetrySection `json:"retry,omitempty"`
+	// Tracing enables tracing and metrics of all service clients.
+	Tracing TracingSection `json:"tracing,omitempty"`
+	// Services holds the settings of each service.
+	Services ServiceSections `json:"services,omitempty"`
+}
+
+// TracingSection holds the opentelemetry settings of a File.
+type TracingSection struct {
+	// TracerName enables tracing requests using the tracer with this name.
+	TracerName string `json:"tracer_name,omitempty"`
+	// MeterName enables recording metrics of requests using the meter with this name.
+	MeterName string `json:"meter_name,omitempty"`
+}
+
+// ServiceSections holds the settings of each service of a File.
+type ServiceSections struct {
+	Reverse      ServiceSection `json:"reverse,omitempty"`
+	Search       ServiceSection `json:"search,omitempty"`
+	ETA          ServiceSection `json:"eta,omitempty"`
+	Matrix       ServiceSection `json:"matrix,omitempty"`
+	AreaGateways ServiceSection `json:"area_gateways,omitempty"`
+}
+
+// ServiceSection holds the settings of a service client.
+type ServiceSection struct {
+	// Timeout overrides File.Timeout for the service.
+	Timeout Duration `json:"timeout,omitempty"`
+	// Version is the API version of the service, e.g. `v1`.
+	Version string `json:"version,omitempty"`
+	// URL overrides the url of the service.
+	URL string `json:"url,omitempty"`
+	// Engine is the default engine of calls. only eta and matrix support it.
+	Engine string `json:"engine,omitempty"`
+	// Retry overrides File.Retry for the service.
+	Retry *RetrySection `json:"retry,omitempty"`
+	// Cache enables the response cache of the service. only reverse supports it.
+	Cache *CacheSection `json:"cache,omitempty"`
+}
+
+// RetrySection holds the settings of a retry.Policy. zero values keep the values of retry.DefaultPolicy.
+type RetrySection struct {
+	MaxAttempts          int      `json:"max_attempts,omitempty"`
+	InitialBackoff       Duration `json:"initial_backoff,omitempty"`
+	MaxBackoff           Duration `json:"max_backoff,omitempty"`
+	Multiplier           float64  `json:"multiplier,omitempty"`
+	Jitter               *float64 `json:"jitter,omitempty"`
+	RetryableStatusCodes []int    `json:"retryable_status_codes,omitempty"`
+	RespectRetryAfter    *bool    `json:"respect_retry_after,omitempty"`
+	MaxRetryAfter        Duration `json:"max_retry_after,omitempty"`
+}
+
+// Policy returns retry.DefaultPolicy overridden by the settings of the section.
+func (s RetrySection) Policy() retry.Policy {
+	policy := retry.DefaultPolicy()
+	if s.MaxAttempts > 0 {
+		policy.MaxAttempts = s.MaxAttempts
+	}
+	if s.InitialBackoff > 0 {
+		policy.InitialBackoff = s.InitialBackoff.Duration()
+	}
+	if s.MaxBackoff > 0 {
+		policy.MaxBackoff = s.MaxBackoff.Duration()
+	}
+	if s.Multiplier > 0 {
+		policy.Multiplier = s.Multiplier
+	}
+	if s.Jitter != nil {
+		policy.Jitter = *s.Jitter
+	}
+	if len(s.RetryableStatusCodes) > 0 {
+		policy.RetryableStatusCodes = s.RetryableStatusCodes
+	}
+	if s.RespectRetryAfter != nil {
+		policy.RespectRetryAfter = *s.RespectRetryAfter
+	}
+	if s.MaxRetryAfter > 0 {
+		policy.MaxRetryAfter = s.MaxRetryAfter.Duration()
+	}
+	return policy
+}
+
+// CacheSection holds the settings of a response cache. zero values keep the defaults of the service.
+type CacheSection struct {
+	// TTL is how long responses are cached.
+	TTL Duration `json:"ttl,omitempty"`
+	// Precision is the number of decimal places coordinates are rounded to in cache keys, between 1 and 10.
+	Precision *int `json:"precision,omitempty"`
+	// MaxEntries is the maximum number of cached responses.
+	MaxEntries int `json:"max_entries,omitempty"`
+}
+
+// LoadFile reads a File from the JSON file at path and validates it. unknown fields are rejected, so typos are not
+// ignored silently.
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: could not read %s: %w", path, err)
+	}
+
+	f := &File{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(f); err != nil {
+		return nil, fmt.Errorf("config: could not parse %s%s: %w", path, position(data, err), err)
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// position returns the line and column of a json error in data, if it is known.
+func position(data []byte, err error) string {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return ""
+	}
+
+	offset = min(offset, int64(len(data)))
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := int(offset) - bytes.LastIndexByte(data[:offset], '\n')
+	return fmt.Sprintf(":%d:%d", line, column)
+}
+
+// Validate checks all settings of f. it returns all problems joined, each as a *FieldError matching
+// ErrInvalidConfig.
+func (f *File) Validate() error {
+	var errs []error
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch APIKeySource(f.APIKeySource) {
+	case "", HeaderSource, QueryParamSource:
+	default:
+		invalid("api_key_source", "should be %q or %q but it is %q", HeaderSource, QueryParamSource, f.APIKeySource)
+	}
+
+	switch {
+	case f.URLPattern == "", f.URLPattern == InternalURLPattern, f.URLPattern == PublicURLPattern:
+	case !strings.Contains(f.URLPattern, "{REGION}"):
+		invalid("url_pattern", "should be %q, %q or a pattern containing {REGION} but it is %q",
+			InternalURLPattern, PublicURLPattern, f.URLPattern)
+	}
+
+	seen := make(map[string]bool, len(f.Regions))
+	for i, region := range f.Regions {
+		field := fmt.Sprintf("regions[%d]", i)
+		if region == "" {
+			invalid(field, "should not be empty")
+		} else if seen[region] {
+			invalid(field, "%q is repeated", region)
+		}
+		seen[region] = true
+	}
+
+	validateURL(invalid, "api_base_url", f.APIBaseURL)
+	validateDuration(invalid, "timeout", f.Timeout)
+	validateRetry(invalid, "retry", f.Retry)
+
+	services := []struct {
+		name              string
+		section           ServiceSection
+		engine, cacheable bool
+	}{
+		{name: "reverse", section: f.Services.Reverse, cacheable: true},
+		{name: "search", section: f.Services.Search},
+		{name: "eta", section: f.Services.ETA, engine: true},
+		{name: "matrix", section: f.Services.Matrix, engine: true},
+		{name: "area_gateways", section: f.Services.AreaGateways},
+	}
+	for _, service := range services {
+		prefix := "services." + service.name + "."
+		s := service.section
+
+		validateDuration(invalid, prefix+"timeout", s.Timeout)
+		if s.Version != "" && !versionPattern.MatchString(s.Version) {
+			invalid(prefix+"version", "should be like \"v1\" but it is %q", s.Version)
+		}
+		validateURL(invalid, prefix+"url", s.URL)
+		if s.Engine != "" && !service.engine {
+			invalid(prefix+"engine", "is not supported by %s", service.name)
+		}
+		validateRetry(invalid, prefix+"retry", s.Retry)
+
+		if s.Cache == nil {
+			continue
+		}
+		if !service.cacheable {
+			invalid(prefix+"cache", "is not supported by %s", service.name)
+			continue
+		}
+		validateDuration(invalid, prefix+"cache.ttl", s.Cache.TTL)
+		if p := s.Cache.Precision; p != nil && (*p < 1 || *p > 10) {
+			invalid(prefix+"cache.precision", "should be between 1 and 10 but it is %d", *p)
+		}
+		if s.Cache.MaxEntries < 0 {
+			invalid(prefix+"cache.max_entries", "should not be negative but it is %d", s.Cache.MaxEntries)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type invalidFunc func(field, format string, args ...any)
+
+func validateURL(invalid invalidFunc, field, rawURL string) {
+	if rawURL == "" {
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid(field, "should be an absolute http or https url but it is %q", rawURL)
+	}
+}
+
+func validateDuration(invalid invalidFunc, field string, d Duration) {
+	if d < 0 {
+		invalid(field, "should not be negative but it is %s", d.Duration())
+	}
+}
+
+func validateRetry(invalid invalidFunc, field string, s *RetrySection) {
+	if s == nil {
+		return
+	}
+	if s.MaxAttempts < 0 {
+		invalid(field+".max_attempts", "should not be negative but it is %d", s.MaxAttempts)
+	}
+	validateDuration(invalid, field+".initial_backoff", s.InitialBackoff)
+	validateDuration(invalid, field+".max_backoff", s.MaxBackoff)
+	if s.Multiplier < 0 {
+		invalid(field+".multiplier", "should not be negative but it is %g", s.Multiplier)
+	}
+	if s.Jitter != nil && (*s.Jitter < 0 || *s.Jitter > 1) {
+		invalid(field+".jitter", "should be between 0 and 1 but it is %g", *s.Jitter)
+	}
+	for i, code := range s.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			invalid(fmt.Sprintf("%s.retryable_status_codes[%d]", field, i), "%d is not a http status code", code)
+		}
+	}
+}
+
+// Config validates f and builds a Config from it. the given options are applied after the settings of f.
+func (f *File) Config(opts ...Option) (*Config, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	var options []Option
+	if f.APIKeySource != "" {
+		options = append(options, WithAPIKeySource(APIKeySource(f.APIKeySource)))
+	}
+	switch {
+	case f.APIKeyName != "":
+		options = append(options, WithAPIKeyName(f.APIKeyName))
+	case APIKeySource(f.APIKeySource) == QueryParamSource:
+		options = append(options, WithAPIKeyName(DefaultQueryParamAPIKeyName))
+	}
+	if f.Region != "" {
+		options = append(options, WithRegion(f.Region), WithAPIBaseURL(strings.ReplaceAll(f.urlPattern(), "{REGION}", f.Region)))
+	}
+	if f.APIBaseURL != "" {
+		options = append(options, WithAPIBaseURL(f.APIBaseURL))
+	}
+	if len(f.Regions) > 0 {
+		options = append(options, WithRegions(f.urlPattern(), f.Regions...))
+	}
+	if f.Retry != nil {
+		options = append(options, WithRetryPolicy(f.Retry.Policy()))
+	}
+
+	return NewDefaultConfig(f.APIKey, append(options, opts...)...)
+}
+
+func (f *File) urlPattern() string {
+	switch f.URLPattern {
+	case "", InternalURLPattern:
+		return InternalBaseURLPattern
+	case PublicURLPattern:
+		return PublicBaseURLPattern
+	}
+	return f.URLPattern
+}
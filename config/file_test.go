@@ -0,0 +1,311 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "smapp.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write config file due to: %s", err.Error())
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		path := writeFile(t, `{
+	"api_key": "foo",
+	"region": "teh-2",
+	"url_pattern": "public",
+	"timeout": "2s",
+	"retry": {"max_attempts": 5, "jitter": 0, "max_retry_after": "30s"},
+	"tracing": {"tracer_name": "smapp"},
+	"services": {
+		"eta": {"timeout": "500ms", "version": "v2", "engine": "v2"},
+		"reverse": {"url": "https://reverse.example.com", "cache": {"ttl": "10m", "max_entries": 100}}
+	}
+}`)
+
+		f, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("LoadFile should not return error: %s", err.Error())
+		}
+		if f.APIKey != "foo" || f.Region != "teh-2" || f.URLPattern != PublicURLPattern {
+			t.Fatalf("unexpected top level settings: %+v", f)
+		}
+		if f.Timeout.Duration() != 2*time.Second {
+			t.Fatalf("timeout should be 2s but it is %s", f.Timeout.Duration())
+		}
+		if f.Services.ETA.Timeout.Duration() != 500*time.Millisecond || f.Services.ETA.Engine != "v2" {
+			t.Fatalf("unexpected eta section: %+v", f.Services.ETA)
+		}
+		if f.Services.Reverse.Cache == nil || f.Services.Reverse.Cache.TTL.Duration() != 10*time.Minute {
+			t.Fatalf("unexpected reverse cache section: %+v", f.Services.Reverse.Cache)
+		}
+
+		policy := f.Retry.Policy()
+		if policy.MaxAttempts != 5 || policy.Jitter != 0 {
+			t.Fatalf("retry policy should have 5 attempts and no jitter but it is %+v", policy)
+		}
+		if policy.MaxBackoff != 2*time.Second {
+			t.Fatalf("unset retry settings should keep defaults but max backoff is %s", policy.MaxBackoff)
+		}
+		if policy.MaxRetryAfter != 30*time.Second {
+			t.Fatalf("max retry after should be 30s but it is %s", policy.MaxRetryAfter)
+		}
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("err should be os.ErrNotExist but it is %v", err)
+		}
+	})
+
+	t.Run("syntax_error", func(t *testing.T) {
+		path := writeFile(t, "{\n\t\"api_key\": \"foo\",\n\t\"region\" \"teh-1\"\n}")
+		_, err := LoadFile(path)
+		if err == nil || !strings.Contains(err.Error(), "smapp.json:3:") {
+			t.Fatalf("err should point to line 3 but it is %v", err)
+		}
+	})
+
+	t.Run("unknown_field", func(t *testing.T) {
+		path := writeFile(t, `{"api_key": "foo", "services": {"eta": {"timout": "1s"}}}`)
+		_, err := LoadFile(path)
+		if err == nil || !strings.Contains(err.Error(), "timout") {
+			t.Fatalf("err should name the unknown field but it is %v", err)
+		}
+	})
+
+	t.Run("invalid_duration", func(t *testing.T) {
+		path := writeFile(t, `{"timeout": "soon"}`)
+		if _, err := LoadFile(path); err == nil {
+			t.Fatal("LoadFile err should not be nil")
+		}
+	})
+
+	t.Run("invalid_settings", func(t *testing.T) {
+		path := writeFile(t, `{"api_key_source": "cookie", "services": {"search": {"engine": "v2"}}}`)
+		_, err := LoadFile(path)
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+		}
+	})
+}
+
+func TestFile_Validate(t *testing.T) {
+	jitter, precision := 1.5, 11
+	f := &File{
+		APIKeySource: "cookie",
+		URLPattern:   "http://example.com",
+		Regions:      []string{"teh-1", "", "teh-1"},
+		APIBaseURL:   "example.com",
+		Timeout:      Duration(-time.Second),
+		Retry:        &RetrySection{MaxAttempts: -1, Jitter: &jitter, RetryableStatusCodes: []int{503, 42}},
+		Services: ServiceSections{
+			Search:       ServiceSection{Engine: "v2", Cache: &CacheSection{}},
+			ETA:          ServiceSection{Version: "2"},
+			Reverse:      ServiceSection{Cache: &CacheSection{Precision: &precision, MaxEntries: -1}},
+			AreaGateways: ServiceSection{URL: "ftp://example.com"},
+		},
+	}
+
+	err := f.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+	}
+
+	expected := []string{
+		"api_key_source",
+		"url_pattern",
+		"regions[1]",
+		"regions[2]",
+		"api_base_url",
+		"timeout",
+		"retry.max_attempts",
+		"retry.jitter",
+		"retry.retryable_status_codes[1]",
+		"services.reverse.cache.precision",
+		"services.reverse.cache.max_entries",
+		"services.search.engine",
+		"services.search.cache",
+		"services.eta.version",
+		"services.area_gateways.url",
+	}
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fieldErr *FieldError
+		if !errors.As(e, &fieldErr) {
+			t.Fatalf("err should be *FieldError but it is %T", e)
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Fatalf("invalid fields should be %v but they are %v", expected, fields)
+	}
+
+	if err := (&File{}).Validate(); err != nil {
+		t.Fatalf("empty file should be valid but err is %v", err)
+	}
+
+	// 0 is not a way to ask for the default precision, it is an invalid value. the default is kept by leaving it out.
+	precision = 0
+	f = &File{Services: ServiceSections{Reverse: ServiceSection{Cache: &CacheSection{Precision: &precision}}}}
+	var fieldErr *FieldError
+	if err := f.Validate(); !errors.As(err, &fieldErr) || fieldErr.Field != "services.reverse.cache.precision" {
+		t.Fatalf("precision 0 should be invalid but err is %v", err)
+	}
+	f.Services.Reverse.Cache.Precision = nil
+	if err := f.Validate(); err != nil {
+		t.Fatalf("unset precision should be valid but err is %v", err)
+	}
+}
+
+func TestFile_ApplyEnvironment(t *testing.T) {
+	t.Run("overrides_file", func(t *testing.T) {
+		t.Setenv("MYAPP_API_KEY", "bar")
+		t.Setenv("MYAPP_API_REGIONS", "teh-1, teh-2")
+		t.Setenv("MYAPP_TIMEOUT", "3s")
+		t.Setenv("MYAPP_ETA_ENGINE", "nostra")
+		t.Setenv("MYAPP_MATRIX_RETRY_MAX_ATTEMPTS", "4")
+		t.Setenv("MYAPP_MATRIX_RETRY_RETRYABLE_STATUS_CODES", "502,503")
+		t.Setenv("MYAPP_MATRIX_RETRY_MAX_RETRY_AFTER", "1m")
+		t.Setenv("MYAPP_REVERSE_CACHE_TTL", "1m")
+		t.Setenv("MYAPP_REVERSE_CACHE_PRECISION", "3")
+
+		f := &File{
+			APIKey:   "foo",
+			Region:   "teh-3",
+			Services: ServiceSections{Reverse: ServiceSection{Cache: &CacheSection{MaxEntries: 10}}},
+		}
+		if err := f.ApplyEnvironment("MYAPP"); err != nil {
+			t.Fatalf("ApplyEnvironment should not return error: %s", err.Error())
+		}
+
+		if f.APIKey != "bar" {
+			t.Fatalf("api key should be overridden by environment but it is %s", f.APIKey)
+		}
+		if f.Region != "teh-3" {
+			t.Fatalf("region should be kept but it is %s", f.Region)
+		}
+		if strings.Join(f.Regions, ",") != "teh-1,teh-2" {
+			t.Fatalf("regions should be [teh-1 teh-2] but they are %v", f.Regions)
+		}
+		if f.Timeout.Duration() != 3*time.Second || f.Services.ETA.Engine != "nostra" {
+			t.Fatalf("unexpected settings: %+v", f)
+		}
+		if f.Services.Matrix.Retry == nil || f.Services.Matrix.Retry.MaxAttempts != 4 ||
+			len(f.Services.Matrix.Retry.RetryableStatusCodes) != 2 || f.Services.Matrix.Retry.MaxRetryAfter.Duration() != time.Minute {
+			t.Fatalf("unexpected matrix retry section: %+v", f.Services.Matrix.Retry)
+		}
+		if f.Services.Search.Retry != nil {
+			t.Fatal("search retry section should not be created")
+		}
+		cache := f.Services.Reverse.Cache
+		if cache.TTL.Duration() != time.Minute || cache.MaxEntries != 10 || cache.Precision == nil || *cache.Precision != 3 {
+			t.Fatalf("reverse cache section should be merged but it is %+v", cache)
+		}
+	})
+
+	t.Run("invalid_values", func(t *testing.T) {
+		t.Setenv("SMAPP_TIMEOUT", "soon")
+		t.Setenv("SMAPP_ETA_RETRY_JITTER", "a lot")
+
+		err := (&File{}).ApplyEnvironment("")
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+		}
+		if !strings.Contains(err.Error(), "$SMAPP_TIMEOUT") || !strings.Contains(err.Error(), "$SMAPP_ETA_RETRY_JITTER") {
+			t.Fatalf("err should name both variables but it is %v", err)
+		}
+	})
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	t.Setenv("SMAPP_API_KEY", "foo")
+	t.Setenv("SMAPP_SEARCH_ENGINE", "v2")
+
+	if _, err := LoadEnvironment("SMAPP"); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+	}
+
+	t.Setenv("SMAPP_SEARCH_ENGINE", "")
+	f, err := LoadEnvironment("SMAPP")
+	if err != nil {
+		t.Fatalf("LoadEnvironment should not return error: %s", err.Error())
+	}
+	if f.APIKey != "foo" {
+		t.Fatalf("api key should be foo but it is %s", f.APIKey)
+	}
+}
+
+func TestFile_Config(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := (&File{APIKey: "foo"}).Config()
+		if err != nil {
+			t.Fatalf("Config should not return error: %s", err.Error())
+		}
+		if c.APIBaseURL != DefaultBaseURL || c.APIKeyName != DefaultHeaderAPIKeyName {
+			t.Fatalf("config should have default settings but it is %+v", c)
+		}
+	})
+
+	t.Run("region_and_pattern", func(t *testing.T) {
+		f := &File{APIKey: "foo", Region: "teh-2", URLPattern: PublicURLPattern, APIKeySource: "query"}
+		c, err := f.Config()
+		if err != nil {
+			t.Fatalf("Config should not return error: %s", err.Error())
+		}
+		if c.APIBaseURL != "https://api.teh-2.snappmaps.ir" {
+			t.Fatalf("config.APIBaseURL should be https://api.teh-2.snappmaps.ir but it is %s", c.APIBaseURL)
+		}
+		if c.APIKeySource != QueryParamSource || c.APIKeyName != DefaultQueryParamAPIKeyName {
+			t.Fatalf("unexpected api key settings: %s %s", c.APIKeySource, c.APIKeyName)
+		}
+	})
+
+	t.Run("regions_and_retry", func(t *testing.T) {
+		f := &File{
+			APIKey:     "foo",
+			Regions:    []string{"a", "b"},
+			URLPattern: "http://{REGION}.example.com",
+			Retry:      &RetrySection{MaxAttempts: 7},
+		}
+		c, err := f.Config()
+		if err != nil {
+			t.Fatalf("Config should not return error: %s", err.Error())
+		}
+		if c.Failover == nil || c.APIBaseURL != "http://a.example.com" {
+			t.Fatalf("config should fail over between regions but it is %+v", c)
+		}
+		if c.RetryPolicy == nil || c.RetryPolicy.MaxAttempts != 7 {
+			t.Fatalf("config.RetryPolicy should have 7 attempts but it is %+v", c.RetryPolicy)
+		}
+	})
+
+	t.Run("options_override_file", func(t *testing.T) {
+		c, err := (&File{APIKey: "foo"}).Config(WithAPIKey("bar"))
+		if err != nil {
+			t.Fatalf("Config should not return error: %s", err.Error())
+		}
+		if c.APIKey != "bar" {
+			t.Fatalf("config.APIKey should be bar but it is %s", c.APIKey)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := (&File{}).Config(); !errors.Is(err, ErrEmptyAPIKey) {
+			t.Fatalf("err should be ErrEmptyAPIKey but it is %v", err)
+		}
+		if _, err := (&File{APIKey: "foo", APIKeySource: "cookie"}).Config(); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+		}
+	})
+}
@@ -1,6 +1,13 @@
 package config
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/credentials"
+	"github.com/snapp-incubator/smapp-sdk-go/failover"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
 
 // Option is a function type for overriding different fields of config struct in a fluent way.
 // You can pass as many as options to config constructors as you want.
@@ -33,6 +40,18 @@ func WithAPIKey(apiKey string) Option {
 	}
 }
 
+// WithCredentialProvider sets a provider consulted for the API key of each request. it takes precedence over APIKey,
+// which can be left empty.
+//
+// Example:
+// 		provider, err := credentials.NewFile("/var/run/secrets/smapp/api-key", credentials.WithGracePeriod(5*time.Minute))
+// 		cfg, err := NewDefaultConfig("", WithCredentialProvider(provider))
+func WithCredentialProvider(provider credentials.Provider) Option {
+	return func(config *Config) {
+		config.CredentialProvider = provider
+	}
+}
+
 // WithAPIBaseURL sets a custom base URL for services
 //
 // Example:
@@ -93,3 +112,56 @@ func WithInternalURL() Option {
 		config.APIBaseURL = strings.ReplaceAll(InternalBaseURLPattern, "{REGION}", config.Region)
 	}
 }
+
+// WithRetryPolicy sets the default retry policy of all clients built from the config.
+// Each client can still override it using its own `WithRetryPolicy` constructor option.
+//
+// Example:
+// 		cfg, err := ReadFromEnvironment(WithRetryPolicy(retry.DefaultPolicy()))
+func WithRetryPolicy(policy retry.Policy) Option {
+	return func(config *Config) {
+		config.RetryPolicy = &policy
+	}
+}
+
+// WithRateLimiter sets the rate limiter shared by all clients built from the config, so their requests share the
+// quota of each API key and endpoint. Each client can still override it using its own `WithRateLimiter` constructor option.
+//
+// Example:
+// 		cfg, err := ReadFromEnvironment(WithRateLimiter(ratelimit.New(ratelimit.Limit{Rate: 50, Burst: 10})))
+func WithRateLimiter(limiter *ratelimit.Limiter) Option {
+	return func(config *Config) {
+		config.RateLimiter = limiter
+	}
+}
+
+// WithRegions sets the ordered list of regions the clients built from the config fail over between. the base url of each
+// region is built by replacing `{REGION}` in baseURLPattern. the first region is the primary, and it overrides the
+// Region and APIBaseURL of the config.
+//
+// Example:
+// 		cfg, err := ReadFromEnvironment(WithRegions(InternalBaseURLPattern, "teh-1", "teh-2"))
+func WithRegions(baseURLPattern string, regions ...string) Option {
+	return func(config *Config) {
+		config.Regions = make([]failover.Region, 0, len(regions))
+		for _, region := range regions {
+			config.Regions = append(config.Regions, failover.Region{
+				Name:    region,
+				BaseURL: strings.ReplaceAll(baseURLPattern, "{REGION}", region),
+			})
+		}
+	}
+}
+
+// WithFailoverSettings sets when a region of the config is considered unhealthy. it is used with WithRegions.
+//
+// Example:
+// 		cfg, err := ReadFromEnvironment(
+//		WithRegions(PublicBaseURLPattern, "teh-1", "teh-2"),
+//		WithFailoverSettings(failover.Settings{FailureThreshold: 5, CoolDown: time.Minute}),
+//		)
+func WithFailoverSettings(settings failover.Settings) Option {
+	return func(config *Config) {
+		config.FailoverSettings = settings
+	}
+}
@@ -3,6 +3,12 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/credentials"
+	"github.com/snapp-incubator/smapp-sdk-go/failover"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
 )
 
 func TestWithAPIBaseURL(t *testing.T) {
@@ -87,3 +93,65 @@ func TestWithRegion(t *testing.T) {
 		t.Fatalf("Region should be %s but it is %s", "teh-2", c.Region)
 	}
 }
+
+func TestWithRetryPolicy(t *testing.T) {
+	c, err := NewDefaultConfig("foo", WithRetryPolicy(retry.DefaultPolicy()))
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+
+	if c.RetryPolicy == nil {
+		t.Fatal("RetryPolicy should not be nil")
+	}
+	if c.RetryPolicy.MaxAttempts != retry.DefaultMaxAttempts {
+		t.Fatalf("RetryPolicy.MaxAttempts should be %d but it is %d", retry.DefaultMaxAttempts, c.RetryPolicy.MaxAttempts)
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limit{Rate: 10, Burst: 1})
+	c, err := NewDefaultConfig("foo", WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+
+	if c.RateLimiter != limiter {
+		t.Fatal("RateLimiter should be the given limiter")
+	}
+}
+
+func TestWithRegions(t *testing.T) {
+	c, err := NewDefaultConfig("foo",
+		WithRegions(PublicBaseURLPattern, "teh-2", "teh-1"),
+		WithFailoverSettings(failover.Settings{FailureThreshold: 5, CoolDown: time.Minute}),
+	)
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+
+	if c.Region != "teh-2" {
+		t.Fatalf("Region should be %s but it is %s", "teh-2", c.Region)
+	}
+	if c.APIBaseURL != "https://api.teh-2.snappmaps.ir" {
+		t.Fatalf("APIBaseURL should be %s but it is %s", "https://api.teh-2.snappmaps.ir", c.APIBaseURL)
+	}
+	if c.Failover == nil {
+		t.Fatal("Failover should not be nil")
+	}
+	regions := c.Failover.Regions()
+	if len(regions) != 2 || regions[1].Name != "teh-1" || regions[1].BaseURL != "https://api.teh-1.snappmaps.ir" {
+		t.Fatalf("Failover regions should be teh-2 and teh-1 but it is %+v", regions)
+	}
+}
+
+func TestWithCredentialProvider(t *testing.T) {
+	provider := credentials.NewStatic("foo")
+	c, err := NewDefaultConfig("", WithCredentialProvider(provider))
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+
+	if c.CredentialProvider != provider {
+		t.Fatal("CredentialProvider should be the given provider")
+	}
+}
@@ -0,0 +1,83 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// PreviousKeyEventName is the name of the span event added when a request is sent again with the previous key.
+const PreviousKeyEventName = "credentials.previous_key"
+
+// ErrEmptyAPIKey is returned by providers whose source holds no API key.
+var ErrEmptyAPIKey = errors.New("credentials: api key is empty")
+
+// Credential is the API key to use for a request.
+type Credential struct {
+	// APIKey is the current key.
+	APIKey string
+	// PreviousAPIKey is the key before the last rotation. it is only set during the grace period of the rotation.
+	PreviousAPIKey string
+}
+
+// Keys returns the non-empty keys of the credential.
+func (c Credential) Keys() []string {
+	keys := make([]string, 0, 2)
+	for _, key := range []string{c.APIKey, c.PreviousAPIKey} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Provider provides the API key of each request. implementations must be safe for concurrent use.
+type Provider interface {
+	// Credential returns the credential of a request.
+	Credential(ctx context.Context) (Credential, error)
+}
+
+// rotation keeps the current key and the previous one during the grace period. it is safe for concurrent use.
+type rotation struct {
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	mu        sync.RWMutex
+	current   string
+	previous  string
+	rotatedAt time.Time
+}
+
+func newRotation(key string, gracePeriod time.Duration) *rotation {
+	return &rotation{
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+		current:     key,
+	}
+}
+
+// set makes key the current key. it reports whether the key is changed.
+func (r *rotation) set(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key == r.current {
+		return false
+	}
+	r.previous = r.current
+	r.current = key
+	r.rotatedAt = r.now()
+	return true
+}
+
+func (r *rotation) credential() Credential {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credential := Credential{APIKey: r.current}
+	if r.previous != "" && r.now().Before(r.rotatedAt.Add(r.gracePeriod)) {
+		credential.PreviousAPIKey = r.previous
+	}
+	return credential
+}
@@ -0,0 +1,40 @@
+package credentials
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCredential_Keys(t *testing.T) {
+	if keys := (Credential{APIKey: "new"}).Keys(); len(keys) != 1 || keys[0] != "new" {
+		t.Fatalf("Keys should be [new] but it is %v", keys)
+	}
+	if keys := (Credential{APIKey: "new", PreviousAPIKey: "old"}).Keys(); len(keys) != 2 || keys[1] != "old" {
+		t.Fatalf("Keys should be [new old] but it is %v", keys)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := newRotation("old", time.Minute)
+	r.now = func() time.Time { return now }
+
+	if c := r.credential(); c.APIKey != "old" || c.PreviousAPIKey != "" {
+		t.Fatalf("credential should have only the old key but it is %+v", c)
+	}
+
+	if !r.set("new") {
+		t.Fatal("set should report the change")
+	}
+	if r.set("new") {
+		t.Fatal("set should not report setting the same key")
+	}
+	if c := r.credential(); c.APIKey != "new" || c.PreviousAPIKey != "old" {
+		t.Fatalf("credential should have both keys during the grace period but it is %+v", c)
+	}
+
+	now = now.Add(time.Minute)
+	if c := r.credential(); c.APIKey != "new" || c.PreviousAPIKey != "" {
+		t.Fatalf("credential should have only the new key after the grace period but it is %+v", c)
+	}
+}
@@ -0,0 +1,6 @@
+// Package credentials contains providers of the API key of service clients. a Provider is consulted for each request,
+// so keys can be rotated without rebuilding clients. it is set using `config.WithCredentialProvider`.
+//
+// providers keep the previous key for a grace period after each rotation. requests rejected with 401 or 403 during
+// the grace period are sent again with the previous key.
+package credentials
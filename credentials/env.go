@@ -0,0 +1,34 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// EnvProvider provides the API key held by an environment variable. the variable is read for each request, so changes
+// to it are applied immediately.
+type EnvProvider struct {
+	name     string
+	rotation *rotation
+}
+
+// NewEnv creates an EnvProvider reading the environment variable with the given name, e.g. `SMAPP_API_KEY`.
+// only WithGracePeriod is applied.
+func NewEnv(name string, opts ...Option) *EnvProvider {
+	o := newOptions(opts)
+	return &EnvProvider{
+		name:     name,
+		rotation: newRotation(os.Getenv(name), o.gracePeriod),
+	}
+}
+
+// Credential implements Provider.
+func (p *EnvProvider) Credential(context.Context) (Credential, error) {
+	apiKey := os.Getenv(p.name)
+	if apiKey == "" {
+		return Credential{}, fmt.Errorf("%w: environment variable %s", ErrEmptyAPIKey, p.name)
+	}
+	p.rotation.set(apiKey)
+	return p.rotation.credential(), nil
+}
@@ -0,0 +1,32 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("TEST_SMAPP_API_KEY", "old")
+	p := NewEnv("TEST_SMAPP_API_KEY", WithGracePeriod(time.Minute))
+
+	c, err := p.Credential(context.Background())
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if c.APIKey != "old" || c.PreviousAPIKey != "" {
+		t.Fatalf("credential should be old but it is %+v", c)
+	}
+
+	t.Setenv("TEST_SMAPP_API_KEY", "new")
+	c, _ = p.Credential(context.Background())
+	if c.APIKey != "new" || c.PreviousAPIKey != "old" {
+		t.Fatalf("credential should be new with previous old but it is %+v", c)
+	}
+
+	t.Setenv("TEST_SMAPP_API_KEY", "")
+	if _, err := p.Credential(context.Background()); !errors.Is(err, ErrEmptyAPIKey) {
+		t.Fatalf("err should be ErrEmptyAPIKey but it is %v", err)
+	}
+}
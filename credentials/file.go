@@ -0,0 +1,92 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileProvider provides the API key held by a file, like a kubernetes secret mounted as a volume. the file is read
+// every poll interval and the key is replaced when its content changes. leading and trailing spaces are ignored.
+type FileProvider struct {
+	path     string
+	options  options
+	rotation *rotation
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewFile creates a FileProvider reading the file at path, and starts watching it. it returns an error if the file
+// can not be read or is empty. Close must be called to stop watching the file.
+func NewFile(path string, opts ...Option) (*FileProvider, error) {
+	p := &FileProvider{
+		path:    path,
+		options: newOptions(opts),
+		stop:    make(chan struct{}),
+	}
+
+	apiKey, err := p.read()
+	if err != nil {
+		return nil, err
+	}
+	p.rotation = newRotation(apiKey, p.options.gracePeriod)
+
+	go p.watch()
+	return p, nil
+}
+
+// Credential implements Provider.
+func (p *FileProvider) Credential(context.Context) (Credential, error) {
+	return p.rotation.credential(), nil
+}
+
+// Reload reads the file and replaces the key if it is changed. it is called every poll interval, and can be called
+// to apply a change immediately. the key is kept if the file can not be read or is empty.
+func (p *FileProvider) Reload() error {
+	apiKey, err := p.read()
+	if err != nil {
+		return err
+	}
+	p.rotation.set(apiKey)
+	return nil
+}
+
+// Close stops watching the file. the provider keeps providing its last key.
+func (p *FileProvider) Close() error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	return nil
+}
+
+func (p *FileProvider) watch() {
+	ticker := time.NewTicker(p.options.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.Reload(); err != nil && p.options.onError != nil {
+				p.options.onError(err)
+			}
+		}
+	}
+}
+
+func (p *FileProvider) read() (string, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("credentials: could not read api key file: %w", err)
+	}
+	apiKey := strings.TrimSpace(string(content))
+	if apiKey == "" {
+		return "", fmt.Errorf("%w: file %s", ErrEmptyAPIKey, p.path)
+	}
+	return apiKey, nil
+}
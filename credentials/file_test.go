@@ -0,0 +1,88 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func writeKey(t *testing.T, path, key string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(key), 0o600); err != nil {
+		t.Fatalf("could not write key file: %s", err.Error())
+	}
+}
+
+func TestNewFile(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := NewFile(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("should return error for missing file")
+	}
+
+	empty := filepath.Join(dir, "empty")
+	writeKey(t, empty, " \n")
+	if _, err := NewFile(empty); !errors.Is(err, ErrEmptyAPIKey) {
+		t.Fatalf("err should be ErrEmptyAPIKey but it is %v", err)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Run("reloads_on_change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "old\n")
+
+		p, err := NewFile(path, WithGracePeriod(time.Minute), WithPollInterval(5*time.Millisecond))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		defer p.Close()
+
+		c, _ := p.Credential(context.Background())
+		if c.APIKey != "old" {
+			t.Fatalf("APIKey should be old but it is %q", c.APIKey)
+		}
+
+		writeKey(t, path, "new")
+		deadline := time.Now().Add(2 * time.Second)
+		for c.APIKey != "new" && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+			c, _ = p.Credential(context.Background())
+		}
+		if c.APIKey != "new" || c.PreviousAPIKey != "old" {
+			t.Fatalf("credential should be new with previous old but it is %+v", c)
+		}
+	})
+
+	t.Run("keeps_key_on_failure", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api-key")
+		writeKey(t, path, "old")
+
+		var failures int32
+		p, err := NewFile(path, WithPollInterval(5*time.Millisecond), WithErrorHandler(func(err error) {
+			atomic.AddInt32(&failures, 1)
+		}))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		defer p.Close()
+
+		if err := os.Remove(path); err != nil {
+			t.Fatalf("could not remove key file: %s", err.Error())
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for atomic.LoadInt32(&failures) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if atomic.LoadInt32(&failures) == 0 {
+			t.Fatal("error handler should be called")
+		}
+		if c, _ := p.Credential(context.Background()); c.APIKey != "old" {
+			t.Fatalf("APIKey should still be old but it is %q", c.APIKey)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package credentials
+
+import "time"
+
+const (
+	DefaultPollInterval = 10 * time.Second
+)
+
+type options struct {
+	gracePeriod  time.Duration
+	pollInterval time.Duration
+	onError      func(err error)
+}
+
+func newOptions(opts []Option) options {
+	o := options{pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pollInterval <= 0 {
+		o.pollInterval = DefaultPollInterval
+	}
+	return o
+}
+
+// Option is a function type for customizing providers.
+type Option func(o *options)
+
+// WithGracePeriod keeps the previous key for the given duration after each rotation. requests rejected with 401 or 403
+// during the grace period are sent again with the previous key. default is 0, which disables the grace period.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.gracePeriod = gracePeriod
+	}
+}
+
+// WithPollInterval sets how often a FileProvider reads its file. default is 10s.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// WithErrorHandler sets a function called when a FileProvider can not reload its file. the provider keeps using its
+// last key.
+func WithErrorHandler(onError func(err error)) Option {
+	return func(o *options) {
+		o.onError = onError
+	}
+}
@@ -0,0 +1,28 @@
+package credentials
+
+import "context"
+
+// StaticProvider provides a fixed API key, which can be rotated by calling Rotate.
+type StaticProvider struct {
+	rotation *rotation
+}
+
+// NewStatic creates a StaticProvider of the given key. only WithGracePeriod is applied.
+func NewStatic(apiKey string, opts ...Option) *StaticProvider {
+	o := newOptions(opts)
+	return &StaticProvider{rotation: newRotation(apiKey, o.gracePeriod)}
+}
+
+// Rotate replaces the key of the provider.
+func (p *StaticProvider) Rotate(apiKey string) {
+	p.rotation.set(apiKey)
+}
+
+// Credential implements Provider.
+func (p *StaticProvider) Credential(context.Context) (Credential, error) {
+	credential := p.rotation.credential()
+	if credential.APIKey == "" {
+		return Credential{}, ErrEmptyAPIKey
+	}
+	return credential, nil
+}
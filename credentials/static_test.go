@@ -0,0 +1,30 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStaticProvider(t *testing.T) {
+	p := NewStatic("old", WithGracePeriod(time.Minute))
+
+	c, err := p.Credential(context.Background())
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if c.APIKey != "old" {
+		t.Fatalf("APIKey should be old but it is %s", c.APIKey)
+	}
+
+	p.Rotate("new")
+	c, _ = p.Credential(context.Background())
+	if c.APIKey != "new" || c.PreviousAPIKey != "old" {
+		t.Fatalf("credential should be new with previous old but it is %+v", c)
+	}
+
+	if _, err := NewStatic("").Credential(context.Background()); !errors.Is(err, ErrEmptyAPIKey) {
+		t.Fatalf("err should be ErrEmptyAPIKey but it is %v", err)
+	}
+}
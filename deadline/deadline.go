@@ -0,0 +1,37 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Header is the request header holding the remaining budget of a call in milliseconds.
+const Header = "X-Smapp-Deadline-Budget-Ms"
+
+// Remaining returns the time left until the deadline of ctx. ok is false if ctx has no deadline.
+func Remaining(ctx context.Context) (remaining time.Duration, ok bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
+// Fits reports whether d fits within the remaining budget of ctx. it is always true if ctx has no deadline.
+func Fits(ctx context.Context, d time.Duration) bool {
+	remaining, ok := Remaining(ctx)
+	return !ok || d < remaining
+}
+
+// SetHeader sets Header of req to the remaining budget of its context, rounded down to milliseconds. the header is
+// removed if the context has no deadline.
+func SetHeader(req *http.Request) {
+	remaining, ok := Remaining(req.Context())
+	if !ok {
+		req.Header.Del(Header)
+		return
+	}
+	req.Header.Set(Header, strconv.FormatInt(remaining.Milliseconds(), 10))
+}
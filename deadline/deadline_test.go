@@ -0,0 +1,61 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRemaining(t *testing.T) {
+	if _, ok := Remaining(context.Background()); ok {
+		t.Fatal("context without deadline should have no budget")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	remaining, ok := Remaining(ctx)
+	if !ok || remaining <= 0 || remaining > time.Second {
+		t.Fatalf("remaining budget should be at most 1s but it is %s", remaining)
+	}
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	if remaining, _ := Remaining(expired); remaining != 0 {
+		t.Fatalf("remaining budget of an expired context should be 0 but it is %s", remaining)
+	}
+}
+
+func TestFits(t *testing.T) {
+	if !Fits(context.Background(), time.Hour) {
+		t.Fatal("anything should fit a context without deadline")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !Fits(ctx, 100*time.Millisecond) {
+		t.Fatal("100ms should fit a budget of 1s")
+	}
+	if Fits(ctx, 2*time.Second) {
+		t.Fatal("2s should not fit a budget of 1s")
+	}
+}
+
+func TestSetHeader(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	SetHeader(req)
+	ms, err := strconv.Atoi(req.Header.Get(Header))
+	if err != nil || ms <= 0 || ms > 1000 {
+		t.Fatalf("header should hold the remaining budget in milliseconds but it is %q", req.Header.Get(Header))
+	}
+
+	req = req.WithContext(context.Background())
+	SetHeader(req)
+	if _, ok := req.Header[Header]; ok {
+		t.Fatal("header should be removed if the context has no deadline")
+	}
+}
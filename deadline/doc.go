@@ -0,0 +1,5 @@
+// Package deadline contains the deadline budget of calls. the budget of a call is the time left until the deadline of
+// its context, which is set by the `WithTimeout` call option of each service or by the timeout of the client. retries
+// are sent only if they fit within the budget, and the remaining budget of each attempt is sent to the server in the
+// Header header, so it can give up on requests the client no longer waits for.
+package deadline
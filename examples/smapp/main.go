@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/smapp"
+)
+
+func main() {
+	cfg, err := config.NewDefaultConfig("api-key")
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := smapp.NewClient(cfg,
+		smapp.WithTimeout(2*time.Second),
+		smapp.WithETA(eta.V1, 5*time.Second),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer client.CloseIdleConnections()
+
+	displayName, err := client.Reverse.GetDisplayName(35.77330981921435, 51.41834378242493, reverse.NewDefaultCallOptions())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(displayName)
+
+	result, err := client.ETA.GetETA([]eta.Point{
+		{
+			Lat: 35.77330981921435,
+			Lon: 51.41834378242493,
+		},
+		{
+			Lat: 35.739136559226864,
+			Lon: 51.510804891586304,
+		},
+	}, eta.NewDefaultCallOptions())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(result.Trip.Legs)
+}
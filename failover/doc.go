@@ -0,0 +1,4 @@
+// Package failover contains passive health tracking of smapp regions and a http.RoundTripper that fails over to the
+// next region on connection errors and 5xx responses. a Failover is built from the ordered regions of a config using
+// `config.WithRegions` and is shared by all clients built from that config.
+package failover
@@ -0,0 +1,156 @@
+package failover
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFailureThreshold = 3
+	DefaultCoolDown         = 30 * time.Second
+)
+
+// Region is a smapp region that can serve requests.
+type Region struct {
+	// Name is the name of the region. e.g. `teh-1`.
+	Name string
+	// BaseURL is the base url of all smapp services in the region. trailing slashes are removed by New, as services
+	// remove them when building their urls.
+	BaseURL string
+}
+
+// Settings specifies when a region is considered unhealthy. zero values are replaced with defaults.
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that make a region unhealthy. default is 3.
+	FailureThreshold int
+	// CoolDown is how long an unhealthy region is skipped before it is tried again. default is 30s.
+	CoolDown time.Duration
+	// OnHealthChange is called when a region becomes unhealthy or healthy again, if not nil.
+	OnHealthChange func(region string, healthy bool)
+}
+
+type health struct {
+	failures       int
+	unhealthy      bool
+	unhealthyUntil time.Time
+}
+
+// Failover keeps the passive health of an ordered list of regions. the first region is the primary. it is safe for
+// concurrent use.
+type Failover struct {
+	regions  []Region
+	settings Settings
+	now      func() time.Time
+
+	mu     sync.Mutex
+	health []health
+}
+
+// New creates a Failover of the given regions, in order of preference. all regions are healthy at first.
+func New(regions []Region, settings Settings) *Failover {
+	regions = append([]Region(nil), regions...)
+	for i := range regions {
+		regions[i].BaseURL = strings.TrimRight(regions[i].BaseURL, "/")
+	}
+
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultFailureThreshold
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = DefaultCoolDown
+	}
+
+	return &Failover{
+		regions:  regions,
+		settings: settings,
+		now:      time.Now,
+		health:   make([]health, len(regions)),
+	}
+}
+
+// Regions returns the regions, in order of preference.
+func (f *Failover) Regions() []Region {
+	return append([]Region(nil), f.regions...)
+}
+
+// Primary returns the first region. it returns an empty Region if there is no region.
+func (f *Failover) Primary() Region {
+	if len(f.regions) == 0 {
+		return Region{}
+	}
+	return f.regions[0]
+}
+
+// Healthy reports whether the region with the given name is healthy. an unhealthy region is healthy again after
+// Settings.CoolDown, until its next failure.
+func (f *Failover) Healthy(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	for i, region := range f.regions {
+		if region.Name == name {
+			return f.healthy(i, now)
+		}
+	}
+	return false
+}
+
+// order returns the indexes of regions to try, healthy regions first, each group in order of preference. so the
+// primary is used as soon as it is healthy again.
+func (f *Failover) order() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	order := make([]int, 0, len(f.regions))
+	var unhealthy []int
+	for i := range f.regions {
+		if f.healthy(i, now) {
+			order = append(order, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+	return append(order, unhealthy...)
+}
+
+// healthy reports whether region i is healthy. f.mu must be held.
+func (f *Failover) healthy(i int, now time.Time) bool {
+	return !now.Before(f.health[i].unhealthyUntil)
+}
+
+// success records a successful request to region i.
+func (f *Failover) success(i int) {
+	f.mu.Lock()
+	h := &f.health[i]
+	h.failures = 0
+	h.unhealthyUntil = time.Time{}
+	recovered := h.unhealthy
+	h.unhealthy = false
+	f.mu.Unlock()
+
+	if recovered && f.settings.OnHealthChange != nil {
+		f.settings.OnHealthChange(f.regions[i].Name, true)
+	}
+}
+
+// failure records a failed request to region i. a region that was unhealthy before becomes unhealthy again on its
+// first failure.
+func (f *Failover) failure(i int) {
+	f.mu.Lock()
+	h := &f.health[i]
+	h.failures++
+	changed := false
+	if h.unhealthy || h.failures >= f.settings.FailureThreshold {
+		changed = !h.unhealthy
+		h.unhealthy = true
+		h.unhealthyUntil = f.now().Add(f.settings.CoolDown)
+	}
+	f.mu.Unlock()
+
+	if changed && f.settings.OnHealthChange != nil {
+		f.settings.OnHealthChange(f.regions[i].Name, false)
+	}
+}
@@ -0,0 +1,142 @@
+package failover
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestFailover(settings Settings, names ...string) (*Failover, *fakeClock) {
+	regions := make([]Region, 0, len(names))
+	for _, name := range names {
+		regions = append(regions, Region{Name: name, BaseURL: "https://api." + name + ".snappmaps.ir"})
+	}
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	f := New(regions, settings)
+	f.now = clock.Now
+	return f, clock
+}
+
+func names(f *Failover, order []int) []string {
+	result := make([]string, 0, len(order))
+	for _, i := range order {
+		result = append(result, f.regions[i].Name)
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	f, _ := newTestFailover(Settings{}, "teh-1", "teh-2")
+
+	if f.settings.FailureThreshold != DefaultFailureThreshold {
+		t.Fatalf("FailureThreshold should be %d but it is %d", DefaultFailureThreshold, f.settings.FailureThreshold)
+	}
+	if f.settings.CoolDown != DefaultCoolDown {
+		t.Fatalf("CoolDown should be %s but it is %s", DefaultCoolDown, f.settings.CoolDown)
+	}
+	if f.Primary().Name != "teh-1" {
+		t.Fatalf("Primary should be teh-1 but it is %s", f.Primary().Name)
+	}
+	if len(f.Regions()) != 2 {
+		t.Fatalf("Regions should have 2 regions but it has %d", len(f.Regions()))
+	}
+	if (&Failover{}).Primary() != (Region{}) {
+		t.Fatal("Primary of a failover without regions should be empty")
+	}
+}
+
+func TestFailover_Health(t *testing.T) {
+	t.Run("unhealthy_after_threshold", func(t *testing.T) {
+		f, _ := newTestFailover(Settings{FailureThreshold: 2}, "teh-1", "teh-2", "teh-3")
+
+		f.failure(0)
+		if !f.Healthy("teh-1") {
+			t.Fatal("teh-1 should be healthy below the threshold")
+		}
+		f.failure(0)
+		if f.Healthy("teh-1") {
+			t.Fatal("teh-1 should be unhealthy after the threshold")
+		}
+		if order := names(f, f.order()); !equal(order, []string{"teh-2", "teh-3", "teh-1"}) {
+			t.Fatalf("unhealthy regions should be tried last but order is %v", order)
+		}
+	})
+
+	t.Run("success_resets_failures", func(t *testing.T) {
+		f, _ := newTestFailover(Settings{FailureThreshold: 2}, "teh-1", "teh-2")
+
+		f.failure(0)
+		f.success(0)
+		f.failure(0)
+		if !f.Healthy("teh-1") {
+			t.Fatal("teh-1 should be healthy because failures are not consecutive")
+		}
+	})
+
+	t.Run("sticky_primary", func(t *testing.T) {
+		var changes []string
+		f, clock := newTestFailover(Settings{
+			FailureThreshold: 1,
+			CoolDown:         10 * time.Second,
+			OnHealthChange: func(region string, healthy bool) {
+				if healthy {
+					changes = append(changes, region+":healthy")
+				} else {
+					changes = append(changes, region+":unhealthy")
+				}
+			},
+		}, "teh-1", "teh-2")
+
+		f.failure(0)
+		if order := names(f, f.order()); !equal(order, []string{"teh-2", "teh-1"}) {
+			t.Fatalf("order should be [teh-2 teh-1] but it is %v", order)
+		}
+
+		clock.Add(11 * time.Second)
+		if order := names(f, f.order()); !equal(order, []string{"teh-1", "teh-2"}) {
+			t.Fatalf("primary should be preferred after cool down but order is %v", order)
+		}
+
+		// the first failure after cool down makes it unhealthy again.
+		f.failure(0)
+		if f.Healthy("teh-1") {
+			t.Fatal("teh-1 should be unhealthy again")
+		}
+
+		clock.Add(11 * time.Second)
+		f.success(0)
+		if !equal(changes, []string{"teh-1:unhealthy", "teh-1:healthy"}) {
+			t.Fatalf("health changes should be [teh-1:unhealthy teh-1:healthy] but it is %v", changes)
+		}
+	})
+
+	t.Run("unknown_region", func(t *testing.T) {
+		f, _ := newTestFailover(Settings{}, "teh-1")
+		if f.Healthy("teh-9") {
+			t.Fatal("unknown regions should not be healthy")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package failover
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/logging"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+const (
+	// RegionKey is the span attribute holding the name of the region that served the request.
+	RegionKey = attribute.Key("smapp.region")
+	// FailoverEventName is the name of the span event added when a request fails over to the next region.
+	FailoverEventName = "failover"
+)
+
+// Transport is a http.RoundTripper that sends requests to the regions of a Failover.
+// requests whose url starts with the base url of the primary region are sent to the first healthy region. on
+// connection errors and 5xx responses, idempotent requests are sent to the next region, and the last response or
+// error is returned if all regions fail. requests to other urls, e.g. set using `WithURL`, are sent as is.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+	// Failover keeps the regions and their health.
+	Failover *Failover
+}
+
+// NewTransport wraps base with a Transport sending requests to the regions of the given failover.
+func NewTransport(base http.RoundTripper, failover *Failover) *Transport {
+	return &Transport{
+		Base:     base,
+		Failover: failover,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	primary := t.Failover.Primary().BaseURL
+	rawURL := req.URL.String()
+	path, ok := strings.CutPrefix(rawURL, primary)
+	if primary == "" || !ok || (path != "" && !strings.ContainsAny(path[:1], "/?")) {
+		return base.RoundTrip(req)
+	}
+
+	order := t.Failover.order()
+	if !retry.IsIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		order = order[:1]
+	}
+
+	ctx := req.Context()
+	span := trace.SpanFromContext(ctx)
+	for n, i := range order {
+		region := t.Failover.regions[i]
+		regionReq, err := regionRequest(req, region.BaseURL+path, n > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := base.RoundTrip(regionReq)
+		if ctx.Err() != nil {
+			// the caller gave up, which says nothing about the health of the region.
+			return response, err
+		}
+		if err == nil && response.StatusCode < http.StatusInternalServerError {
+			t.Failover.success(i)
+			span.SetAttributes(RegionKey.String(region.Name))
+			return response, nil
+		}
+
+		t.Failover.failure(i)
+		if n == len(order)-1 {
+			span.SetAttributes(RegionKey.String(region.Name))
+			return response, err
+		}
+
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		next := t.Failover.regions[order[n+1]].Name
+		span.AddEvent(FailoverEventName, trace.WithAttributes(
+			attribute.String("failover.from", region.Name),
+			attribute.String("failover.to", next),
+			attribute.Int("status_code", statusCode),
+		))
+		if logger := logging.FromContext(ctx); logger != nil {
+			logger.LogAttrs(ctx, slog.LevelDebug, "smapp request failover",
+				slog.String("from", region.Name),
+				slog.String("to", next),
+				slog.Int("status", statusCode),
+			)
+		}
+	}
+
+	// there is no region.
+	return base.RoundTrip(req)
+}
+
+// regionRequest returns a copy of req sent to rawURL. the body of req is read again if reuse is true.
+func regionRequest(req *http.Request, rawURL string, reuse bool) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	regionReq := req.Clone(req.Context())
+	regionReq.URL = u
+	regionReq.Host = u.Host
+	if reuse && req.GetBody != nil {
+		if regionReq.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	if reuse && req.Header.Get(deadline.Header) != "" {
+		deadline.SetHeader(regionReq)
+	}
+	return regionReq, nil
+}
@@ -0,0 +1,211 @@
+package failover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+// recordingSpan keeps the attributes and event names added to it.
+type recordingSpan struct {
+	noop.Span
+
+	mu         sync.Mutex
+	attributes map[attribute.Key]string
+	events     []string
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range kv {
+		s.attributes[attr.Key] = attr.Value.Emit()
+	}
+}
+
+func (s *recordingSpan) AddEvent(name string, _ ...trace.EventOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, name)
+}
+
+// newRegion starts a server answering with the given status code and returns it as a region.
+func newRegion(t *testing.T, name string, statusCode int, calls *int32) Region {
+	t.Helper()
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(name + ":" + r.URL.RequestURI() + ":" + string(body)))
+	}))
+	t.Cleanup(sv.Close)
+	return Region{Name: name, BaseURL: sv.URL}
+}
+
+func send(t *testing.T, tr *Transport, req *http.Request) (int, string) {
+	t.Helper()
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Run("fails_over_on_server_errors", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int32
+		f := New([]Region{
+			newRegion(t, "teh-1", http.StatusBadGateway, &primaryCalls),
+			newRegion(t, "teh-2", http.StatusOK, &secondaryCalls),
+		}, Settings{FailureThreshold: 2})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		span := &recordingSpan{attributes: map[attribute.Key]string{}}
+		ctx := retry.WithIdempotent(trace.ContextWithSpan(context.Background(), span))
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, f.Primary().BaseURL+"/eta/v1?engine=ocelot", bytes.NewBufferString("body"))
+		statusCode, body := send(t, tr, req)
+
+		if statusCode != http.StatusOK || body != "teh-2:/eta/v1?engine=ocelot:body" {
+			t.Fatalf("request should be served by teh-2 but response is %d %q", statusCode, body)
+		}
+		if span.attributes[RegionKey] != "teh-2" {
+			t.Fatalf("%s attribute should be teh-2 but it is %q", RegionKey, span.attributes[RegionKey])
+		}
+		if len(span.events) != 1 || span.events[0] != FailoverEventName {
+			t.Fatalf("span events should be [%s] but it is %v", FailoverEventName, span.events)
+		}
+
+		req, _ = http.NewRequest(http.MethodGet, f.Primary().BaseURL+"/eta/v1", nil)
+		send(t, tr, req)
+		if f.Healthy("teh-1") {
+			t.Fatal("teh-1 should be unhealthy")
+		}
+
+		req, _ = http.NewRequest(http.MethodGet, f.Primary().BaseURL+"/eta/v1", nil)
+		send(t, tr, req)
+		if primaryCalls != 2 || secondaryCalls != 3 {
+			t.Fatalf("unhealthy teh-1 should be skipped but calls are %d and %d", primaryCalls, secondaryCalls)
+		}
+	})
+
+	t.Run("base_url_with_trailing_slash", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int32
+		primary := newRegion(t, "teh-1", http.StatusBadGateway, &primaryCalls)
+		secondary := newRegion(t, "teh-2", http.StatusOK, &secondaryCalls)
+		primary.BaseURL += "/"
+		secondary.BaseURL += "/"
+		f := New([]Region{primary, secondary}, Settings{})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		// services remove the trailing slash of the base url when building their urls.
+		req, _ := http.NewRequest(http.MethodGet, strings.TrimSuffix(primary.BaseURL, "/")+"/eta/v1", nil)
+		if _, body := send(t, tr, req); body != "teh-2:/eta/v1:" {
+			t.Fatalf("request should be served by teh-2 but body is %q", body)
+		}
+		if primaryCalls != 1 || secondaryCalls != 1 {
+			t.Fatalf("each region should be called once but calls are %d and %d", primaryCalls, secondaryCalls)
+		}
+	})
+
+	t.Run("fails_over_on_connection_errors", func(t *testing.T) {
+		var calls int32
+		down := httptest.NewServer(http.NotFoundHandler())
+		down.Close()
+		f := New([]Region{
+			{Name: "teh-1", BaseURL: down.URL},
+			newRegion(t, "teh-2", http.StatusOK, &calls),
+		}, Settings{})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		req, _ := http.NewRequest(http.MethodGet, down.URL+"/search/v1", nil)
+		if _, body := send(t, tr, req); body != "teh-2:/search/v1:" {
+			t.Fatalf("request should be served by teh-2 but body is %q", body)
+		}
+	})
+
+	t.Run("returns_last_response", func(t *testing.T) {
+		var calls int32
+		f := New([]Region{
+			newRegion(t, "teh-1", http.StatusInternalServerError, &calls),
+			newRegion(t, "teh-2", http.StatusServiceUnavailable, &calls),
+		}, Settings{})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		req, _ := http.NewRequest(http.MethodGet, f.Primary().BaseURL+"/eta/v1", nil)
+		if statusCode, _ := send(t, tr, req); statusCode != http.StatusServiceUnavailable {
+			t.Fatalf("response of the last region should be returned but status code is %d", statusCode)
+		}
+	})
+
+	t.Run("not_idempotent", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int32
+		f := New([]Region{
+			newRegion(t, "teh-1", http.StatusInternalServerError, &primaryCalls),
+			newRegion(t, "teh-2", http.StatusOK, &secondaryCalls),
+		}, Settings{})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		req, _ := http.NewRequest(http.MethodPost, f.Primary().BaseURL+"/eta/v1", bytes.NewBufferString("body"))
+		if statusCode, _ := send(t, tr, req); statusCode != http.StatusInternalServerError {
+			t.Fatalf("status code should be 500 but it is %d", statusCode)
+		}
+		if secondaryCalls != 0 {
+			t.Fatalf("teh-2 should not be called but it is called %d times", secondaryCalls)
+		}
+	})
+
+	t.Run("other_urls_sent_as_is", func(t *testing.T) {
+		var primaryCalls, otherCalls int32
+		other := newRegion(t, "other", http.StatusOK, &otherCalls)
+		f := New([]Region{newRegion(t, "teh-1", http.StatusOK, &primaryCalls)}, Settings{})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		req, _ := http.NewRequest(http.MethodGet, other.BaseURL+"/eta/v1", nil)
+		send(t, tr, req)
+		req, _ = http.NewRequest(http.MethodGet, f.Primary().BaseURL+"0/eta/v1", nil)
+		_, _ = tr.RoundTrip(req)
+
+		if primaryCalls != 0 || otherCalls != 1 {
+			t.Fatalf("requests should be sent as is but calls are %d and %d", primaryCalls, otherCalls)
+		}
+	})
+
+	t.Run("cancelled_requests_not_counted", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer sv.Close()
+		var calls int32
+		f := New([]Region{
+			{Name: "teh-1", BaseURL: sv.URL},
+			newRegion(t, "teh-2", http.StatusOK, &calls),
+		}, Settings{FailureThreshold: 1})
+		tr := NewTransport(http.DefaultTransport, f)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL+"/eta/v1", nil)
+		if _, err := tr.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be DeadlineExceeded but it is %v", err)
+		}
+		if !f.Healthy("teh-1") || calls != 0 {
+			t.Fatal("teh-1 should be healthy and teh-2 should not be called")
+		}
+	})
+}
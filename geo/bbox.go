@@ -0,0 +1,82 @@
+package geo
+
+import "math"
+
+// BoundingBox is the rectangle between a south-west and a north-east corner. boxes crossing the antimeridian are
+// not supported.
+type BoundingBox struct {
+	// Min is the south-west corner of the box.
+	Min LatLng `json:"min"`
+	// Max is the north-east corner of the box.
+	Max LatLng `json:"max"`
+}
+
+// NewBoundingBox returns the smallest box containing points. the box of no points is empty, see IsEmpty.
+func NewBoundingBox(points ...LatLng) BoundingBox {
+	box := BoundingBox{
+		Min: LatLng{Lat: math.Inf(1), Lon: math.Inf(1)},
+		Max: LatLng{Lat: math.Inf(-1), Lon: math.Inf(-1)},
+	}
+	for _, p := range points {
+		box = box.Extend(p)
+	}
+	return box
+}
+
+// BoundingBoxAround returns the box containing the circle of radius meters around center.
+func BoundingBoxAround(center LatLng, radius float64) BoundingBox {
+	north := Destination(center, 0, radius)
+	south := Destination(center, 180, radius)
+	// the widest part of the circle is on the parallel closest to the pole, not on the parallel of center.
+	widest := center
+	if math.Abs(north.Lat) > math.Abs(south.Lat) {
+		widest.Lat = north.Lat
+	} else {
+		widest.Lat = south.Lat
+	}
+	east := Destination(widest, 90, radius)
+	west := Destination(widest, 270, radius)
+	return BoundingBox{
+		Min: LatLng{Lat: south.Lat, Lon: west.Lon},
+		Max: LatLng{Lat: north.Lat, Lon: east.Lon},
+	}
+}
+
+// IsEmpty reports whether b contains no points.
+func (b BoundingBox) IsEmpty() bool {
+	return b.Min.Lat > b.Max.Lat || b.Min.Lon > b.Max.Lon
+}
+
+// Extend returns the smallest box containing b and p.
+func (b BoundingBox) Extend(p LatLng) BoundingBox {
+	return BoundingBox{
+		Min: LatLng{Lat: math.Min(b.Min.Lat, p.Lat), Lon: math.Min(b.Min.Lon, p.Lon)},
+		Max: LatLng{Lat: math.Max(b.Max.Lat, p.Lat), Lon: math.Max(b.Max.Lon, p.Lon)},
+	}
+}
+
+// Union returns the smallest box containing b and other.
+func (b BoundingBox) Union(other BoundingBox) BoundingBox {
+	if other.IsEmpty() {
+		return b
+	}
+	return b.Extend(other.Min).Extend(other.Max)
+}
+
+// Contains reports whether p is inside b or on its edges.
+func (b BoundingBox) Contains(p LatLng) bool {
+	return p.Lat >= b.Min.Lat && p.Lat <= b.Max.Lat && p.Lon >= b.Min.Lon && p.Lon <= b.Max.Lon
+}
+
+// Intersects reports whether b and other have any point in common.
+func (b BoundingBox) Intersects(other BoundingBox) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return b.Min.Lat <= other.Max.Lat && other.Min.Lat <= b.Max.Lat && b.Min.Lon <= other.Max.Lon && other.Min.Lon <= b.Max.Lon
+}
+
+// Center returns the middle point of b.
+func (b BoundingBox) Center() LatLng {
+	return LatLng{Lat: (b.Min.Lat + b.Max.Lat) / 2, Lon: (b.Min.Lon + b.Max.Lon) / 2}
+}
@@ -0,0 +1,59 @@
+package geo
+
+import "testing"
+
+func TestNewBoundingBox(t *testing.T) {
+	if box := NewBoundingBox(); !box.IsEmpty() {
+		t.Fatalf("box of no points should be empty but it is %+v", box)
+	}
+
+	box := NewBoundingBox(tehran, karaj)
+	if box.Min != (LatLng{Lat: 35.6892, Lon: 50.9391}) || box.Max != (LatLng{Lat: 35.84, Lon: 51.389}) {
+		t.Fatalf("unexpected box: %+v", box)
+	}
+	if !box.Contains(tehran) || !box.Contains(box.Center()) {
+		t.Fatal("box should contain its points and its center")
+	}
+	if box.Contains(LatLng{Lat: 36, Lon: 51}) {
+		t.Fatal("box should not contain points out of it")
+	}
+
+	single := NewBoundingBox(tehran)
+	if single.IsEmpty() || !single.Contains(tehran) {
+		t.Fatalf("box of a single point should contain it: %+v", single)
+	}
+}
+
+func TestBoundingBox_UnionAndIntersects(t *testing.T) {
+	a := NewBoundingBox(LatLng{Lat: 0, Lon: 0}, LatLng{Lat: 1, Lon: 1})
+	b := NewBoundingBox(LatLng{Lat: 0.5, Lon: 0.5}, LatLng{Lat: 2, Lon: 2})
+	c := NewBoundingBox(LatLng{Lat: 3, Lon: 3}, LatLng{Lat: 4, Lon: 4})
+
+	if !a.Intersects(b) || a.Intersects(c) || a.Intersects(NewBoundingBox()) {
+		t.Fatal("unexpected intersection of boxes")
+	}
+	if u := a.Union(c); u.Min != a.Min || u.Max != c.Max {
+		t.Fatalf("unexpected union: %+v", u)
+	}
+	if u := a.Union(NewBoundingBox()); u != a {
+		t.Fatalf("union with an empty box should not change the box: %+v", u)
+	}
+	if u := NewBoundingBox().Union(a); u != a {
+		t.Fatalf("union of an empty box should be the other box: %+v", u)
+	}
+}
+
+func TestBoundingBoxAround(t *testing.T) {
+	box := BoundingBoxAround(tehran, 1000)
+	for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		if p := Destination(tehran, bearing, 999); !box.Contains(p) {
+			t.Fatalf("box should contain %v at bearing %v", p, bearing)
+		}
+	}
+	if box.Contains(Destination(tehran, 0, 1100)) || box.Contains(Destination(tehran, 90, 1100)) {
+		t.Fatal("box should not contain points far out of the circle")
+	}
+	if !almostEqual(box.Center().Lat, tehran.Lat, 1e-6) || !almostEqual(box.Center().Lon, tehran.Lon, 1e-6) {
+		t.Fatalf("center of the box should be the center of the circle: %v", box.Center())
+	}
+}
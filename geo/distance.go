@@ -0,0 +1,48 @@
+package geo
+
+import "math"
+
+// EarthRadius is the mean radius of the earth in meters, used by all spherical computations of the package.
+const EarthRadius = 6371008.8
+
+// Distance returns the great-circle distance between a and b in meters, using the haversine formula.
+func Distance(a, b LatLng) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bearing returns the initial bearing of the great-circle path from `from` to `to`, in degrees clockwise from north
+// within [0, 360).
+func Bearing(from, to LatLng) float64 {
+	lat1, lat2 := radians(from.Lat), radians(to.Lat)
+	dLon := radians(to.Lon - from.Lon)
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Destination returns the point reached by traveling distance meters from `from` on the great-circle path with the
+// given initial bearing in degrees.
+func Destination(from LatLng, bearing, distance float64) LatLng {
+	lat1, lon1 := radians(from.Lat), radians(from.Lon)
+	angle := distance / EarthRadius
+	theta := radians(bearing)
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+	return LatLng{Lat: degrees(lat2), Lon: normalizeLon(degrees(lon2))}
+}
+
+// PathLength returns the total distance of traveling through points in order, in meters.
+func PathLength(points []LatLng) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		length += Distance(points[i-1], points[i])
+	}
+	return length
+}
@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	tehran = LatLng{Lat: 35.6892, Lon: 51.3890}
+	karaj  = LatLng{Lat: 35.8400, Lon: 50.9391}
+)
+
+func almostEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestDistance(t *testing.T) {
+	if d := Distance(tehran, tehran); d != 0 {
+		t.Fatalf("distance of a point to itself should be 0 but it is %v", d)
+	}
+	// about 44km by the great-circle path.
+	if d := Distance(tehran, karaj); !almostEqual(d, 43800, 500) {
+		t.Fatalf("distance of tehran and karaj should be about 43.8km but it is %v", d)
+	}
+	if Distance(tehran, karaj) != Distance(karaj, tehran) {
+		t.Fatal("distance should be symmetric")
+	}
+	// a degree of latitude is about 111.2km.
+	if d := Distance(LatLng{Lat: 0, Lon: 0}, LatLng{Lat: 1, Lon: 0}); !almostEqual(d, 111195, 1) {
+		t.Fatalf("a degree of latitude should be about 111.2km but it is %v", d)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	origin := LatLng{Lat: 0, Lon: 0}
+	tests := []struct {
+		to   LatLng
+		want float64
+	}{
+		{to: LatLng{Lat: 1, Lon: 0}, want: 0},
+		{to: LatLng{Lat: 0, Lon: 1}, want: 90},
+		{to: LatLng{Lat: -1, Lon: 0}, want: 180},
+		{to: LatLng{Lat: 0, Lon: -1}, want: 270},
+	}
+	for _, tt := range tests {
+		if got := Bearing(origin, tt.to); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("Bearing to %v = %v, want %v", tt.to, got, tt.want)
+		}
+	}
+	// karaj is west-north-west of tehran.
+	if b := Bearing(tehran, karaj); b < 270 || b > 300 {
+		t.Fatalf("bearing from tehran to karaj should be about 292 but it is %v", b)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	d := Destination(tehran, Bearing(tehran, karaj), Distance(tehran, karaj))
+	if Distance(d, karaj) > 0.01 {
+		t.Fatalf("destination should be karaj but it is %v", d)
+	}
+
+	if d := Destination(LatLng{Lat: 0, Lon: 179.5}, 90, 111195); !almostEqual(d.Lon, -179.5, 1e-3) {
+		t.Fatalf("destination should wrap around the antimeridian but it is %v", d)
+	}
+}
+
+func TestPathLength(t *testing.T) {
+	if l := PathLength(nil); l != 0 {
+		t.Fatalf("length of an empty path should be 0 but it is %v", l)
+	}
+	path := []LatLng{tehran, karaj, tehran}
+	if l := PathLength(path); !almostEqual(l, 2*Distance(tehran, karaj), 1e-6) {
+		t.Fatalf("length of a round trip should be twice the distance but it is %v", l)
+	}
+}
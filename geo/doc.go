@@ -0,0 +1,6 @@
+// Package geo contains LatLng, the common coordinate type of the SDK, and the geometry used on it: validation,
+// great-circle distance, bearing, destination points, bounding boxes and polygons, along with GeoJSON, encoded
+// polylines and geohash cells. each service converts its own coordinate type to and from LatLng, e.g.
+// eta.PointFromLatLng and smappshot.Location.LatLng, so callers can keep locations in one representation. distances
+// are in meters and angles in degrees.
+package geo
@@ -0,0 +1,234 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// MaxGeohashPrecision is the longest supported geohash, whose cells are a few centimeters wide.
+	MaxGeohashPrecision = 12
+
+	// maxGeohashCover is the largest number of cells enumerated by GeohashCover.
+	maxGeohashCover = 1 << 20
+
+	geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+var (
+	// ErrInvalidGeohash is returned for malformed geohashes and for precisions out of [1, MaxGeohashPrecision].
+	ErrInvalidGeohash = errors.New("smapp geo: invalid geohash")
+	// ErrTooManyCells is returned by GeohashCover if the polygon is too large for the precision.
+	ErrTooManyCells = errors.New("smapp geo: too many cells")
+)
+
+// EncodeGeohash returns the geohash of the cell of the given precision containing ll. cells of a precision have the
+// same size in degrees, e.g. about 153m × 153m for 7 and 38m × 19m for 8 at the equator, and are narrower towards
+// the poles. geohashes are prefixes of the geohashes of their smaller cells, so nearby locations share a geohash,
+// which makes them keys for caching or batching requests of nearby locations.
+// It returns an error if ll is invalid, or precision is out of [1, MaxGeohashPrecision].
+func EncodeGeohash(ll LatLng, precision int) (string, error) {
+	if precision < 1 || precision > MaxGeohashPrecision {
+		return "", fmt.Errorf("%w: precision should be in [1, %d] but it is %d", ErrInvalidGeohash, MaxGeohashPrecision, precision)
+	}
+	if err := ll.Validate(); err != nil {
+		return "", err
+	}
+
+	box := BoundingBox{Min: LatLng{Lat: -90, Lon: -180}, Max: LatLng{Lat: 90, Lon: 180}}
+	hash := make([]byte, 0, precision)
+	// bits alternate between longitude and latitude, starting with longitude, and each halves the cell.
+	isLon := true
+	for range precision {
+		index := 0
+		for range 5 {
+			index <<= 1
+			if isLon {
+				if mid := (box.Min.Lon + box.Max.Lon) / 2; ll.Lon >= mid {
+					index |= 1
+					box.Min.Lon = mid
+				} else {
+					box.Max.Lon = mid
+				}
+			} else {
+				if mid := (box.Min.Lat + box.Max.Lat) / 2; ll.Lat >= mid {
+					index |= 1
+					box.Min.Lat = mid
+				} else {
+					box.Max.Lat = mid
+				}
+			}
+			isLon = !isLon
+		}
+		hash = append(hash, geohashAlphabet[index])
+	}
+	return string(hash), nil
+}
+
+// CellKey returns a key that is equal for the locations in the same geohash cell of the given precision, like
+// `geohash=tnke1du`, for bucketing requests of nearby locations in cache or coalescing keys. precision is clamped to
+// [1, MaxGeohashPrecision]. invalid locations have no cell, so their key is the exact location, like `95,51.4`.
+func CellKey(ll LatLng, precision int) string {
+	precision = min(max(precision, 1), MaxGeohashPrecision)
+	cell, err := EncodeGeohash(ll, precision)
+	if err != nil {
+		return ll.String()
+	}
+	return "geohash=" + cell
+}
+
+// DecodeGeohash returns the cell of a geohash. its center is the location the geohash stands for, with an error of
+// half of the size of the cell. geohashes are case-insensitive.
+// It returns an error matching ErrInvalidGeohash if hash is empty, too long or has characters out of the alphabet.
+func DecodeGeohash(hash string) (BoundingBox, error) {
+	if len(hash) == 0 || len(hash) > MaxGeohashPrecision {
+		return BoundingBox{}, fmt.Errorf("%w: length should be in [1, %d] but it is %d", ErrInvalidGeohash, MaxGeohashPrecision, len(hash))
+	}
+
+	box := BoundingBox{Min: LatLng{Lat: -90, Lon: -180}, Max: LatLng{Lat: 90, Lon: 180}}
+	isLon := true
+	for i, c := range strings.ToLower(hash) {
+		index := strings.IndexRune(geohashAlphabet, c)
+		if index < 0 {
+			return BoundingBox{}, fmt.Errorf("%w: unexpected character %q at %d", ErrInvalidGeohash, c, i)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := index>>bit&1 == 1
+			if isLon {
+				if mid := (box.Min.Lon + box.Max.Lon) / 2; set {
+					box.Min.Lon = mid
+				} else {
+					box.Max.Lon = mid
+				}
+			} else {
+				if mid := (box.Min.Lat + box.Max.Lat) / 2; set {
+					box.Min.Lat = mid
+				} else {
+					box.Max.Lat = mid
+				}
+			}
+			isLon = !isLon
+		}
+	}
+	return box, nil
+}
+
+// GeohashNeighbors returns the geohashes of the cells around the cell of hash, with the same precision, in the order
+// of north, northeast, east, southeast, south, southwest, west and northwest. cells across the antimeridian are
+// included, and cells beyond the poles are left out, so cells next to a pole have five neighbors.
+// It returns an error matching ErrInvalidGeohash if hash is invalid.
+func GeohashNeighbors(hash string) ([]string, error) {
+	box, err := DecodeGeohash(hash)
+	if err != nil {
+		return nil, err
+	}
+	center := box.Center()
+	height, width := box.Max.Lat-box.Min.Lat, box.Max.Lon-box.Min.Lon
+
+	neighbors := make([]string, 0, 8)
+	for _, d := range [][2]float64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}} {
+		ll := LatLng{Lat: center.Lat + d[0]*height, Lon: normalizeLon(center.Lon + d[1]*width)}
+		if ll.Lat < -90 || ll.Lat > 90 {
+			continue
+		}
+		neighbor, err := EncodeGeohash(ll, len(hash))
+		if err != nil {
+			return nil, err
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+	return neighbors, nil
+}
+
+// GeohashCover returns the geohashes of the cells of the given precision intersecting p, i.e. the cells to look up
+// or prefetch for locations inside of p. cells inside of holes are left out, and the cells are ordered from south to
+// north and west to east. like Polygon.Contains, intersections are computed on the plane of lat and lon.
+// It returns an error if p is invalid, precision is out of [1, MaxGeohashPrecision], or p covers more than about a
+// million cells, which matches ErrTooManyCells.
+func GeohashCover(p Polygon, precision int) ([]string, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	box := p.BoundingBox()
+	first, err := EncodeGeohash(box.Min, precision)
+	if err != nil {
+		return nil, err
+	}
+	cell, _ := DecodeGeohash(first)
+	height, width := cell.Max.Lat-cell.Min.Lat, cell.Max.Lon-cell.Min.Lon
+
+	rows := int(math.Floor((box.Max.Lat-cell.Min.Lat)/height)) + 1
+	columns := int(math.Floor((box.Max.Lon-cell.Min.Lon)/width)) + 1
+	if rows*columns > maxGeohashCover {
+		return nil, fmt.Errorf("%w: polygon covers %d × %d cells of precision %d", ErrTooManyCells, rows, columns, precision)
+	}
+
+	center := cell.Center()
+	var cells []string
+	// centers beyond the poles or the antimeridian are clamped, which may repeat the cells of the last row or column.
+	seen := make(map[string]bool)
+	for row := range rows {
+		for column := range columns {
+			ll := LatLng{Lat: math.Min(center.Lat+float64(row)*height, 90), Lon: math.Min(center.Lon+float64(column)*width, 180)}
+			hash, err := EncodeGeohash(ll, precision)
+			if err != nil {
+				return nil, err
+			}
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			if c, _ := DecodeGeohash(hash); p.intersects(c) {
+				cells = append(cells, hash)
+			}
+		}
+	}
+	return cells, nil
+}
+
+// intersects reports whether p and the box b have common points, on the plane of lat and lon.
+func (p Polygon) intersects(b BoundingBox) bool {
+	corners := []LatLng{b.Min, {Lat: b.Min.Lat, Lon: b.Max.Lon}, b.Max, {Lat: b.Max.Lat, Lon: b.Min.Lon}}
+	for _, corner := range append(corners, b.Center()) {
+		if p.Contains(corner) {
+			return true
+		}
+	}
+	// no point of b is in p, so b intersects p only if a ring passes through it.
+	for _, ring := range append([]Ring{p.Boundary}, p.Holes...) {
+		open := ring.open()
+		for i := range open {
+			a, c := open[i], open[(i+1)%len(open)]
+			if b.Contains(a) {
+				return true
+			}
+			for j := range corners {
+				if segmentsIntersect(a, c, corners[j], corners[(j+1)%len(corners)]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect reports whether the segments p1-p2 and q1-q2 have common points, on the plane of lat and lon.
+func segmentsIntersect(p1, p2, q1, q2 LatLng) bool {
+	orientation := func(a, b, c LatLng) float64 {
+		return (b.Lon-a.Lon)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lon-a.Lon)
+	}
+	onSegment := func(a, b, c LatLng) bool {
+		return math.Min(a.Lon, b.Lon) <= c.Lon && c.Lon <= math.Max(a.Lon, b.Lon) &&
+			math.Min(a.Lat, b.Lat) <= c.Lat && c.Lat <= math.Max(a.Lat, b.Lat)
+	}
+
+	d1, d2 := orientation(q1, q2, p1), orientation(q1, q2, p2)
+	d3, d4 := orientation(p1, p2, q1), orientation(p1, p2, q2)
+	if (d1 > 0) != (d2 > 0) && (d3 > 0) != (d4 > 0) && d1 != 0 && d2 != 0 && d3 != 0 && d4 != 0 {
+		return true
+	}
+	return d1 == 0 && onSegment(q1, q2, p1) || d2 == 0 && onSegment(q1, q2, p2) ||
+		d3 == 0 && onSegment(p1, p2, q1) || d4 == 0 && onSegment(p1, p2, q2)
+}
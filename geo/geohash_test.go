@@ -0,0 +1,155 @@
+package geo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		ll        LatLng
+		precision int
+		want      string
+	}{
+		{ll: LatLng{Lat: 42.6, Lon: -5.6}, precision: 5, want: "ezs42"},
+		{ll: LatLng{Lat: 57.64911, Lon: 10.40744}, precision: 11, want: "u4pruydqqvj"},
+		{ll: LatLng{Lat: -90, Lon: -180}, precision: 3, want: "000"},
+		{ll: LatLng{Lat: 90, Lon: 180}, precision: 3, want: "zzz"},
+	}
+	for _, tt := range tests {
+		if got, err := EncodeGeohash(tt.ll, tt.precision); err != nil || got != tt.want {
+			t.Errorf("EncodeGeohash(%v, %d) = %q, %v, want %q", tt.ll, tt.precision, got, err, tt.want)
+		}
+	}
+
+	if _, err := EncodeGeohash(LatLng{}, 0); !errors.Is(err, ErrInvalidGeohash) {
+		t.Fatalf("precision 0 should return ErrInvalidGeohash but it returned %v", err)
+	}
+	if _, err := EncodeGeohash(LatLng{}, MaxGeohashPrecision+1); !errors.Is(err, ErrInvalidGeohash) {
+		t.Fatalf("precision %d should return ErrInvalidGeohash but it returned %v", MaxGeohashPrecision+1, err)
+	}
+	if _, err := EncodeGeohash(LatLng{Lon: 181}, 5); !errors.Is(err, ErrInvalidLongitude) {
+		t.Fatalf("invalid location should return ErrInvalidLongitude but it returned %v", err)
+	}
+}
+
+func TestCellKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		ll        LatLng
+		precision int
+		want      string
+	}{
+		{name: "cell", ll: LatLng{Lat: 42.6, Lon: -5.6}, precision: 5, want: "geohash=ezs42"},
+		{name: "zero_precision", ll: LatLng{Lat: 42.6, Lon: -5.6}, precision: 0, want: "geohash=e"},
+		{name: "long_precision", ll: LatLng{Lat: 57.64911, Lon: 10.40744}, precision: 20, want: "geohash=u4pruydqqvj8"},
+		{name: "invalid_location", ll: LatLng{Lat: 95, Lon: 51.4}, precision: 5, want: "95,51.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CellKey(tt.ll, tt.precision); got != tt.want {
+				t.Fatalf("CellKey(%v, %d) should be %s but it is %s", tt.ll, tt.precision, tt.want, got)
+			}
+		})
+	}
+
+	if CellKey(LatLng{Lat: 35.70001, Lon: 51.40001}, 7) != CellKey(LatLng{Lat: 35.70002, Lon: 51.40002}, 7) {
+		t.Fatal("nearby locations should have the same key")
+	}
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	box, err := DecodeGeohash("EZS42")
+	if err != nil {
+		t.Fatalf("DecodeGeohash should not return error: %s", err)
+	}
+	want := BoundingBox{Min: LatLng{Lat: 42.583007812, Lon: -5.625}, Max: LatLng{Lat: 42.626953125, Lon: -5.581054688}}
+	if !almostEqual(box.Min.Lat, want.Min.Lat, 1e-6) || !almostEqual(box.Max.Lon, want.Max.Lon, 1e-6) {
+		t.Fatalf("DecodeGeohash = %+v, want %+v", box, want)
+	}
+	if !box.Contains(LatLng{Lat: 42.6, Lon: -5.6}) {
+		t.Fatal("cell should contain the encoded location")
+	}
+
+	for _, ll := range []LatLng{{Lat: 35.6997, Lon: 51.338}, {Lat: -33.8688, Lon: 151.2093}, {Lat: 89.9, Lon: -179.9}} {
+		hash, _ := EncodeGeohash(ll, 9)
+		if box, err := DecodeGeohash(hash); err != nil || !box.Contains(ll) {
+			t.Errorf("cell %q should contain %v but it is %+v, err: %v", hash, ll, box, err)
+		}
+	}
+
+	for _, hash := range []string{"", "ezs4a", "ezs42ezs42ezs", "ezs 4"} {
+		if _, err := DecodeGeohash(hash); !errors.Is(err, ErrInvalidGeohash) {
+			t.Errorf("%q: DecodeGeohash should return ErrInvalidGeohash but it returned %v", hash, err)
+		}
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	neighbors, err := GeohashNeighbors("ezs42")
+	if err != nil {
+		t.Fatalf("GeohashNeighbors should not return error: %s", err)
+	}
+	if want := []string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"}; !reflect.DeepEqual(neighbors, want) {
+		t.Fatalf("GeohashNeighbors = %v, want %v", neighbors, want)
+	}
+
+	// the east of the eastmost cell is the westmost cell, across the antimeridian.
+	neighbors, _ = GeohashNeighbors("r")
+	if neighbors[2] != "2" {
+		t.Fatalf("east neighbor of r should be 2 but neighbors are %v", neighbors)
+	}
+
+	neighbors, _ = GeohashNeighbors("zzz")
+	if len(neighbors) != 5 {
+		t.Fatalf("cells next to the pole should have 5 neighbors but they are %v", neighbors)
+	}
+
+	if _, err := GeohashNeighbors("a"); !errors.Is(err, ErrInvalidGeohash) {
+		t.Fatalf("invalid geohash should return ErrInvalidGeohash but it returned %v", err)
+	}
+}
+
+func TestGeohashCover(t *testing.T) {
+	// a polygon inside a single cell of precision 5.
+	box, _ := DecodeGeohash("ezs42")
+	center := box.Center()
+	small := Polygon{Boundary: Ring{
+		{Lat: center.Lat - 0.001, Lon: center.Lon - 0.001},
+		{Lat: center.Lat - 0.001, Lon: center.Lon + 0.001},
+		{Lat: center.Lat + 0.001, Lon: center.Lon},
+	}}
+	if cells, err := GeohashCover(small, 5); err != nil || !reflect.DeepEqual(cells, []string{"ezs42"}) {
+		t.Fatalf("GeohashCover = %v, %v, want [ezs42]", cells, err)
+	}
+
+	// cells of precision 2 are 5.625° × 11.25°, so the square covers 8 × 4 cells, and 4 × 2 of them are in its hole.
+	p := Polygon{Boundary: square(0.1, 44.9), Holes: []Ring{square(11.2, 33.8)}}
+	cells, err := GeohashCover(p, 2)
+	if err != nil {
+		t.Fatalf("GeohashCover should not return error: %s", err)
+	}
+	if len(cells) != 24 {
+		t.Fatalf("cover should have 24 cells but it has %d: %v", len(cells), cells)
+	}
+	for _, cell := range cells {
+		if c, _ := DecodeGeohash(cell); c.Min.Lat >= 11.25 && c.Max.Lat <= 33.75 && c.Min.Lon >= 11.25 && c.Max.Lon <= 33.75 {
+			t.Fatalf("cell %q inside the hole should be left out", cell)
+		}
+	}
+
+	// a thin diagonal strip crosses cells whose corners and centers are all out of it.
+	strip := Polygon{Boundary: Ring{{Lat: 0.1, Lon: 0.1}, {Lat: 0.1, Lon: 0.2}, {Lat: 44.9, Lon: 44.9}, {Lat: 44.8, Lon: 44.9}}}
+	cells, _ = GeohashCover(strip, 2)
+	if len(cells) < 8 || len(cells) > 16 {
+		t.Fatalf("strip should be covered by the cells along the diagonal but cover is %v", cells)
+	}
+
+	if _, err := GeohashCover(p, 9); !errors.Is(err, ErrTooManyCells) {
+		t.Fatalf("large polygon with long geohashes should return ErrTooManyCells but it returned %v", err)
+	}
+	if _, err := GeohashCover(Polygon{}, 5); !errors.Is(err, ErrInvalidRing) {
+		t.Fatalf("invalid polygon should return ErrInvalidRing but it returned %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GeoJSON object types used by the SDK.
+const (
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+	TypePoint             = "Point"
+	TypePolygon           = "Polygon"
+)
+
+// ErrInvalidGeoJSON is returned for GeoJSON documents that can not be decoded to the expected objects.
+var ErrInvalidGeoJSON = errors.New("smapp geo: invalid geojson")
+
+// Geometry is a GeoJSON geometry. its coordinates are kept raw, so any geometry can be decoded, and are converted by
+// Point and Polygon. positions are [lon, lat] pairs.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Feature is a GeoJSON feature. ID is a string or a number. numbers of decoded features, in ID and Properties, are
+// json.Number, so large integers like ids are not rounded.
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// FeatureCollection is a GeoJSON feature collection. Properties is a foreign member holding properties of the whole
+// collection, which is ignored by tools that do not know it.
+type FeatureCollection struct {
+	Type       string         `json:"type"`
+	Features   []Feature      `json:"features"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// NewGeometry creates a geometry of the given type with coordinates encoded as JSON.
+func NewGeometry(geometryType string, coordinates any) (Geometry, error) {
+	raw, err := json.Marshal(coordinates)
+	if err != nil {
+		return Geometry{}, fmt.Errorf("%w: could not encode coordinates: %w", ErrInvalidGeoJSON, err)
+	}
+	return Geometry{Type: geometryType, Coordinates: raw}, nil
+}
+
+// PointGeometry creates a Point geometry of ll.
+func PointGeometry(ll LatLng) Geometry {
+	g, _ := NewGeometry(TypePoint, position(ll))
+	return g
+}
+
+// PolygonGeometry creates a Polygon geometry of p. rings are closed, as required by GeoJSON.
+func PolygonGeometry(p Polygon) Geometry {
+	rings := make([][][]float64, 0, 1+len(p.Holes))
+	for _, ring := range append([]Ring{p.Boundary}, p.Holes...) {
+		positions := make([][]float64, 0, len(ring)+1)
+		for _, ll := range ring {
+			positions = append(positions, position(ll))
+		}
+		if open := ring.open(); len(open) > 0 && len(open) == len(ring) {
+			positions = append(positions, position(ring[0]))
+		}
+		rings = append(rings, positions)
+	}
+	g, _ := NewGeometry(TypePolygon, rings)
+	return g
+}
+
+// Point decodes g as a Point geometry.
+func (g Geometry) Point() (LatLng, error) {
+	if g.Type != TypePoint {
+		return LatLng{}, fmt.Errorf("%w: expected %s geometry but got %q", ErrInvalidGeoJSON, TypePoint, g.Type)
+	}
+	var coordinates []float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return LatLng{}, fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	return fromPosition(coordinates)
+}
+
+// Polygon decodes g as a Polygon geometry.
+func (g Geometry) Polygon() (Polygon, error) {
+	if g.Type != TypePolygon {
+		return Polygon{}, fmt.Errorf("%w: expected %s geometry but got %q", ErrInvalidGeoJSON, TypePolygon, g.Type)
+	}
+	var coordinates [][][]float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		return Polygon{}, fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	if len(coordinates) == 0 {
+		return Polygon{}, fmt.Errorf("%w: polygon has no rings", ErrInvalidGeoJSON)
+	}
+	rings := make([]Ring, 0, len(coordinates))
+	for _, positions := range coordinates {
+		ring := make(Ring, 0, len(positions))
+		for _, pos := range positions {
+			ll, err := fromPosition(pos)
+			if err != nil {
+				return Polygon{}, err
+			}
+			ring = append(ring, ll)
+		}
+		rings = append(rings, ring)
+	}
+	return Polygon{Boundary: rings[0], Holes: rings[1:]}, nil
+}
+
+// NewFeature creates a feature of geometry. properties may be nil.
+func NewFeature(id any, geometry Geometry, properties map[string]any) Feature {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	return Feature{Type: TypeFeature, ID: id, Geometry: &geometry, Properties: properties}
+}
+
+// NewFeatureCollection creates a collection of features.
+func NewFeatureCollection(features ...Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: TypeFeatureCollection, Features: features}
+}
+
+// DecodeFeature decodes a GeoJSON Feature.
+func DecodeFeature(data []byte) (Feature, error) {
+	var f Feature
+	if err := unmarshal(data, &f); err != nil {
+		return Feature{}, fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	if f.Type != TypeFeature {
+		return Feature{}, fmt.Errorf("%w: expected %s but got %q", ErrInvalidGeoJSON, TypeFeature, f.Type)
+	}
+	if f.Geometry == nil {
+		return Feature{}, fmt.Errorf("%w: feature has no geometry", ErrInvalidGeoJSON)
+	}
+	return f, nil
+}
+
+// DecodeFeatureCollection decodes a GeoJSON FeatureCollection.
+func DecodeFeatureCollection(data []byte) (FeatureCollection, error) {
+	var fc FeatureCollection
+	if err := unmarshal(data, &fc); err != nil {
+		return FeatureCollection{}, fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	if fc.Type != TypeFeatureCollection {
+		return FeatureCollection{}, fmt.Errorf("%w: expected %s but got %q", ErrInvalidGeoJSON, TypeFeatureCollection, fc.Type)
+	}
+	for i, f := range fc.Features {
+		if f.Type != TypeFeature || f.Geometry == nil {
+			return FeatureCollection{}, fmt.Errorf("%w: feature %d is not a feature with geometry", ErrInvalidGeoJSON, i)
+		}
+	}
+	return fc, nil
+}
+
+// Properties returns the JSON fields of v as feature properties, without the omitted fields. it is used to keep all
+// fields of a struct, except its coordinates, in the properties of its feature.
+func Properties(v any, omit ...string) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not encode properties: %w", ErrInvalidGeoJSON, err)
+	}
+	properties := map[string]any{}
+	if err := unmarshal(raw, &properties); err != nil {
+		return nil, fmt.Errorf("%w: properties should be an object: %w", ErrInvalidGeoJSON, err)
+	}
+	for _, key := range omit {
+		delete(properties, key)
+	}
+	return properties, nil
+}
+
+// DecodeProperties decodes feature properties into v, the reverse of Properties.
+func DecodeProperties(properties map[string]any, v any) error {
+	raw, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: could not decode properties: %w", ErrInvalidGeoJSON, err)
+	}
+	return nil
+}
+
+// unmarshal decodes data into v, keeping numbers as json.Number.
+func unmarshal(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// position returns the GeoJSON position of ll.
+func position(ll LatLng) []float64 {
+	return []float64{ll.Lon, ll.Lat}
+}
+
+// fromPosition converts a GeoJSON position to a coordinate and validates it.
+func fromPosition(pos []float64) (LatLng, error) {
+	if len(pos) < 2 {
+		return LatLng{}, fmt.Errorf("%w: expected [lon, lat] position but got %v", ErrInvalidGeoJSON, pos)
+	}
+	ll := LatLng{Lat: pos[1], Lon: pos[0]}
+	if err := ll.Validate(); err != nil {
+		return LatLng{}, fmt.Errorf("%w: %w", ErrInvalidGeoJSON, err)
+	}
+	return ll, nil
+}
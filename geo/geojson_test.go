@@ -0,0 +1,143 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGeometry_Point(t *testing.T) {
+	g := PointGeometry(LatLng{Lat: 35.7, Lon: 51.4})
+	if g.Type != TypePoint || string(g.Coordinates) != "[51.4,35.7]" {
+		t.Fatalf("point should be encoded as [lon, lat] but it is %s %s", g.Type, g.Coordinates)
+	}
+	ll, err := g.Point()
+	if err != nil || ll != (LatLng{Lat: 35.7, Lon: 51.4}) {
+		t.Fatalf("unexpected point %+v, err: %v", ll, err)
+	}
+
+	tests := map[string]Geometry{
+		"polygon":  PolygonGeometry(Polygon{Boundary: square(0, 1)}),
+		"short":    {Type: TypePoint, Coordinates: json.RawMessage("[51.4]")},
+		"swapped":  {Type: TypePoint, Coordinates: json.RawMessage("[35.7,151.4]")},
+		"not_list": {Type: TypePoint, Coordinates: json.RawMessage(`"51.4,35.7"`)},
+	}
+	for name, g := range tests {
+		if _, err := g.Point(); !errors.Is(err, ErrInvalidGeoJSON) {
+			t.Errorf("%s: Point should return ErrInvalidGeoJSON but it returned %v", name, err)
+		}
+	}
+}
+
+func TestGeometry_Polygon(t *testing.T) {
+	p := Polygon{Boundary: square(0, 2)[:4], Holes: []Ring{square(0.5, 1)}}
+	g := PolygonGeometry(p)
+
+	var coordinates [][][]float64
+	if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+		t.Fatalf("coordinates should be rings of positions: %s", err)
+	}
+	if len(coordinates) != 2 || len(coordinates[0]) != 5 || len(coordinates[1]) != 5 {
+		t.Fatalf("rings should be closed but they are %v", coordinates)
+	}
+
+	decoded, err := g.Polygon()
+	if err != nil {
+		t.Fatalf("Polygon should not return error: %s", err)
+	}
+	if len(decoded.Holes) != 1 || decoded.Boundary[4] != decoded.Boundary[0] || decoded.Holes[0][2] != p.Holes[0][2] {
+		t.Fatalf("unexpected polygon: %+v", decoded)
+	}
+
+	if _, err := (Geometry{Type: TypePolygon, Coordinates: json.RawMessage("[]")}).Polygon(); !errors.Is(err, ErrInvalidGeoJSON) {
+		t.Fatalf("polygon without rings should return ErrInvalidGeoJSON but it returned %v", err)
+	}
+	if _, err := PointGeometry(LatLng{}).Polygon(); !errors.Is(err, ErrInvalidGeoJSON) {
+		t.Fatalf("point should not be decoded as polygon but it returned %v", err)
+	}
+}
+
+func TestDecodeFeature(t *testing.T) {
+	data, err := json.Marshal(NewFeature("a", PointGeometry(LatLng{Lat: 1, Lon: 2}), map[string]any{"id": int64(1) << 60}))
+	if err != nil {
+		t.Fatalf("feature should be encoded: %s", err)
+	}
+	f, err := DecodeFeature(data)
+	if err != nil {
+		t.Fatalf("DecodeFeature should not return error: %s", err)
+	}
+	if f.ID != "a" || f.Properties["id"] != json.Number("1152921504606846976") {
+		t.Fatalf("id and large numbers should be kept but they are %v and %v", f.ID, f.Properties["id"])
+	}
+
+	tests := map[string]string{
+		"collection":  `{"type":"FeatureCollection","features":[]}`,
+		"no_geometry": `{"type":"Feature","geometry":null,"properties":{}}`,
+		"trailing":    `{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":{}}{}`,
+		"not_json":    `Feature`,
+	}
+	for name, data := range tests {
+		if _, err := DecodeFeature([]byte(data)); !errors.Is(err, ErrInvalidGeoJSON) {
+			t.Errorf("%s: DecodeFeature should return ErrInvalidGeoJSON but it returned %v", name, err)
+		}
+	}
+}
+
+func TestDecodeFeatureCollection(t *testing.T) {
+	fc := NewFeatureCollection(NewFeature(nil, PointGeometry(LatLng{Lat: 1, Lon: 2}), nil), NewFeature(1, PolygonGeometry(Polygon{Boundary: square(0, 1)}), nil))
+	data, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatalf("collection should be encoded: %s", err)
+	}
+	decoded, err := DecodeFeatureCollection(data)
+	if err != nil {
+		t.Fatalf("DecodeFeatureCollection should not return error: %s", err)
+	}
+	if len(decoded.Features) != 2 || decoded.Features[1].Geometry.Type != TypePolygon || decoded.Features[1].ID != json.Number("1") {
+		t.Fatalf("unexpected collection: %+v", decoded)
+	}
+
+	if data, _ := json.Marshal(NewFeatureCollection()); string(data) != `{"type":"FeatureCollection","features":[]}` {
+		t.Fatalf("empty collection should have empty features but it is %s", data)
+	}
+
+	tests := map[string]string{
+		"feature":     `{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":{}}`,
+		"bad_feature": `{"type":"FeatureCollection","features":[{"type":"Point","coordinates":[0,0]}]}`,
+	}
+	for name, data := range tests {
+		if _, err := DecodeFeatureCollection([]byte(data)); !errors.Is(err, ErrInvalidGeoJSON) {
+			t.Errorf("%s: DecodeFeatureCollection should return ErrInvalidGeoJSON but it returned %v", name, err)
+		}
+	}
+}
+
+func TestProperties(t *testing.T) {
+	type place struct {
+		Name string  `json:"name"`
+		Lat  float64 `json:"lat"`
+		ID   int64   `json:"id"`
+	}
+	properties, err := Properties(place{Name: "Azadi", Lat: 35.7, ID: 1<<53 + 1}, "lat")
+	if err != nil {
+		t.Fatalf("Properties should not return error: %s", err)
+	}
+	if _, ok := properties["lat"]; ok || properties["name"] != "Azadi" {
+		t.Fatalf("unexpected properties: %v", properties)
+	}
+
+	var decoded place
+	if err := DecodeProperties(properties, &decoded); err != nil {
+		t.Fatalf("DecodeProperties should not return error: %s", err)
+	}
+	if decoded != (place{Name: "Azadi", ID: 1<<53 + 1}) {
+		t.Fatalf("properties should round-trip but they are %+v", decoded)
+	}
+
+	if _, err := Properties([]int{1}); !errors.Is(err, ErrInvalidGeoJSON) {
+		t.Fatalf("non-object properties should return ErrInvalidGeoJSON but it returned %v", err)
+	}
+	if err := DecodeProperties(map[string]any{"name": 1}, &decoded); !errors.Is(err, ErrInvalidGeoJSON) {
+		t.Fatalf("mismatched properties should return ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
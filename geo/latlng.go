@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidLatitude is returned for latitudes that are not within [-90, 90].
+	ErrInvalidLatitude = errors.New("smapp geo: latitude is not valid")
+	// ErrInvalidLongitude is returned for longitudes that are not within [-180, 180].
+	ErrInvalidLongitude = errors.New("smapp geo: longitude is not valid")
+)
+
+// LatLng is a geographic coordinate in degrees. unlike some wire formats, latitude always comes first.
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Validate returns ErrInvalidLatitude or ErrInvalidLongitude if ll is out of range or not a number.
+func (ll LatLng) Validate() error {
+	if math.IsNaN(ll.Lat) || ll.Lat < -90 || ll.Lat > 90 {
+		return fmt.Errorf("%w: %v", ErrInvalidLatitude, ll.Lat)
+	}
+	if math.IsNaN(ll.Lon) || ll.Lon < -180 || ll.Lon > 180 {
+		return fmt.Errorf("%w: %v", ErrInvalidLongitude, ll.Lon)
+	}
+	return nil
+}
+
+// String formats ll as `lat,lon`, which is parsed by ParseLatLng.
+func (ll LatLng) String() string {
+	return strconv.FormatFloat(ll.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(ll.Lon, 'f', -1, 64)
+}
+
+// ParseLatLng parses a `lat,lon` coordinate and validates it.
+func ParseLatLng(s string) (LatLng, error) {
+	lat, lon, ok := strings.Cut(s, ",")
+	if !ok {
+		return LatLng{}, fmt.Errorf("smapp geo: invalid coordinate %q, should be lat,lon", s)
+	}
+	var ll LatLng
+	var err error
+	if ll.Lat, err = strconv.ParseFloat(strings.TrimSpace(lat), 64); err != nil {
+		return LatLng{}, fmt.Errorf("%w: %w", ErrInvalidLatitude, err)
+	}
+	if ll.Lon, err = strconv.ParseFloat(strings.TrimSpace(lon), 64); err != nil {
+		return LatLng{}, fmt.Errorf("%w: %w", ErrInvalidLongitude, err)
+	}
+	if err := ll.Validate(); err != nil {
+		return LatLng{}, err
+	}
+	return ll, nil
+}
+
+// radians converts degrees to radians.
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// degrees converts radians to degrees.
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// normalizeLon wraps lon into [-180, 180).
+func normalizeLon(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
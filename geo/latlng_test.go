@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLatLng_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		ll   LatLng
+		want error
+	}{
+		{name: "valid", ll: LatLng{Lat: 35.7, Lon: 51.4}},
+		{name: "edges", ll: LatLng{Lat: -90, Lon: 180}},
+		{name: "lat_too_big", ll: LatLng{Lat: 90.1, Lon: 51.4}, want: ErrInvalidLatitude},
+		{name: "lat_nan", ll: LatLng{Lat: math.NaN(), Lon: 51.4}, want: ErrInvalidLatitude},
+		{name: "lon_too_small", ll: LatLng{Lat: 35.7, Lon: -180.1}, want: ErrInvalidLongitude},
+		{name: "swapped", ll: LatLng{Lat: 51.4, Lon: 351.4}, want: ErrInvalidLongitude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ll.Validate()
+			if tt.want == nil && err != nil {
+				t.Fatalf("Validate should not return error but it returned %s", err)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Validate should return %v but it returned %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseLatLng(t *testing.T) {
+	ll, err := ParseLatLng(" 35.7 , 51.4")
+	if err != nil {
+		t.Fatalf("ParseLatLng should not return error: %s", err)
+	}
+	if ll != (LatLng{Lat: 35.7, Lon: 51.4}) {
+		t.Fatalf("unexpected coordinate: %+v", ll)
+	}
+	if ll.String() != "35.7,51.4" {
+		t.Fatalf("String should format lat,lon but it returned %s", ll.String())
+	}
+
+	for _, s := range []string{"35.7", "a,51.4", "35.7,b", "135.7,51.4"} {
+		if _, err := ParseLatLng(s); err == nil {
+			t.Fatalf("ParseLatLng(%q) should return error", s)
+		}
+	}
+	if _, err := ParseLatLng("x,51.4"); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("invalid latitude should be ErrInvalidLatitude but it is %v", err)
+	}
+}
+
+func TestNormalizeLon(t *testing.T) {
+	tests := map[float64]float64{0: 0, 180: -180, 190: -170, -190: 170, 540: -180, -45: -45}
+	for lon, want := range tests {
+		if got := normalizeLon(lon); math.Abs(got-want) > 1e-9 {
+			t.Errorf("normalizeLon(%v) = %v, want %v", lon, got, want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidRing is returned for rings with less than three distinct points.
+var ErrInvalidRing = errors.New("smapp geo: ring should have at least three points")
+
+// Ring is a closed line of points, like the boundary of an area. the last point may repeat the first one, as in
+// GeoJSON, or not; both forms describe the same ring.
+type Ring []LatLng
+
+// Polygon is an area bounded by a ring, with optional holes.
+//
+// computations are made on the sphere for Area and DistanceToBoundary, and on the plane of lat and lon for
+// Contains and Centroid, which is accurate for polygons that are small compared to the earth, like areas of a city.
+// polygons crossing the antimeridian or containing a pole are not supported.
+type Polygon struct {
+	// Boundary is the outer ring of the polygon.
+	Boundary Ring `json:"boundary"`
+	// Holes are the rings excluded from the polygon.
+	Holes []Ring `json:"holes,omitempty"`
+}
+
+// open returns r without the repeated closing point.
+func (r Ring) open() Ring {
+	if len(r) > 1 && r[0] == r[len(r)-1] {
+		return r[:len(r)-1]
+	}
+	return r
+}
+
+// Validate returns an error if r has less than three points or any invalid point.
+func (r Ring) Validate() error {
+	if len(r.open()) < 3 {
+		return ErrInvalidRing
+	}
+	for i, p := range r {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("point %d of ring: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether p is inside r, using ray casting. points exactly on the edges may be reported either way.
+func (r Ring) Contains(p LatLng) bool {
+	ring := r.open()
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) && p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Area returns the area enclosed by r on the sphere, in square meters.
+func (r Ring) Area() float64 {
+	ring := r.open()
+	if len(ring) < 3 {
+		return 0
+	}
+	sum := 0.0
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		sum += radians(b.Lon-a.Lon) * (2 + math.Sin(radians(a.Lat)) + math.Sin(radians(b.Lat)))
+	}
+	return math.Abs(sum) * EarthRadius * EarthRadius / 2
+}
+
+// BoundingBox returns the smallest box containing r.
+func (r Ring) BoundingBox() BoundingBox {
+	return NewBoundingBox(r...)
+}
+
+// DistanceTo returns the distance of p to the closest edge of r, in meters.
+func (r Ring) DistanceTo(p LatLng) float64 {
+	ring := r.open()
+	switch len(ring) {
+	case 0:
+		return math.Inf(1)
+	case 1:
+		return Distance(p, ring[0])
+	}
+	distance := math.Inf(1)
+	for i := range ring {
+		distance = math.Min(distance, distanceToSegment(p, ring[i], ring[(i+1)%len(ring)]))
+	}
+	return distance
+}
+
+// centroid returns the centroid of r on the plane of lat and lon, and its signed area on that plane.
+func (r Ring) centroid() (LatLng, float64) {
+	ring := r.open()
+	var area, lat, lon float64
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		cross := a.Lon*b.Lat - b.Lon*a.Lat
+		area += cross
+		lon += (a.Lon + b.Lon) * cross
+		lat += (a.Lat + b.Lat) * cross
+	}
+	area /= 2
+	if area == 0 {
+		// degenerate rings have no area, so the average of their points is used.
+		var c LatLng
+		for _, p := range ring {
+			c.Lat += p.Lat / float64(len(ring))
+			c.Lon += p.Lon / float64(len(ring))
+		}
+		return c, 0
+	}
+	return LatLng{Lat: lat / (6 * area), Lon: lon / (6 * area)}, area
+}
+
+// Validate returns an error if the boundary or any hole of p is invalid.
+func (p Polygon) Validate() error {
+	if err := p.Boundary.Validate(); err != nil {
+		return fmt.Errorf("boundary: %w", err)
+	}
+	for i, hole := range p.Holes {
+		if err := hole.Validate(); err != nil {
+			return fmt.Errorf("hole %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether ll is inside the boundary of p and not inside any of its holes.
+func (p Polygon) Contains(ll LatLng) bool {
+	if !p.Boundary.Contains(ll) {
+		return false
+	}
+	for _, hole := range p.Holes {
+		if hole.Contains(ll) {
+			return false
+		}
+	}
+	return true
+}
+
+// Area returns the area of p on the sphere, excluding its holes, in square meters.
+func (p Polygon) Area() float64 {
+	area := p.Boundary.Area()
+	for _, hole := range p.Holes {
+		area -= hole.Area()
+	}
+	return math.Max(area, 0)
+}
+
+// Centroid returns the center of mass of p, excluding its holes. the centroid of a concave polygon may be outside of
+// it.
+func (p Polygon) Centroid() LatLng {
+	c, area := p.Boundary.centroid()
+	if area == 0 {
+		return c
+	}
+	// signs of areas are normalized, since rings may be clockwise or counterclockwise.
+	area = math.Abs(area)
+	lat, lon := c.Lat*area, c.Lon*area
+	total := area
+	for _, hole := range p.Holes {
+		hc, holeArea := hole.centroid()
+		holeArea = math.Abs(holeArea)
+		lat -= hc.Lat * holeArea
+		lon -= hc.Lon * holeArea
+		total -= holeArea
+	}
+	if total <= 0 {
+		return c
+	}
+	return LatLng{Lat: lat / total, Lon: lon / total}
+}
+
+// DistanceToBoundary returns the distance of ll to the closest edge of the boundary or holes of p, in meters. it is
+// the same for points inside and outside of p, use Contains to tell them apart.
+func (p Polygon) DistanceToBoundary(ll LatLng) float64 {
+	distance := p.Boundary.DistanceTo(ll)
+	for _, hole := range p.Holes {
+		distance = math.Min(distance, hole.DistanceTo(ll))
+	}
+	return distance
+}
+
+// BoundingBox returns the smallest box containing p.
+func (p Polygon) BoundingBox() BoundingBox {
+	return p.Boundary.BoundingBox()
+}
+
+// distanceToSegment returns the distance of p to the segment between a and b in meters. the segment is projected on
+// a plane tangent to the earth at p, which is accurate for segments that are short compared to the earth.
+func distanceToSegment(p, a, b LatLng) float64 {
+	scale := math.Cos(radians(p.Lat))
+	project := func(q LatLng) (float64, float64) {
+		return radians(normalizeLon(q.Lon-p.Lon)) * scale * EarthRadius, radians(q.Lat-p.Lat) * EarthRadius
+	}
+	ax, ay := project(a)
+	bx, by := project(b)
+
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/length))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
@@ -0,0 +1,135 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// square returns the closed counterclockwise ring of the square between (lo, lo) and (hi, hi).
+func square(lo, hi float64) Ring {
+	return Ring{{Lat: lo, Lon: lo}, {Lat: lo, Lon: hi}, {Lat: hi, Lon: hi}, {Lat: hi, Lon: lo}, {Lat: lo, Lon: lo}}
+}
+
+// reversed returns the points of r in reverse order.
+func reversed(r Ring) Ring {
+	result := make(Ring, 0, len(r))
+	for i := len(r) - 1; i >= 0; i-- {
+		result = append(result, r[i])
+	}
+	return result
+}
+
+func TestRing_Validate(t *testing.T) {
+	if err := square(0, 1).Validate(); err != nil {
+		t.Fatalf("square should be valid: %s", err)
+	}
+	if err := square(0, 1)[:4].Validate(); err != nil {
+		t.Fatalf("open square should be valid: %s", err)
+	}
+	if err := (Ring{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}, {Lat: 0, Lon: 0}}).Validate(); !errors.Is(err, ErrInvalidRing) {
+		t.Fatalf("ring with two distinct points should be ErrInvalidRing but it is %v", err)
+	}
+	if err := (Ring{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}, {Lat: 91, Lon: 0}}).Validate(); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("ring with invalid point should be ErrInvalidLatitude but it is %v", err)
+	}
+}
+
+func TestRing_Area(t *testing.T) {
+	// area of a lat/lon rectangle on a sphere is R² * Δλ * (sin φ2 - sin φ1).
+	want := EarthRadius * EarthRadius * radians(1) * math.Sin(radians(1))
+	for name, ring := range map[string]Ring{"closed": square(0, 1), "open": square(0, 1)[:4], "clockwise": reversed(square(0, 1))} {
+		if got := ring.Area(); !almostEqual(got, want, want*1e-9) {
+			t.Errorf("area of %s square = %v, want %v", name, got, want)
+		}
+	}
+	if got := (Ring{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}}).Area(); got != 0 {
+		t.Errorf("area of a line should be 0 but it is %v", got)
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	p := Polygon{Boundary: square(0, 2), Holes: []Ring{square(0.5, 1)}}
+
+	tests := []struct {
+		name string
+		ll   LatLng
+		want bool
+	}{
+		{name: "inside", ll: LatLng{Lat: 1.5, Lon: 1.5}, want: true},
+		{name: "in_hole", ll: LatLng{Lat: 0.75, Lon: 0.75}, want: false},
+		{name: "outside", ll: LatLng{Lat: 2.5, Lon: 1}, want: false},
+		{name: "west", ll: LatLng{Lat: 1, Lon: -0.1}, want: false},
+	}
+	for _, tt := range tests {
+		if got := p.Contains(tt.ll); got != tt.want {
+			t.Errorf("%s: Contains(%v) = %v, want %v", tt.name, tt.ll, got, tt.want)
+		}
+	}
+
+	concave := Polygon{Boundary: Ring{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 2}, {Lat: 2, Lon: 2}, {Lat: 1, Lon: 1}, {Lat: 2, Lon: 0}}}
+	if concave.Contains(LatLng{Lat: 1.8, Lon: 1}) || !concave.Contains(LatLng{Lat: 1.8, Lon: 1.8}) {
+		t.Fatal("concave polygon should not contain points in its notch")
+	}
+}
+
+func TestPolygon_AreaAndCentroid(t *testing.T) {
+	p := Polygon{Boundary: square(0, 2)}
+	if c := p.Centroid(); !almostEqual(c.Lat, 1, 1e-9) || !almostEqual(c.Lon, 1, 1e-9) {
+		t.Fatalf("centroid of square should be its center but it is %v", c)
+	}
+
+	p.Holes = []Ring{reversed(square(0.2, 0.8))}
+	if area, want := p.Area(), square(0, 2).Area()-square(0.2, 0.8).Area(); !almostEqual(area, want, 1) {
+		t.Fatalf("area should exclude the hole: got %v, want %v", area, want)
+	}
+	// (4 * 1 - 0.36 * 0.5) / 3.64
+	want := 3.82 / 3.64
+	if c := p.Centroid(); !almostEqual(c.Lat, want, 1e-9) || !almostEqual(c.Lon, want, 1e-9) {
+		t.Fatalf("centroid should move away from the hole: got %v, want %v", c, want)
+	}
+
+	line := Polygon{Boundary: Ring{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}, {Lat: 2, Lon: 2}}}
+	if c := line.Centroid(); !almostEqual(c.Lat, 1, 1e-9) || !almostEqual(c.Lon, 1, 1e-9) {
+		t.Fatalf("centroid of a degenerate polygon should be the average of its points but it is %v", c)
+	}
+}
+
+func TestPolygon_DistanceToBoundary(t *testing.T) {
+	p := Polygon{Boundary: square(0, 1)}
+
+	// the closest edges of the center are the meridians at half a degree of longitude.
+	center := LatLng{Lat: 0.5, Lon: 0.5}
+	if d, want := p.DistanceToBoundary(center), Distance(center, LatLng{Lat: 0.5, Lon: 0}); !almostEqual(d, want, 1) {
+		t.Fatalf("distance of center = %v, want %v", d, want)
+	}
+
+	outside := LatLng{Lat: 0.5, Lon: 2}
+	if d, want := p.DistanceToBoundary(outside), Distance(outside, LatLng{Lat: 0.5, Lon: 1}); !almostEqual(d, want, 1) {
+		t.Fatalf("distance of outside point = %v, want %v", d, want)
+	}
+
+	corner := LatLng{Lat: 2, Lon: 2}
+	if d, want := p.DistanceToBoundary(corner), Distance(corner, LatLng{Lat: 1, Lon: 1}); !almostEqual(d, want, want*1e-3) {
+		t.Fatalf("distance of point beyond corner = %v, want %v", d, want)
+	}
+
+	p.Holes = []Ring{square(0.4, 0.6)}
+	if d := p.DistanceToBoundary(center); d > Distance(center, LatLng{Lat: 0.6, Lon: 0.5}) {
+		t.Fatalf("distance should consider holes but it is %v", d)
+	}
+}
+
+func TestPolygon_Validate(t *testing.T) {
+	p := Polygon{Boundary: square(0, 2), Holes: []Ring{square(0.5, 1)}}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("polygon should be valid: %s", err)
+	}
+	p.Holes = append(p.Holes, Ring{{Lat: 0, Lon: 0}})
+	if err := p.Validate(); !errors.Is(err, ErrInvalidRing) {
+		t.Fatalf("polygon with invalid hole should be ErrInvalidRing but it is %v", err)
+	}
+	if box := (Polygon{Boundary: square(0, 2)}).BoundingBox(); box != NewBoundingBox(LatLng{}, LatLng{Lat: 2, Lon: 2}) {
+		t.Fatalf("unexpected bounding box: %+v", box)
+	}
+}
@@ -0,0 +1,127 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// PolylinePrecision is the precision of Google encoded polylines, 5 decimal digits.
+	PolylinePrecision = 5
+	// PolylinePrecision6 is the precision of polylines with 6 decimal digits, as used by OSRM and Valhalla.
+	PolylinePrecision6 = 6
+
+	// maxPolylinePrecision is the largest precision whose values fit in the integers of the encoding.
+	maxPolylinePrecision = 9
+)
+
+// ErrInvalidPolyline is returned for encoded polylines that can not be decoded and for invalid encoding precisions.
+var ErrInvalidPolyline = errors.New("smapp geo: invalid polyline")
+
+// EncodePolyline encodes points with the encoded polyline algorithm format, rounding coordinates to precision
+// decimal digits, e.g. PolylinePrecision or PolylinePrecision6. It returns an error if a point is invalid, or
+// precision is out of [0, 9].
+func EncodePolyline(points []LatLng, precision int) (string, error) {
+	factor, err := polylineFactor(precision)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	var lat, lon int64
+	for i, ll := range points {
+		if err := ll.Validate(); err != nil {
+			return "", fmt.Errorf("point %d: %w", i, err)
+		}
+		// rounded values are accumulated instead of coordinates, so rounding errors do not add up over long lines.
+		nextLat, nextLon := int64(math.Round(ll.Lat*factor)), int64(math.Round(ll.Lon*factor))
+		encodePolylineValue(&sb, nextLat-lat)
+		encodePolylineValue(&sb, nextLon-lon)
+		lat, lon = nextLat, nextLon
+	}
+	return sb.String(), nil
+}
+
+// DecodePolyline decodes a polyline encoded with precision decimal digits. the precision is not part of the
+// encoding, so it should be the one the polyline is encoded with. It returns an error matching ErrInvalidPolyline if
+// polyline is malformed or decodes to invalid points.
+func DecodePolyline(polyline string, precision int) ([]LatLng, error) {
+	factor, err := polylineFactor(precision)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]LatLng, 0, len(polyline)/4)
+	var lat, lon int64
+	for i := 0; i < len(polyline); {
+		deltaLat, n, err := decodePolylineValue(polyline[i:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: latitude at %d: %w", ErrInvalidPolyline, i, err)
+		}
+		i += n
+		deltaLon, n, err := decodePolylineValue(polyline[i:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: longitude at %d: %w", ErrInvalidPolyline, i, err)
+		}
+		i += n
+
+		lat, lon = lat+deltaLat, lon+deltaLon
+		ll := LatLng{Lat: float64(lat) / factor, Lon: float64(lon) / factor}
+		if err := ll.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: point %d: %w", ErrInvalidPolyline, len(points), err)
+		}
+		points = append(points, ll)
+	}
+	return points, nil
+}
+
+// polylineFactor returns the multiplier of coordinates encoded with precision decimal digits.
+func polylineFactor(precision int) (float64, error) {
+	if precision < 0 || precision > maxPolylinePrecision {
+		return 0, fmt.Errorf("%w: precision should be in [0, %d] but it is %d", ErrInvalidPolyline, maxPolylinePrecision, precision)
+	}
+	return math.Pow10(precision), nil
+}
+
+// encodePolylineValue appends a signed value to sb: the value is shifted left with its sign in the lowest bit,
+// inverted if negative, and written in chunks of 5 bits from the lowest, each but the last or-ed with 0x20. 63 is
+// added to each chunk to make it a printable character.
+func encodePolylineValue(sb *strings.Builder, value int64) {
+	v := uint64(value) << 1
+	if value < 0 {
+		v = ^v
+	}
+	for v >= 0x20 {
+		sb.WriteByte(byte(0x20|v&0x1f) + 63)
+		v >>= 5
+	}
+	sb.WriteByte(byte(v) + 63)
+}
+
+// decodePolylineValue decodes the first value of s, the reverse of encodePolylineValue, and returns the number of
+// bytes it is encoded in.
+func decodePolylineValue(s string) (int64, int, error) {
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 63 || c > 126 {
+			return 0, 0, fmt.Errorf("unexpected character %q", c)
+		}
+		chunk := uint64(c - 63)
+		if shift := 5 * i; shift < 64 {
+			v |= (chunk & 0x1f) << shift
+		} else {
+			return 0, 0, errors.New("value is too long")
+		}
+		if chunk < 0x20 {
+			value := int64(v >> 1)
+			if v&1 != 0 {
+				value = ^value
+			}
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("unexpected end of polyline")
+}
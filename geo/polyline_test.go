@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// googleExample is the example of the encoded polyline algorithm format documentation.
+var googleExample = []LatLng{{Lat: 38.5, Lon: -120.2}, {Lat: 40.7, Lon: -120.95}, {Lat: 43.252, Lon: -126.453}}
+
+func TestEncodePolyline(t *testing.T) {
+	encoded, err := EncodePolyline(googleExample, PolylinePrecision)
+	if err != nil {
+		t.Fatalf("EncodePolyline should not return error: %s", err)
+	}
+	if want := "_p~iF~ps|U_ulLnnqC_mqNvxq`@"; encoded != want {
+		t.Fatalf("EncodePolyline = %q, want %q", encoded, want)
+	}
+
+	if encoded, _ := EncodePolyline(nil, PolylinePrecision); encoded != "" {
+		t.Fatalf("empty line should be encoded as empty string but it is %q", encoded)
+	}
+	if _, err := EncodePolyline([]LatLng{{Lat: 91}}, PolylinePrecision); !errors.Is(err, ErrInvalidLatitude) {
+		t.Fatalf("invalid point should return ErrInvalidLatitude but it returned %v", err)
+	}
+	if _, err := EncodePolyline(googleExample, 10); !errors.Is(err, ErrInvalidPolyline) {
+		t.Fatalf("invalid precision should return ErrInvalidPolyline but it returned %v", err)
+	}
+}
+
+func TestDecodePolyline(t *testing.T) {
+	points, err := DecodePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@", PolylinePrecision)
+	if err != nil {
+		t.Fatalf("DecodePolyline should not return error: %s", err)
+	}
+	if len(points) != len(googleExample) {
+		t.Fatalf("unexpected points: %v", points)
+	}
+	for i := range points {
+		if !almostEqual(points[i].Lat, googleExample[i].Lat, 1e-9) || !almostEqual(points[i].Lon, googleExample[i].Lon, 1e-9) {
+			t.Fatalf("point %d = %v, want %v", i, points[i], googleExample[i])
+		}
+	}
+
+	tests := map[string]string{
+		"truncated":     "_p~iF~ps|U_ulLnnqC_mqNvxq",
+		"only_latitude": "_p~iF",
+		"bad_character": "_p~iF~ps|U !",
+		"out_of_range":  "_p~iF~ps|U_p~iF~ps|U_p~iF~ps|U",
+	}
+	for name, polyline := range tests {
+		if _, err := DecodePolyline(polyline, PolylinePrecision); !errors.Is(err, ErrInvalidPolyline) {
+			t.Errorf("%s: DecodePolyline should return ErrInvalidPolyline but it returned %v", name, err)
+		}
+	}
+}
+
+func TestPolyline_RoundTrip(t *testing.T) {
+	route := []LatLng{{Lat: 35.699739, Lon: 51.338097}, {Lat: 35.700012, Lon: 51.339001}, {Lat: 35.7, Lon: 51.339001}, {Lat: -33.8688, Lon: 151.2093}}
+	for _, precision := range []int{PolylinePrecision, PolylinePrecision6} {
+		encoded, err := EncodePolyline(route, precision)
+		if err != nil {
+			t.Fatalf("precision %d: EncodePolyline should not return error: %s", precision, err)
+		}
+		decoded, err := DecodePolyline(encoded, precision)
+		if err != nil {
+			t.Fatalf("precision %d: DecodePolyline should not return error: %s", precision, err)
+		}
+		tolerance := 0.5 / math.Pow10(precision)
+		for i := range route {
+			if !almostEqual(decoded[i].Lat, route[i].Lat, tolerance) || !almostEqual(decoded[i].Lon, route[i].Lon, tolerance) {
+				t.Fatalf("precision %d: point %d = %v, want %v", precision, i, decoded[i], route[i])
+			}
+		}
+	}
+
+	encoded, _ := EncodePolyline(route[:2], PolylinePrecision6)
+	decoded, _ := DecodePolyline(encoded, PolylinePrecision6)
+	if decoded[0] != route[0] || decoded[1] != route[1] {
+		t.Fatalf("points with 6 digits should be kept exactly with precision 6 but they are %v", decoded)
+	}
+}
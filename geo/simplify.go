@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Simplify returns the points of a line, e.g. a route trace, that are needed to keep it within tolerance meters of
+// the original line, using the Douglas-Peucker algorithm. the first and last points are always kept, and the result
+// is a new slice.
+func Simplify(points []LatLng, tolerance float64) []LatLng {
+	importance := simplificationImportance(points)
+	result := make([]LatLng, 0, len(points))
+	for i, ll := range points {
+		if i == 0 || i == len(points)-1 || importance[i] > tolerance {
+			result = append(result, ll)
+		}
+	}
+	return result
+}
+
+// SimplifyN returns at most maxPoints points of a line, simplified with the smallest tolerance of Simplify that keeps
+// them within maxPoints, e.g. to fit a long trace within the size limits of a URL. the first and last points are
+// always kept, so the result has two points if maxPoints is less than two, and the result is a new slice.
+func SimplifyN(points []LatLng, maxPoints int) []LatLng {
+	importance := simplificationImportance(points)
+	interior := maxPoints - 2
+	if len(points) <= 2 || interior >= len(points)-2 {
+		return append([]LatLng(nil), points...)
+	}
+
+	tolerance := math.Inf(1)
+	if interior > 0 {
+		ranked := append([]float64(nil), importance[1:len(points)-1]...)
+		sort.Sort(sort.Reverse(sort.Float64Slice(ranked)))
+		// points as important as the first point out of the limit are dropped too, so ties do not exceed it.
+		tolerance = ranked[interior]
+	}
+	result := make([]LatLng, 0, maxPoints)
+	for i, ll := range points {
+		if i == 0 || i == len(points)-1 || importance[i] > tolerance {
+			result = append(result, ll)
+		}
+	}
+	return result
+}
+
+// simplificationImportance returns the largest tolerance in meters for which Douglas-Peucker keeps each point. a
+// point is kept if it is farther than the tolerance from the segment of its span, and all the points splitting the
+// spans containing it are kept too, so its importance is bounded by theirs. the first and last points have infinite
+// importance.
+func simplificationImportance(points []LatLng) []float64 {
+	importance := make([]float64, len(points))
+	if len(points) == 0 {
+		return importance
+	}
+	importance[0], importance[len(points)-1] = math.Inf(1), math.Inf(1)
+
+	type span struct {
+		first, last int
+		bound       float64
+	}
+	stack := []span{{first: 0, last: len(points) - 1, bound: math.Inf(1)}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		farthest, distance := -1, 0.0
+		for i := s.first + 1; i < s.last; i++ {
+			if d := distanceToSegment(points[i], points[s.first], points[s.last]); d > distance {
+				farthest, distance = i, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		importance[farthest] = math.Min(distance, s.bound)
+		stack = append(stack,
+			span{first: s.first, last: farthest, bound: importance[farthest]},
+			span{first: farthest, last: s.last, bound: importance[farthest]},
+		)
+	}
+	return importance
+}
@@ -0,0 +1,69 @@
+package geo
+
+import "testing"
+
+// zigzag returns a line going east along the equator, with every other point moved north by offset degrees.
+func zigzag(n int, offset float64) []LatLng {
+	points := make([]LatLng, 0, n)
+	for i := range n {
+		ll := LatLng{Lon: float64(i) * 0.001}
+		if i%2 == 1 {
+			ll.Lat = offset
+		}
+		points = append(points, ll)
+	}
+	return points
+}
+
+func TestSimplify(t *testing.T) {
+	// the middle point is about 111m from the line between the others.
+	line := []LatLng{{Lat: 0, Lon: 0}, {Lat: 0.001, Lon: 0.01}, {Lat: 0, Lon: 0.02}}
+	if got := Simplify(line, 200); len(got) != 2 || got[0] != line[0] || got[1] != line[2] {
+		t.Fatalf("point within tolerance should be removed but result is %v", got)
+	}
+	if got := Simplify(line, 100); len(got) != 3 {
+		t.Fatalf("point out of tolerance should be kept but result is %v", got)
+	}
+
+	straight := []LatLng{{Lat: 0, Lon: 0}, {Lat: 0, Lon: 0.01}, {Lat: 0, Lon: 0.02}, {Lat: 0, Lon: 0.03}}
+	if got := Simplify(straight, 0); len(got) != 2 {
+		t.Fatalf("points on a straight line should be removed but result is %v", got)
+	}
+
+	for _, points := range [][]LatLng{nil, line[:1], line[:2]} {
+		if got := Simplify(points, 1000); len(got) != len(points) {
+			t.Fatalf("lines with less than three points should be kept but result is %v", got)
+		}
+	}
+}
+
+func TestSimplify_Nested(t *testing.T) {
+	// the peak is kept, and the bump near it is kept only if it is far enough from the line to the peak.
+	line := []LatLng{{Lat: 0, Lon: 0}, {Lat: 0.0005, Lon: 0.005}, {Lat: 0.01, Lon: 0.01}, {Lat: 0, Lon: 0.02}}
+	if got := Simplify(line, 500); len(got) != 3 || got[1] != line[2] {
+		t.Fatalf("only the peak should be kept but result is %v", got)
+	}
+	if got := Simplify(line, 1); len(got) != 4 {
+		t.Fatalf("all points should be kept but result is %v", got)
+	}
+}
+
+func TestSimplifyN(t *testing.T) {
+	points := zigzag(100, 0.001)
+	for _, maxPoints := range []int{0, 2, 3, 10, 99} {
+		got := SimplifyN(points, maxPoints)
+		if len(got) > max(maxPoints, 2) || got[0] != points[0] || got[len(got)-1] != points[len(points)-1] {
+			t.Fatalf("SimplifyN(%d) returned %d points, first %v and last %v", maxPoints, len(got), got[0], got[len(got)-1])
+		}
+	}
+	if got := SimplifyN(points, 100); len(got) != 100 {
+		t.Fatalf("points within the limit should be kept but %d are returned", len(got))
+	}
+
+	// a single spike among small bumps is the most important point.
+	points = zigzag(21, 0.00001)
+	points[10].Lat = 0.01
+	if got := SimplifyN(points, 3); len(got) != 3 || got[1] != points[10] {
+		t.Fatalf("spike should be kept but result is %v", got)
+	}
+}
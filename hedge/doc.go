@@ -0,0 +1,5 @@
+// Package hedge contains a http.RoundTripper that sends a second, identical request when the first one is slow, and
+// returns whichever response arrives first. it is enabled using the `WithHedging` constructor option of latency
+// critical services (eta and matrix). only idempotent requests, as reported by retry.IsIdempotent, are hedged, and
+// the rate of hedged requests is capped by Policy.MaxHedgeRatio.
+package hedge
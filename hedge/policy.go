@@ -0,0 +1,84 @@
+package hedge
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	DefaultDelay         = 100 * time.Millisecond
+	DefaultMaxHedgeRatio = 0.1
+
+	// SampleSize is the number of latest latencies used to calculate the percentile based delay.
+	SampleSize = 100
+	// MinSamples is the number of latencies needed before the percentile based delay is used.
+	MinSamples = 20
+	// MaxBurst is the maximum number of hedges that can be sent back to back, e.g. after a quiet period.
+	MaxBurst = 10
+)
+
+// Policy specifies when requests are hedged. zero values are replaced with defaults.
+type Policy struct {
+	// Delay is how long to wait for the response of the first request before sending the hedge. it is also used as
+	// the delay of percentile based policies until MinSamples latencies are recorded. default is 100ms.
+	Delay time.Duration
+	// Percentile, if between 0 and 1, makes the delay equal to the given percentile of the latest latencies of the
+	// client. e.g. 0.95 sends a hedge for requests slower than the p95 latency.
+	Percentile float64
+	// MinDelay is a lower bound of the percentile based delay.
+	MinDelay time.Duration
+	// MaxHedgeRatio is the maximum ratio of requests that are hedged, between 0 and 1. default is 0.1.
+	MaxHedgeRatio float64
+}
+
+// DefaultPolicy returns a Policy that hedges requests slower than the p95 latency of the client, and at most 10% of
+// requests.
+func DefaultPolicy() Policy {
+	return Policy{
+		Delay:         DefaultDelay,
+		Percentile:    0.95,
+		MaxHedgeRatio: DefaultMaxHedgeRatio,
+	}
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.Delay <= 0 {
+		p.Delay = DefaultDelay
+	}
+	if p.MaxHedgeRatio <= 0 || p.MaxHedgeRatio > 1 {
+		p.MaxHedgeRatio = DefaultMaxHedgeRatio
+	}
+	return p
+}
+
+func (p Policy) isDynamic() bool {
+	return p.Percentile > 0 && p.Percentile < 1
+}
+
+// latencies keeps the latest SampleSize latencies in a ring.
+type latencies struct {
+	samples [SampleSize]time.Duration
+	count   int
+	next    int
+}
+
+func (l *latencies) add(d time.Duration) {
+	l.samples[l.next] = d
+	l.next = (l.next + 1) % SampleSize
+	l.count = min(l.count+1, SampleSize)
+}
+
+// percentile returns the given percentile of the recorded latencies. it returns false if less than MinSamples
+// latencies are recorded.
+func (l *latencies) percentile(p float64) (time.Duration, bool) {
+	if l.count < MinSamples {
+		return 0, false
+	}
+	sorted := slices.Clone(l.samples[:l.count])
+	slices.Sort(sorted)
+	index := int(p * float64(l.count))
+	if index >= l.count {
+		index = l.count - 1
+	}
+	return sorted[index], true
+}
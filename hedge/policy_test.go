@@ -0,0 +1,45 @@
+package hedge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicy_withDefaults(t *testing.T) {
+	p := Policy{MaxHedgeRatio: 2}.withDefaults()
+	if p.Delay != DefaultDelay {
+		t.Fatalf("Delay should be %s but it is %s", DefaultDelay, p.Delay)
+	}
+	if p.MaxHedgeRatio != DefaultMaxHedgeRatio {
+		t.Fatalf("MaxHedgeRatio should be %f but it is %f", DefaultMaxHedgeRatio, p.MaxHedgeRatio)
+	}
+
+	p = DefaultPolicy().withDefaults()
+	if !p.isDynamic() {
+		t.Fatalf("DefaultPolicy should be percentile based")
+	}
+}
+
+func TestLatencies_percentile(t *testing.T) {
+	var l latencies
+	for i := 1; i < MinSamples; i++ {
+		l.add(time.Duration(i) * time.Millisecond)
+	}
+	if _, ok := l.percentile(0.5); ok {
+		t.Fatalf("percentile should not be calculated with less than %d samples", MinSamples)
+	}
+
+	for i := MinSamples; i <= SampleSize+50; i++ {
+		l.add(time.Duration(i) * time.Millisecond)
+	}
+	// only the latest SampleSize latencies, 51ms to 150ms, are kept.
+	if p, _ := l.percentile(0); p != 51*time.Millisecond {
+		t.Fatalf("p0 should be 51ms but it is %s", p)
+	}
+	if p, _ := l.percentile(0.9); p != 141*time.Millisecond {
+		t.Fatalf("p90 should be 141ms but it is %s", p)
+	}
+	if p, _ := l.percentile(1); p != 150*time.Millisecond {
+		t.Fatalf("p100 should be 150ms but it is %s", p)
+	}
+}
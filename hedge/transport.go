@@ -0,0 +1,237 @@
+package hedge
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/logging"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+const (
+	// HedgeEventName is the name of the span event added when a hedge is sent.
+	HedgeEventName = "hedge.sent"
+	// WinEventName is the name of the span event added when the response of the hedge is returned.
+	WinEventName = "hedge.won"
+)
+
+// Stats holds the counters of a Transport.
+type Stats struct {
+	// Requests is the number of hedgeable requests.
+	Requests int
+	// Hedged is the number of requests a hedge is sent for.
+	Hedged int
+	// Wins is the number of requests answered by their hedge.
+	Wins int
+}
+
+// Transport is a http.RoundTripper that hedges slow idempotent requests according to a Policy.
+// if the response of a request is not received within the delay of the policy, an identical request is sent. the
+// first response, or the last error if both fail, is returned and the other request is cancelled.
+// requests that finish before the delay are returned as is, so hedging never replaces retries.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+
+	policy Policy
+
+	mu        sync.Mutex
+	latencies latencies
+	budget    float64
+	stats     Stats
+}
+
+// NewTransport wraps base with a Transport hedging with the given policy.
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	return &Transport{
+		Base:   base,
+		policy: policy.withDefaults(),
+	}
+}
+
+// Policy returns the policy of the transport, with defaults applied.
+func (t *Transport) Policy() Policy {
+	return t.policy
+}
+
+// Stats returns the counters of the transport.
+func (t *Transport) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+type attempt struct {
+	index    int
+	hedge    bool
+	response *http.Response
+	err      error
+	cancel   context.CancelFunc
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if !retry.IsIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return base.RoundTrip(req)
+	}
+
+	delay := t.begin()
+	ctx := req.Context()
+	results := make(chan attempt, 2)
+	cancels := []context.CancelFunc{t.send(base, req, req.Body, 0, results)}
+	pending := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if ctx.Err() != nil || !t.allowHedge() {
+				continue
+			}
+			body := req.Body
+			if req.GetBody != nil {
+				var err error
+				if body, err = req.GetBody(); err != nil {
+					continue
+				}
+			}
+
+			trace.SpanFromContext(ctx).AddEvent(HedgeEventName, trace.WithAttributes(
+				attribute.Int64("delay_ms", delay.Milliseconds()),
+			))
+			if logger := logging.FromContext(ctx); logger != nil {
+				logger.LogAttrs(ctx, slog.LevelDebug, "smapp request hedged", slog.Duration("delay", delay))
+			}
+
+			cancels = append(cancels, t.send(base, req, body, len(cancels), results))
+			pending++
+		case result := <-results:
+			pending--
+			// an error is returned only if no other attempt can answer the request.
+			if result.err != nil && pending > 0 {
+				result.cancel()
+				continue
+			}
+
+			if pending > 0 {
+				for i, cancel := range cancels {
+					if i != result.index {
+						cancel()
+					}
+				}
+				go discard(results, pending)
+			}
+			if result.err != nil {
+				result.cancel()
+				return nil, result.err
+			}
+
+			if result.hedge {
+				t.mu.Lock()
+				t.stats.Wins++
+				t.mu.Unlock()
+				trace.SpanFromContext(ctx).AddEvent(WinEventName)
+			}
+			result.response.Body = &cancelBody{ReadCloser: result.response.Body, cancel: result.cancel}
+			result.response.Request = req
+			return result.response, nil
+		}
+	}
+}
+
+// begin records a new request and returns the delay of its hedge.
+func (t *Transport) begin() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.Requests++
+	t.budget = min(t.budget+t.policy.MaxHedgeRatio, MaxBurst)
+
+	if !t.policy.isDynamic() {
+		return t.policy.Delay
+	}
+	delay, ok := t.latencies.percentile(t.policy.Percentile)
+	if !ok {
+		return t.policy.Delay
+	}
+	return max(delay, t.policy.MinDelay)
+}
+
+// allowHedge takes a hedge from the budget, if any is left.
+func (t *Transport) allowHedge() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.budget < 1 {
+		return false
+	}
+	t.budget--
+	t.stats.Hedged++
+	return true
+}
+
+func (t *Transport) record(latency time.Duration) {
+	t.mu.Lock()
+	t.latencies.add(latency)
+	t.mu.Unlock()
+}
+
+// send sends a copy of req with the given body and returns the function cancelling it. each attempt has its own
+// context, so the loser can be cancelled without affecting the response body of the winner.
+func (t *Transport) send(base http.RoundTripper, req *http.Request, body io.ReadCloser, index int, results chan<- attempt) context.CancelFunc {
+	ctx, cancel := context.WithCancel(req.Context())
+	attemptReq := req.Clone(ctx)
+	attemptReq.Body = body
+	if index > 0 && req.Header.Get(deadline.Header) != "" {
+		deadline.SetHeader(attemptReq)
+	}
+
+	go func() {
+		start := time.Now()
+		response, err := base.RoundTrip(attemptReq)
+		if err == nil {
+			t.record(time.Since(start))
+		}
+		results <- attempt{index: index, hedge: index > 0, response: response, err: err, cancel: cancel}
+	}()
+
+	return cancel
+}
+
+// discard closes the responses of the attempts that lost.
+func discard(results <-chan attempt, pending int) {
+	for ; pending > 0; pending-- {
+		result := <-results
+		if result.response != nil {
+			_ = result.response.Body.Close()
+		}
+		result.cancel()
+	}
+}
+
+// cancelBody releases the context of the winning attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,204 @@
+package hedge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func response(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// slowFirst returns a base transport whose first attempt of each request waits until it is cancelled, and whose
+// other attempts answer immediately.
+func slowFirst(calls *int32, cancelled chan<- struct{}) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(calls, 1)%2 == 1 {
+			<-req.Context().Done()
+			if cancelled != nil {
+				cancelled <- struct{}{}
+			}
+			return nil, req.Context().Err()
+		}
+		body := "hedge"
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			body += ":" + string(b)
+		}
+		return response(req, body), nil
+	}
+}
+
+func get(t *testing.T, tr http.RoundTripper) (string, error) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "http://smapp.local/eta", nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body), nil
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Run("fast_requests_not_hedged", func(t *testing.T) {
+		var calls int32
+		tr := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return response(req, "first"), nil
+		}), Policy{Delay: time.Second, MaxHedgeRatio: 1})
+
+		body, err := get(t, tr)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if body != "first" || calls != 1 {
+			t.Fatalf("request should be sent once but it is sent %d times", calls)
+		}
+		if s := tr.Stats(); s.Requests != 1 || s.Hedged != 0 {
+			t.Fatalf("Stats should be 1 request and no hedges but it is %+v", s)
+		}
+	})
+
+	t.Run("slow_requests_hedged", func(t *testing.T) {
+		var calls int32
+		cancelled := make(chan struct{}, 1)
+		tr := NewTransport(slowFirst(&calls, cancelled), Policy{Delay: 10 * time.Millisecond, MaxHedgeRatio: 1})
+
+		ctx := retry.WithIdempotent(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://smapp.local/matrix", bytes.NewBufferString("body"))
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(body) != "hedge:body" {
+			t.Fatalf("response of the hedge should be returned but it is %q", body)
+		}
+		if resp.Request != req {
+			t.Fatalf("Request of the response should be the original request")
+		}
+
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatalf("the slow request should be cancelled")
+		}
+		if s := tr.Stats(); s.Hedged != 1 || s.Wins != 1 {
+			t.Fatalf("Stats should be 1 hedge and 1 win but it is %+v", s)
+		}
+	})
+
+	t.Run("hedge_rate_capped", func(t *testing.T) {
+		tr := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}), Policy{Delay: time.Millisecond, MaxHedgeRatio: 0.5})
+
+		for i := 0; i < 4; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://smapp.local/eta", nil)
+			if _, err := tr.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("err should be DeadlineExceeded but it is %v", err)
+			}
+			cancel()
+		}
+
+		if s := tr.Stats(); s.Requests != 4 || s.Hedged != 2 {
+			t.Fatalf("Stats should be 4 requests and 2 hedges but it is %+v", s)
+		}
+	})
+
+	t.Run("waits_for_hedge_after_failure", func(t *testing.T) {
+		var calls int32
+		tr := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				time.Sleep(20 * time.Millisecond)
+				return nil, errors.New("connection reset")
+			}
+			time.Sleep(40 * time.Millisecond)
+			return response(req, "hedge"), nil
+		}), Policy{Delay: 10 * time.Millisecond, MaxHedgeRatio: 1})
+
+		body, err := get(t, tr)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if body != "hedge" {
+			t.Fatalf("response of the hedge should be returned but it is %q", body)
+		}
+	})
+
+	t.Run("returns_last_error", func(t *testing.T) {
+		var calls int32
+		tr := NewTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&calls, 1)
+			time.Sleep(time.Duration(n) * 20 * time.Millisecond)
+			return nil, fmt.Errorf("failure %d", n)
+		}), Policy{Delay: 10 * time.Millisecond, MaxHedgeRatio: 1})
+
+		_, err := get(t, tr)
+		if err == nil || err.Error() != "failure 2" {
+			t.Fatalf("error of the last attempt should be returned but it is %v", err)
+		}
+	})
+
+	t.Run("not_idempotent", func(t *testing.T) {
+		var calls int32
+		tr := NewTransport(slowFirst(&calls, nil), Policy{Delay: time.Millisecond, MaxHedgeRatio: 1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://smapp.local/eta", bytes.NewBufferString("body"))
+		if _, err := tr.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be DeadlineExceeded but it is %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("request should be sent once but it is sent %d times", calls)
+		}
+	})
+
+	t.Run("percentile_delay", func(t *testing.T) {
+		tr := NewTransport(http.DefaultTransport, Policy{Delay: time.Second, Percentile: 0.5, MinDelay: 3 * time.Millisecond})
+		if d := tr.begin(); d != time.Second {
+			t.Fatalf("delay should be Policy.Delay before enough samples but it is %s", d)
+		}
+
+		for i := 1; i <= MinSamples; i++ {
+			tr.record(time.Duration(i) * time.Millisecond)
+		}
+		if d := tr.begin(); d != 11*time.Millisecond {
+			t.Fatalf("delay should be p50 of latencies but it is %s", d)
+		}
+
+		for i := 0; i < SampleSize; i++ {
+			tr.record(time.Millisecond)
+		}
+		if d := tr.begin(); d != 3*time.Millisecond {
+			t.Fatalf("delay should be MinDelay but it is %s", d)
+		}
+	})
+}
@@ -0,0 +1,5 @@
+// Package interceptor contains the hooks that service clients call around each request.
+// interceptors are registered using the `WithInterceptors` constructor option of each service, or
+// smapp.WithInterceptors for all services of a smapp.Client. they can add headers, audit calls or record metrics
+// without changing the clients.
+package interceptor
@@ -0,0 +1,76 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Call describes the call of a client method that a request is sent for.
+type Call struct {
+	// Service is the name of the service. e.g. `eta`.
+	Service string
+	// Operation is the name of the operation. e.g. `get-eta`.
+	Operation string
+	// Start is the time the call is started.
+	Start time.Time
+}
+
+// Interceptor is called by service clients around each request.
+// hooks of multiple interceptors are called in the order they are registered.
+type Interceptor interface {
+	// BeforeRequest is called after the request is built, including the API key and headers, and before it is sent.
+	// it can modify req. returning an error aborts the call with an error matching smapperrors.ErrInterceptor.
+	BeforeRequest(ctx context.Context, call Call, req *http.Request) error
+	// AfterResponse is called when a response is received, whatever its status code, and before its body is read.
+	// it must not read or close the body. returning an error aborts the call with an error matching
+	// smapperrors.ErrInterceptor.
+	AfterResponse(ctx context.Context, call Call, req *http.Request, resp *http.Response) error
+	// OnError is called with the error returned from the call, if it fails after it is started.
+	OnError(ctx context.Context, call Call, err error)
+}
+
+// Funcs is an Interceptor made of optional functions. nil functions are skipped.
+type Funcs struct {
+	BeforeRequestFunc func(ctx context.Context, call Call, req *http.Request) error
+	AfterResponseFunc func(ctx context.Context, call Call, req *http.Request, resp *http.Response) error
+	OnErrorFunc       func(ctx context.Context, call Call, err error)
+}
+
+// Force Funcs to implement Interceptor at compile time
+var _ Interceptor = Funcs{}
+
+// BeforeRequest calls BeforeRequestFunc if it is not nil.
+func (f Funcs) BeforeRequest(ctx context.Context, call Call, req *http.Request) error {
+	if f.BeforeRequestFunc == nil {
+		return nil
+	}
+	return f.BeforeRequestFunc(ctx, call, req)
+}
+
+// AfterResponse calls AfterResponseFunc if it is not nil.
+func (f Funcs) AfterResponse(ctx context.Context, call Call, req *http.Request, resp *http.Response) error {
+	if f.AfterResponseFunc == nil {
+		return nil
+	}
+	return f.AfterResponseFunc(ctx, call, req, resp)
+}
+
+// OnError calls OnErrorFunc if it is not nil.
+func (f Funcs) OnError(ctx context.Context, call Call, err error) {
+	if f.OnErrorFunc != nil {
+		f.OnErrorFunc(ctx, call, err)
+	}
+}
+
+// WithHeaders returns an Interceptor that sets the given headers on every request.
+func WithHeaders(headers map[string]string) Interceptor {
+	return Funcs{
+		BeforeRequestFunc: func(_ context.Context, _ Call, req *http.Request) error {
+			for key, val := range headers {
+				req.Header.Set(key, val)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFuncs(t *testing.T) {
+	t.Run("nil_funcs", func(t *testing.T) {
+		f := Funcs{}
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		if err := f.BeforeRequest(context.Background(), Call{}, req); err != nil {
+			t.Fatalf("BeforeRequest should not return error: %s", err.Error())
+		}
+		if err := f.AfterResponse(context.Background(), Call{}, req, &http.Response{}); err != nil {
+			t.Fatalf("AfterResponse should not return error: %s", err.Error())
+		}
+		f.OnError(context.Background(), Call{}, errors.New("error"))
+	})
+
+	t.Run("funcs", func(t *testing.T) {
+		var called []string
+		expected := errors.New("abort")
+		f := Funcs{
+			BeforeRequestFunc: func(context.Context, Call, *http.Request) error {
+				called = append(called, "before")
+				return expected
+			},
+			AfterResponseFunc: func(context.Context, Call, *http.Request, *http.Response) error {
+				called = append(called, "after")
+				return nil
+			},
+			OnErrorFunc: func(context.Context, Call, error) {
+				called = append(called, "error")
+			},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		if err := f.BeforeRequest(context.Background(), Call{}, req); err != expected {
+			t.Fatalf("BeforeRequest should return the error of BeforeRequestFunc")
+		}
+		_ = f.AfterResponse(context.Background(), Call{}, req, &http.Response{})
+		f.OnError(context.Background(), Call{}, expected)
+
+		if len(called) != 3 {
+			t.Fatalf("all funcs should be called but called funcs are %v", called)
+		}
+	})
+}
+
+func TestWithHeaders(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	err := WithHeaders(map[string]string{"X-Tenant": "snapp"}).BeforeRequest(context.Background(), Call{}, req)
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if req.Header.Get("X-Tenant") != "snapp" {
+		t.Fatalf("X-Tenant header should be snapp but it is %q", req.Header.Get("X-Tenant"))
+	}
+}
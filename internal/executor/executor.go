@@ -0,0 +1,491 @@
+// Package executor contains the request pipeline shared by all service clients. it builds the request, injects
+// the API key and headers, records spans, calls interceptors and turns failures into smapperrors.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/credentials"
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/logging"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/redact"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+	"github.com/snapp-incubator/smapp-sdk-go/version"
+)
+
+// Request describes a request of a client method.
+type Request struct {
+	// Operation is the name of the operation, used in spans, errors and rate limiting. e.g. `get-eta`.
+	Operation string
+	// Method is the http method. default is GET.
+	Method string
+	// URL is the url of the request without query params.
+	URL string
+	// Query holds the query params. the API key is added to it if config.QueryParamSource is used.
+	Query url.Values
+	// Headers are set on the request after the API key.
+	Headers map[string]string
+	// Body is the body of the request. it can be nil.
+	Body []byte
+	// Idempotent marks a non GET request as safe to be retried.
+	Idempotent bool
+	// Attributes are set on the span of the operation.
+	Attributes []attribute.KeyValue
+	// Engine is the routing engine of eta and matrix requests, used as a metric label. it can be empty.
+	Engine string
+	// Timeout overrides the timeout of the http client for this request, including its retries. zero keeps the
+	// timeout of the http client.
+	Timeout time.Duration
+}
+
+// Decoder reads a 200 response body. it may return smapperrors.ErrStatusNotOK if the body reports a failure.
+type Decoder func(body io.Reader) error
+
+// JSON returns a Decoder that unmarshals the body into out.
+func JSON(out any) Decoder {
+	return func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(out)
+	}
+}
+
+// JSONWithStatus returns a Decoder that unmarshals the body into out and returns smapperrors.ErrStatusNotOK if
+// status, which must point into out, is not okStatus after unmarshalling. the comparison is case-insensitive.
+func JSONWithStatus(out any, status *string, okStatus string) Decoder {
+	return func(body io.Reader) error {
+		if err := json.NewDecoder(body).Decode(out); err != nil {
+			return err
+		}
+		if !strings.EqualFold(*status, okStatus) {
+			return smapperrors.ErrStatusNotOK
+		}
+		return nil
+	}
+}
+
+// Executor sends the requests of a service client.
+type Executor struct {
+	service      string
+	cfg          *config.Config
+	httpClient   *http.Client
+	tracerName   string
+	interceptors []interceptor.Interceptor
+	apiVersion   string
+	metrics      *metrics
+	logger       *slog.Logger
+}
+
+// Option is a function type for customizing the Executor.
+type Option func(e *Executor)
+
+// WithTracerName sets the name of the tracer used for the spans of requests.
+func WithTracerName(tracerName string) Option {
+	return func(e *Executor) {
+		e.tracerName = tracerName
+	}
+}
+
+// WithInterceptors appends interceptors whose hooks are called around each request.
+func WithInterceptors(interceptors ...interceptor.Interceptor) Option {
+	return func(e *Executor) {
+		e.interceptors = append(e.interceptors, interceptors...)
+	}
+}
+
+// WithAPIVersion sets the API version of the service, used as a metric label.
+func WithAPIVersion(apiVersion string) Option {
+	return func(e *Executor) {
+		e.apiVersion = apiVersion
+	}
+}
+
+// WithMeterName enables recording metrics of requests using the meter with the given name of the global
+// opentelemetry meter provider. metrics are disabled if meterName is empty.
+func WithMeterName(meterName string) Option {
+	return func(e *Executor) {
+		if meterName == "" {
+			e.metrics = nil
+			return
+		}
+		e.metrics = newMetrics(meterName)
+	}
+}
+
+// WithLogger enables logging the lifecycle of requests using logger. the API key is never logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *Executor) {
+		e.logger = logger
+	}
+}
+
+// New creates an Executor for the given service. httpClient is used as is, so later changes to it are applied.
+func New(service string, cfg *config.Config, httpClient *http.Client, opts ...Option) *Executor {
+	e := &Executor{
+		service:    service,
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Do sends r and decodes its 200 response using decode. every failure is returned as a smapperrors.Error or
+// a smapperrors.APIError, and is passed to the OnError hook of interceptors.
+func (e *Executor) Do(ctx context.Context, r Request, decode Decoder) error {
+	if ctx == nil {
+		return smapperrors.New(e.service, r.Operation, smapperrors.ErrNilContext, nil)
+	}
+
+	call := interceptor.Call{
+		Service:   e.service,
+		Operation: r.Operation,
+		Start:     time.Now(),
+	}
+
+	logger := e.logger
+	if logger != nil {
+		logger = logger.With(
+			slog.String("service", e.service),
+			slog.String("operation", r.Operation),
+			slog.String("path", requestPath(r.URL)),
+		)
+		ctx = logging.WithLogger(ctx, logger)
+		logger.DebugContext(ctx, "smapp request started")
+	}
+
+	// the timeout becomes the deadline of the context, so transports can fit retries within the remaining budget
+	// and it can be sent to the server.
+	httpClient := e.httpClient
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = httpClient.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		withoutTimeout := *httpClient
+		withoutTimeout.Timeout = 0
+		httpClient = &withoutTimeout
+	}
+
+	var result result
+	finish := e.metrics.start(ctx, e.labels(r))
+	credential, err := e.credential(ctx)
+	redactor := e.redactor(credential.Keys()...)
+	if err != nil {
+		err = smapperrors.New(e.service, r.Operation, smapperrors.ErrCredentials, redactor.Error(err))
+	} else {
+		err = e.do(ctx, httpClient, call, r, decode, credential, redactor, &result)
+	}
+	finish(result, err)
+	e.logResult(ctx, logger, redactor, call, result, err)
+
+	if err != nil {
+		for _, i := range e.interceptors {
+			i.OnError(ctx, call, err)
+		}
+	}
+	return err
+}
+
+// RecordCacheLookup records a lookup of the response cache of the client for the given operation.
+func (e *Executor) RecordCacheLookup(ctx context.Context, operation string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	if e.logger != nil {
+		e.logger.DebugContext(ctx, "smapp cache lookup",
+			slog.String("service", e.service),
+			slog.String("operation", operation),
+			slog.String("result", result),
+		)
+	}
+
+	if e.metrics == nil {
+		return
+	}
+	e.metrics.cacheLookups.Add(ctx, 1, metric.WithAttributes(
+		ServiceKey.String(e.service),
+		OperationKey.String(operation),
+		APIVersionKey.String(e.apiVersion),
+		CacheResultKey.String(result),
+	))
+}
+
+// LogCacheError logs a failure of the response cache of the client at warn level. cache failures do not fail calls.
+// like the errors of calls, the keys of the credential of the call are redacted from err.
+func (e *Executor) LogCacheError(ctx context.Context, operation string, err error) {
+	if e.logger == nil {
+		return
+	}
+	var keys []string
+	if credential, credErr := e.credential(ctx); credErr == nil {
+		keys = credential.Keys()
+	}
+	e.logger.WarnContext(ctx, "smapp cache failed",
+		slog.String("service", e.service),
+		slog.String("operation", operation),
+		slog.String("error", e.redactor(keys...).String(err.Error())),
+	)
+}
+
+// logResult logs the end of a call. successful calls are logged at debug level and failures at warn or error level.
+func (e *Executor) logResult(ctx context.Context, logger *slog.Logger, redactor redact.Redactor, call interceptor.Call, result result, err error) {
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{slog.Duration("duration", time.Since(call.Start))}
+	if result.statusCode != 0 {
+		attrs = append(attrs, slog.Int("status", result.statusCode))
+	}
+	if result.requestID != "" {
+		attrs = append(attrs, slog.String("request_id", result.requestID))
+	}
+
+	if err == nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, "smapp request completed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.String("error", redactor.String(err.Error())))
+	logger.LogAttrs(ctx, failureLevel(err), "smapp request failed", attrs...)
+}
+
+// failureLevel returns slog.LevelError for failures that need attention, like network errors, timeouts,
+// 5xx responses and undecodable bodies, and slog.LevelWarn for the others.
+func failureLevel(err error) slog.Level {
+	var apiErr *smapperrors.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode >= http.StatusInternalServerError {
+			return slog.LevelError
+		}
+		return slog.LevelWarn
+	}
+
+	for _, kind := range []error{
+		context.Canceled,
+		smapperrors.ErrInvalidInput,
+		smapperrors.ErrStatusNotOK,
+		smapperrors.ErrCircuitOpen,
+		smapperrors.ErrRateLimited,
+		smapperrors.ErrInterceptor,
+	} {
+		if errors.Is(err, kind) {
+			return slog.LevelWarn
+		}
+	}
+	return slog.LevelError
+}
+
+// redactor returns the Redactor of the API key of the config and the given keys.
+func (e *Executor) redactor(keys ...string) redact.Redactor {
+	return redact.New(e.cfg.APIKeyName, append([]string{e.cfg.APIKey}, keys...)...)
+}
+
+// credential returns the credential of a call, from the credential provider of the config if it is set.
+func (e *Executor) credential(ctx context.Context) (credentials.Credential, error) {
+	if e.cfg.CredentialProvider == nil {
+		return credentials.Credential{APIKey: e.cfg.APIKey}, nil
+	}
+	return e.cfg.CredentialProvider.Credential(ctx)
+}
+
+// requestPath returns the path of rawURL, so the query params holding the API key are never logged.
+func requestPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// labels returns the metric labels of r, except the status class.
+func (e *Executor) labels(r Request) []attribute.KeyValue {
+	labels := []attribute.KeyValue{
+		ServiceKey.String(e.service),
+		OperationKey.String(r.Operation),
+		APIVersionKey.String(e.apiVersion),
+	}
+	if r.Engine != "" {
+		labels = append(labels, EngineKey.String(r.Engine))
+	}
+	return labels
+}
+
+// do sends r and fills the status code and size of its response in result, if any response is received.
+func (e *Executor) do(ctx context.Context, httpClient *http.Client, call interceptor.Call, r Request, decode Decoder, credential credentials.Credential, redactor redact.Redactor, result *result) error {
+	// Start of parent span
+	var span trace.Span
+	ctx, span = otel.Tracer(e.tracerName).Start(ctx, r.Operation)
+	defer span.End()
+	if len(r.Attributes) > 0 {
+		span.SetAttributes(r.Attributes...)
+	}
+
+	req, response, err := e.send(ctx, httpClient, call, r, credential, redactor)
+	if err != nil {
+		return err
+	}
+
+	result.statusCode = response.StatusCode
+	result.requestID = response.Header.Get(smapperrors.RequestIDHeader)
+	body := &countingBody{ReadCloser: response.Body}
+	response.Body = body
+	defer func() {
+		_, _ = io.Copy(io.Discard, body)
+		_ = body.Close()
+		result.size = body.n
+	}()
+
+	for _, i := range e.interceptors {
+		if err := i.AfterResponse(ctx, call, req, response); err != nil {
+			return smapperrors.New(e.service, r.Operation, smapperrors.ErrInterceptor, redactor.Error(err))
+		}
+	}
+
+	var responseSpan trace.Span
+	_, responseSpan = otel.Tracer(e.tracerName).Start(ctx, "response-deserialization")
+	defer responseSpan.End()
+
+	if response.StatusCode != http.StatusOK {
+		responseSpan.SetStatus(codes.Error, "non 200 status code")
+		responseSpan.SetAttributes(attribute.Int("status_code", response.StatusCode))
+		apiErr := smapperrors.NewAPIError(e.service, r.Operation, response)
+		apiErr.Body = redactor.String(apiErr.Body)
+		return apiErr
+	}
+
+	if err := decode(response.Body); err != nil {
+		if errors.Is(err, smapperrors.ErrStatusNotOK) {
+			responseSpan.SetStatus(codes.Error, "status not OK")
+			return smapperrors.New(e.service, r.Operation, smapperrors.ErrStatusNotOK, nil)
+		}
+		responseSpan.RecordError(err)
+		return smapperrors.New(e.service, r.Operation, smapperrors.ErrDecode, err)
+	}
+
+	return nil
+}
+
+// send sends the request of r with the key of credential. if the response is 401 or 403 and the previous key of
+// credential is in its grace period, the request is sent again with the previous key.
+func (e *Executor) send(ctx context.Context, httpClient *http.Client, call interceptor.Call, r Request, credential credentials.Credential, redactor redact.Redactor) (*http.Request, *http.Response, error) {
+	req, err := e.newRequest(ctx, call, r, credential.APIKey, redactor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deadline.SetHeader(req)
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, smapperrors.New(e.service, r.Operation, smapperrors.ErrRequest, redactor.Error(err))
+	}
+
+	if credential.PreviousAPIKey == "" ||
+		(response.StatusCode != http.StatusUnauthorized && response.StatusCode != http.StatusForbidden) {
+		return req, response, nil
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	trace.SpanFromContext(ctx).AddEvent(credentials.PreviousKeyEventName, trace.WithAttributes(
+		attribute.Int("status_code", response.StatusCode),
+	))
+	if logger := logging.FromContext(ctx); logger != nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, "smapp request sent with previous api key",
+			slog.Int("status", response.StatusCode),
+		)
+	}
+
+	return e.send(ctx, httpClient, call, r, credentials.Credential{APIKey: credential.PreviousAPIKey}, redactor)
+}
+
+// newRequest builds the http request of r with the given key inside the request-initialization span. the request
+// carries the span of the operation, so transports can add events and attributes to it.
+func (e *Executor) newRequest(ctx context.Context, call interceptor.Call, r Request, apiKey string, redactor redact.Redactor) (*http.Request, error) {
+	initCtx, reqInitSpan := otel.Tracer(e.tracerName).Start(ctx, "request-initialization")
+	defer reqInitSpan.End()
+
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	reqCtx := ratelimit.WithOperation(ctx, r.Operation)
+	if r.Idempotent {
+		reqCtx = retry.WithIdempotent(reqCtx)
+	}
+
+	var body io.Reader
+	if r.Body != nil {
+		body = bytes.NewReader(r.Body)
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, method, r.URL, body)
+	if err != nil {
+		reqInitSpan.RecordError(err)
+		return nil, smapperrors.Newf(e.service, r.Operation, smapperrors.ErrInvalidInput, "could not create request: %w", err)
+	}
+
+	params := url.Values{}
+	for key, values := range r.Query {
+		params[key] = values
+	}
+
+	switch e.cfg.APIKeySource {
+	case config.HeaderSource:
+		req.Header.Set(e.cfg.APIKeyName, apiKey)
+	case config.QueryParamSource:
+		params.Set(e.cfg.APIKeyName, apiKey)
+	default:
+		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
+		return nil, smapperrors.Newf(e.service, r.Operation, smapperrors.ErrInvalidAPIKeySource, "%s", string(e.cfg.APIKeySource))
+	}
+
+	if r.Body != nil && method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for key, val := range r.Headers {
+		req.Header.Set(key, val)
+	}
+
+	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
+
+	req.URL.RawQuery = params.Encode()
+
+	for _, i := range e.interceptors {
+		if err := i.BeforeRequest(initCtx, call, req); err != nil {
+			reqInitSpan.RecordError(err)
+			return nil, smapperrors.New(e.service, r.Operation, smapperrors.ErrInterceptor, redactor.Error(err))
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,522 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/credentials"
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+	"github.com/snapp-incubator/smapp-sdk-go/version"
+)
+
+func newTestExecutor(t *testing.T, cfg *config.Config, interceptors ...interceptor.Interceptor) *Executor {
+	t.Helper()
+	return New("test", cfg, &http.Client{}, WithInterceptors(interceptors...))
+}
+
+func TestExecutor_Do(t *testing.T) {
+	t.Run("header_source", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(config.DefaultHeaderAPIKeyName) != "key" {
+				t.Errorf("api key header should be key but it is %q", r.Header.Get(config.DefaultHeaderAPIKeyName))
+			}
+			if r.Header.Get("foo") != "bar" {
+				t.Errorf("foo header should be bar but it is %q", r.Header.Get("foo"))
+			}
+			if r.Header.Get(version.UserAgentHeader) != version.GetUserAgent() {
+				t.Errorf("user agent should be %s but it is %q", version.GetUserAgent(), r.Header.Get(version.UserAgentHeader))
+			}
+			if r.URL.Query().Get("a") != "1" {
+				t.Errorf("query param a should be 1 but it is %q", r.URL.Query().Get("a"))
+			}
+			_, _ = w.Write([]byte(`{"status":"ok","value":42}`))
+		}))
+		defer sv.Close()
+
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		var out struct {
+			Status string `json:"status"`
+			Value  int    `json:"value"`
+		}
+		err = newTestExecutor(t, cfg).Do(context.Background(), Request{
+			Operation: "op",
+			URL:       sv.URL,
+			Query:     url.Values{"a": []string{"1"}},
+			Headers:   map[string]string{"foo": "bar"},
+		}, JSONWithStatus(&out, &out.Status, "OK"))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if out.Value != 42 {
+			t.Fatalf("value should be 42 but it is %d", out.Value)
+		}
+	})
+
+	t.Run("query_source_and_post", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("key", config.WithAPIKeySource(config.QueryParamSource), config.WithAPIKeyName("key"))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("method should be POST but it is %s", r.Method)
+			}
+			if r.URL.Query().Get("key") != "key" {
+				t.Errorf("api key query param should be key but it is %q", r.URL.Query().Get("key"))
+			}
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("content type should be application/json but it is %q", r.Header.Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"a":1}` {
+				t.Errorf("body should be {\"a\":1} but it is %s", string(body))
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer sv.Close()
+
+		var out struct{}
+		err = newTestExecutor(t, cfg).Do(context.Background(), Request{
+			Operation: "op",
+			Method:    http.MethodPost,
+			URL:       sv.URL,
+			Body:      []byte(`{"a":1}`),
+		}, JSON(&out))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/not-ok":
+				_, _ = w.Write([]byte(`{"status":"ERROR"}`))
+			case "/invalid":
+				_, _ = w.Write([]byte(`{`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+		defer sv.Close()
+
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		e := newTestExecutor(t, cfg)
+
+		var out struct {
+			Status string `json:"status"`
+		}
+		cases := []struct {
+			name string
+			ctx  context.Context
+			path string
+			kind error
+		}{
+			{"nil_context", nil, "/", smapperrors.ErrNilContext},
+			{"status_not_ok", context.Background(), "/not-ok", smapperrors.ErrStatusNotOK},
+			{"decode", context.Background(), "/invalid", smapperrors.ErrDecode},
+		}
+		for _, c := range cases {
+			err := e.Do(c.ctx, Request{Operation: "op", URL: sv.URL + c.path}, JSONWithStatus(&out, &out.Status, "OK"))
+			if !errors.Is(err, c.kind) {
+				t.Fatalf("%s: err should be %v but it is %v", c.name, c.kind, err)
+			}
+		}
+
+		err = e.Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		if smapperrors.StatusCode(err) != http.StatusBadRequest {
+			t.Fatalf("status code should be 400 but it is %d", smapperrors.StatusCode(err))
+		}
+
+		cfg.APIKeySource = "invalid"
+		err = e.Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		if !errors.Is(err, smapperrors.ErrInvalidAPIKeySource) {
+			t.Fatalf("err should be ErrInvalidAPIKeySource but it is %v", err)
+		}
+	})
+}
+
+func TestExecutor_Do_Interceptors(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Audit") != "yes" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	t.Run("order_and_hooks", func(t *testing.T) {
+		var events []string
+		record := func(name string) interceptor.Interceptor {
+			return interceptor.Funcs{
+				BeforeRequestFunc: func(_ context.Context, call interceptor.Call, req *http.Request) error {
+					if call.Service != "test" || call.Operation != "op" || call.Start.IsZero() {
+						t.Errorf("unexpected call: %+v", call)
+					}
+					events = append(events, name+":before")
+					return nil
+				},
+				AfterResponseFunc: func(_ context.Context, _ interceptor.Call, _ *http.Request, resp *http.Response) error {
+					events = append(events, name+":after")
+					return nil
+				},
+				OnErrorFunc: func(_ context.Context, _ interceptor.Call, err error) {
+					events = append(events, name+":error")
+				},
+			}
+		}
+
+		e := newTestExecutor(t, cfg, interceptor.WithHeaders(map[string]string{"X-Audit": "yes"}), record("a"), record("b"))
+		var out struct{}
+		if err := e.Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out)); err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+
+		expected := []string{"a:before", "b:before", "a:after", "b:after"}
+		if len(events) != len(expected) {
+			t.Fatalf("events should be %v but it is %v", expected, events)
+		}
+		for i := range expected {
+			if events[i] != expected[i] {
+				t.Fatalf("events should be %v but it is %v", expected, events)
+			}
+		}
+
+		events = nil
+		e = newTestExecutor(t, cfg, record("a"))
+		err := e.Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		if smapperrors.StatusCode(err) != http.StatusForbidden {
+			t.Fatalf("status code should be 403 but it is %d", smapperrors.StatusCode(err))
+		}
+		if events[len(events)-1] != "a:error" {
+			t.Fatalf("OnError should be called but events are %v", events)
+		}
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		abort := errors.New("not allowed")
+		var onError error
+		e := newTestExecutor(t, cfg, interceptor.Funcs{
+			BeforeRequestFunc: func(context.Context, interceptor.Call, *http.Request) error {
+				return abort
+			},
+			OnErrorFunc: func(_ context.Context, _ interceptor.Call, err error) {
+				onError = err
+			},
+		})
+
+		var out struct{}
+		err := e.Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		if !errors.Is(err, smapperrors.ErrInterceptor) || !errors.Is(err, abort) {
+			t.Fatalf("err should be ErrInterceptor wrapping the hook error but it is %v", err)
+		}
+		if onError != err {
+			t.Fatalf("OnError should receive the returned error")
+		}
+	})
+}
+
+func TestExecutor_Do_Logging(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(smapperrors.RequestIDHeader, "req-1")
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("secret-key", config.WithAPIKeySource(config.QueryParamSource), config.WithAPIKeyName("key"))
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	e := New("eta", cfg, &http.Client{}, WithLogger(logger))
+
+	var out struct{}
+	cases := []struct {
+		name     string
+		url      string
+		expected []string
+	}{
+		{
+			name:     "success",
+			url:      sv.URL + "/ok",
+			expected: []string{"level=DEBUG msg=\"smapp request started\" service=eta operation=op path=/ok", "level=DEBUG msg=\"smapp request completed\"", "status=200", "request_id=req-1"},
+		},
+		{
+			name:     "server_error",
+			url:      sv.URL + "/fail",
+			expected: []string{"level=ERROR msg=\"smapp request failed\"", "status=503", "request_id=req-1"},
+		},
+		{
+			name:     "client_error",
+			url:      sv.URL + "/bad",
+			expected: []string{"level=WARN msg=\"smapp request failed\"", "status=400"},
+		},
+		{
+			name:     "network_error",
+			url:      "http://127.0.0.1:1/unreachable",
+			expected: []string{"level=ERROR msg=\"smapp request failed\"", "path=/unreachable"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			logs.Reset()
+			_ = e.Do(context.Background(), Request{Operation: "op", URL: c.url}, JSON(&out))
+
+			for _, expected := range c.expected {
+				if !strings.Contains(logs.String(), expected) {
+					t.Fatalf("logs should contain %q but they are %q", expected, logs.String())
+				}
+			}
+			if strings.Contains(logs.String(), "secret-key") {
+				t.Fatalf("logs should not contain the api key but they are %q", logs.String())
+			}
+		})
+	}
+}
+
+// namedTracerProvider starts spans that only know their name.
+type namedTracerProvider struct {
+	embedded.TracerProvider
+}
+
+func (p namedTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return namedTracer{}
+}
+
+type namedTracer struct {
+	embedded.Tracer
+}
+
+func (namedTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &namedSpan{name: name}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type namedSpan struct {
+	noop.Span
+	name string
+}
+
+func TestExecutor_Do_RequestSpan(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(namedTracerProvider{})
+	defer otel.SetTracerProvider(previous)
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	// transports add events and attributes to the span of the request, so it must be the span of the operation.
+	var spanName string
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if span, ok := trace.SpanFromContext(req.Context()).(*namedSpan); ok {
+			spanName = span.name
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Request: req}, nil
+	})}
+	var out struct{}
+	err = New("test", cfg, client).Do(context.Background(), Request{Operation: "op", URL: "http://smapp.local"}, JSON(&out))
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if spanName != "op" {
+		t.Fatalf("span of the request should be op but it is %q", spanName)
+	}
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Credential(context.Context) (credentials.Credential, error) {
+	return credentials.Credential{}, errors.New("secret store is down")
+}
+
+func TestExecutor_Do_Credentials(t *testing.T) {
+	// the server does not accept the new key yet.
+	var keys []string
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(config.DefaultHeaderAPIKeyName)
+		keys = append(keys, key)
+		if key != "old-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	t.Run("previous_key_in_grace_period", func(t *testing.T) {
+		keys = nil
+		provider := credentials.NewStatic("old-key", credentials.WithGracePeriod(time.Minute))
+		provider.Rotate("new-key")
+		cfg, err := config.NewDefaultConfig("", config.WithCredentialProvider(provider))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		var out struct{}
+		if err := newTestExecutor(t, cfg).Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out)); err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if len(keys) != 2 || keys[0] != "new-key" || keys[1] != "old-key" {
+			t.Fatalf("request should be sent with new-key and then old-key but keys are %v", keys)
+		}
+	})
+
+	t.Run("no_grace_period", func(t *testing.T) {
+		keys = nil
+		provider := credentials.NewStatic("old-key")
+		provider.Rotate("new-key")
+		cfg, err := config.NewDefaultConfig("", config.WithCredentialProvider(provider))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		var out struct{}
+		err = newTestExecutor(t, cfg).Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("err should be a 401 APIError but it is %v", err)
+		}
+		if len(keys) != 1 {
+			t.Fatalf("request should be sent once but it is sent %d times", len(keys))
+		}
+	})
+
+	t.Run("provider_error", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("", config.WithCredentialProvider(failingProvider{}))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		var out struct{}
+		err = newTestExecutor(t, cfg).Do(context.Background(), Request{Operation: "op", URL: sv.URL}, JSON(&out))
+		if !errors.Is(err, smapperrors.ErrCredentials) {
+			t.Fatalf("err should be ErrCredentials but it is %v", err)
+		}
+	})
+}
+
+func TestExecutor_LogCacheError(t *testing.T) {
+	provider := credentials.NewStatic("old-key", credentials.WithGracePeriod(time.Minute))
+	provider.Rotate("new-key")
+	cfg, err := config.NewDefaultConfig("", config.WithCredentialProvider(provider))
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	var logs bytes.Buffer
+	e := New("reverse", cfg, &http.Client{}, WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	e.LogCacheError(context.Background(), "op", errors.New("could not get smapp:reverse:new-key:old-key"))
+
+	if !strings.Contains(logs.String(), "level=WARN msg=\"smapp cache failed\" service=reverse operation=op") {
+		t.Fatalf("cache failure should be logged but logs are %s", logs.String())
+	}
+	if strings.Contains(logs.String(), "new-key") || strings.Contains(logs.String(), "old-key") {
+		t.Fatalf("keys of the credential provider should be redacted but logs are %s", logs.String())
+	}
+}
+
+func TestExecutor_Do_Timeout(t *testing.T) {
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	var budget string
+	var remaining time.Duration
+	client := &http.Client{Timeout: 100 * time.Millisecond, Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		budget = req.Header.Get(deadline.Header)
+		remaining, _ = deadline.Remaining(req.Context())
+		select {
+		case <-time.After(200 * time.Millisecond):
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Request: req}, nil
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	})}
+	e := New("test", cfg, client)
+
+	t.Run("client_timeout", func(t *testing.T) {
+		var out struct{}
+		err := e.Do(context.Background(), Request{Operation: "op", URL: "http://smapp.local"}, JSON(&out))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be DeadlineExceeded but it is %v", err)
+		}
+		if remaining <= 0 || remaining > 100*time.Millisecond {
+			t.Fatalf("budget of the request should be at most 100ms but it is %s", remaining)
+		}
+		if ms, err := strconv.Atoi(budget); err != nil || ms > 100 {
+			t.Fatalf("budget header should be at most 100 but it is %q", budget)
+		}
+	})
+
+	t.Run("call_timeout_overrides_client", func(t *testing.T) {
+		var out struct{}
+		err := e.Do(context.Background(), Request{Operation: "op", URL: "http://smapp.local", Timeout: time.Second}, JSON(&out))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if remaining <= 100*time.Millisecond || remaining > time.Second {
+			t.Fatalf("budget of the request should be at most 1s but it is %s", remaining)
+		}
+		if client.Timeout != 100*time.Millisecond {
+			t.Fatal("timeout of the http client should not be changed")
+		}
+	})
+
+	t.Run("context_deadline_is_kept", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var out struct{}
+		err := e.Do(ctx, Request{Operation: "op", URL: "http://smapp.local", Timeout: time.Second}, JSON(&out))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be DeadlineExceeded but it is %v", err)
+		}
+		if remaining > 50*time.Millisecond {
+			t.Fatalf("budget of the request should be at most 50ms but it is %s", remaining)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// names of the recorded metrics.
+const (
+	RequestDurationMetricName = "smapp.client.request.duration"
+	ActiveRequestsMetricName  = "smapp.client.active_requests"
+	ResponseSizeMetricName    = "smapp.client.response.size"
+	RequestErrorsMetricName   = "smapp.client.request.errors"
+	CacheLookupsMetricName    = "smapp.client.cache.lookups"
+)
+
+// labels of the recorded metrics.
+const (
+	ServiceKey     = attribute.Key("service")
+	OperationKey   = attribute.Key("operation")
+	StatusClassKey = attribute.Key("status_class")
+	EngineKey      = attribute.Key("engine")
+	APIVersionKey  = attribute.Key("api_version")
+	CacheResultKey = attribute.Key("result")
+)
+
+// StatusClassError is the status class of calls that failed without receiving a response.
+const StatusClassError = "error"
+
+// metrics holds the instruments of an Executor. a nil *metrics records nothing.
+type metrics struct {
+	duration       metric.Float64Histogram
+	activeRequests metric.Int64UpDownCounter
+	responseSize   metric.Int64Histogram
+	errors         metric.Int64Counter
+	cacheLookups   metric.Int64Counter
+}
+
+// newMetrics creates the instruments using the global meter provider. instruments that could not be created are
+// reported to the global error handler and replaced with no-op ones by opentelemetry.
+func newMetrics(meterName string) *metrics {
+	meter := otel.Meter(meterName)
+	m := &metrics{}
+
+	var err error
+	m.duration, err = meter.Float64Histogram(RequestDurationMetricName,
+		metric.WithDescription("Duration of calls to smapp services, including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.activeRequests, err = meter.Int64UpDownCounter(ActiveRequestsMetricName,
+		metric.WithDescription("Number of in-flight calls to smapp services."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.responseSize, err = meter.Int64Histogram(ResponseSizeMetricName,
+		metric.WithDescription("Size of response bodies of smapp services."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.errors, err = meter.Int64Counter(RequestErrorsMetricName,
+		metric.WithDescription("Number of failed calls to smapp services."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.cacheLookups, err = meter.Int64Counter(CacheLookupsMetricName,
+		metric.WithDescription("Number of lookups of response caches of smapp services."),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return m
+}
+
+// result holds the response of a call needed by metrics and logs. statusCode is zero if no response is received.
+type result struct {
+	statusCode int
+	size       int64
+	requestID  string
+}
+
+// start records the start of a call and returns a function that must be called with its result.
+func (m *metrics) start(ctx context.Context, labels []attribute.KeyValue) func(result, error) {
+	if m == nil {
+		return func(result, error) {}
+	}
+
+	start := time.Now()
+	active := metric.WithAttributeSet(attribute.NewSet(labels...))
+	m.activeRequests.Add(ctx, 1, active)
+
+	return func(r result, err error) {
+		m.activeRequests.Add(ctx, -1, active)
+
+		attrs := metric.WithAttributeSet(attribute.NewSet(append(labels, StatusClassKey.String(statusClass(r.statusCode)))...))
+		m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+		if r.statusCode != 0 {
+			m.responseSize.Record(ctx, r.size, attrs)
+		}
+		if err != nil {
+			m.errors.Add(ctx, 1, attrs)
+		}
+	}
+}
+
+// statusClass returns the class of a status code, e.g. `2xx`, or StatusClassError if it is zero.
+func statusClass(statusCode int) string {
+	if statusCode == 0 {
+		return StatusClassError
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingBody) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
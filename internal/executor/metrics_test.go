@@ -0,0 +1,249 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+type measurement struct {
+	name  string
+	value float64
+	attrs attribute.Set
+}
+
+// fakeMeterProvider records all measurements of the instruments used by the executor.
+type fakeMeterProvider struct {
+	embedded.MeterProvider
+
+	mu           sync.Mutex
+	measurements []measurement
+}
+
+func (p *fakeMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return &fakeMeter{provider: p}
+}
+
+func (p *fakeMeterProvider) record(name string, value float64, attrs attribute.Set) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.measurements = append(p.measurements, measurement{
+		name:  name,
+		value: value,
+		attrs: attrs,
+	})
+}
+
+func (p *fakeMeterProvider) find(name string) []measurement {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var found []measurement
+	for _, m := range p.measurements {
+		if m.name == name {
+			found = append(found, m)
+		}
+	}
+	return found
+}
+
+type fakeMeter struct {
+	noop.Meter
+	provider *fakeMeterProvider
+}
+
+func (m *fakeMeter) Float64Histogram(name string, _ ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return &fakeFloat64Histogram{name: name, provider: m.provider}, nil
+}
+
+func (m *fakeMeter) Int64Histogram(name string, _ ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	return &fakeInt64Histogram{name: name, provider: m.provider}, nil
+}
+
+func (m *fakeMeter) Int64UpDownCounter(name string, _ ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	return &fakeInt64UpDownCounter{name: name, provider: m.provider}, nil
+}
+
+func (m *fakeMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return &fakeInt64Counter{name: name, provider: m.provider}, nil
+}
+
+type fakeFloat64Histogram struct {
+	noop.Float64Histogram
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (h *fakeFloat64Histogram) Record(_ context.Context, value float64, opts ...metric.RecordOption) {
+	h.provider.record(h.name, value, metric.NewRecordConfig(opts).Attributes())
+}
+
+type fakeInt64Histogram struct {
+	noop.Int64Histogram
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (h *fakeInt64Histogram) Record(_ context.Context, value int64, opts ...metric.RecordOption) {
+	h.provider.record(h.name, float64(value), metric.NewRecordConfig(opts).Attributes())
+}
+
+type fakeInt64UpDownCounter struct {
+	noop.Int64UpDownCounter
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (c *fakeInt64UpDownCounter) Add(_ context.Context, value int64, opts ...metric.AddOption) {
+	c.provider.record(c.name, float64(value), metric.NewAddConfig(opts).Attributes())
+}
+
+type fakeInt64Counter struct {
+	noop.Int64Counter
+	name     string
+	provider *fakeMeterProvider
+}
+
+func (c *fakeInt64Counter) Add(_ context.Context, value int64, opts ...metric.AddOption) {
+	c.provider.record(c.name, float64(value), metric.NewAddConfig(opts).Attributes())
+}
+
+func attr(set attribute.Set, key attribute.Key) string {
+	value, _ := set.Value(key)
+	return value.Emit()
+}
+
+func TestExecutor_Do_Metrics(t *testing.T) {
+	provider := &fakeMeterProvider{}
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	defer otel.SetMeterProvider(previous)
+
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":1}`))
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	e := New("eta", cfg, &http.Client{}, WithMeterName("test"), WithAPIVersion("v2"))
+
+	var out struct{}
+	if err := e.Do(context.Background(), Request{Operation: "get-eta", URL: sv.URL, Engine: "ocelot"}, JSON(&out)); err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	err = e.Do(context.Background(), Request{Operation: "get-eta", URL: sv.URL + "/fail"}, JSON(&out))
+	if smapperrors.StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("status code should be 503 but it is %d", smapperrors.StatusCode(err))
+	}
+
+	durations := provider.find(RequestDurationMetricName)
+	if len(durations) != 2 {
+		t.Fatalf("duration should be recorded 2 times but it is recorded %d times", len(durations))
+	}
+	labels := map[attribute.Key]string{
+		ServiceKey:     "eta",
+		OperationKey:   "get-eta",
+		APIVersionKey:  "v2",
+		EngineKey:      "ocelot",
+		StatusClassKey: "2xx",
+	}
+	for key, expected := range labels {
+		if value := attr(durations[0].attrs, key); value != expected {
+			t.Fatalf("label %s should be %s but it is %s", key, expected, value)
+		}
+	}
+	if attr(durations[1].attrs, StatusClassKey) != "5xx" {
+		t.Fatalf("status class should be 5xx but it is %s", attr(durations[1].attrs, StatusClassKey))
+	}
+	if _, ok := durations[1].attrs.Value(EngineKey); ok {
+		t.Fatal("engine label should not be set for requests without engine")
+	}
+
+	sizes := provider.find(ResponseSizeMetricName)
+	if len(sizes) != 2 || sizes[0].value != float64(len(`{"value":1}`)) {
+		t.Fatalf("response size should be recorded as %d but it is %v", len(`{"value":1}`), sizes)
+	}
+
+	errs := provider.find(RequestErrorsMetricName)
+	if len(errs) != 1 || attr(errs[0].attrs, StatusClassKey) != "5xx" {
+		t.Fatalf("one 5xx error should be counted but errors are %v", errs)
+	}
+
+	var active float64
+	for _, m := range provider.find(ActiveRequestsMetricName) {
+		active += m.value
+	}
+	if active != 0 {
+		t.Fatalf("active requests should be 0 after calls but it is %f", active)
+	}
+
+	t.Run("no_response", func(t *testing.T) {
+		e := New("eta", cfg, &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})}, WithMeterName("test"))
+
+		_ = e.Do(context.Background(), Request{Operation: "get-eta", URL: sv.URL}, JSON(&out))
+		errs := provider.find(RequestErrorsMetricName)
+		if attr(errs[len(errs)-1].attrs, StatusClassKey) != StatusClassError {
+			t.Fatalf("status class should be %s but it is %s", StatusClassError, attr(errs[len(errs)-1].attrs, StatusClassKey))
+		}
+		if len(provider.find(ResponseSizeMetricName)) != 2 {
+			t.Fatal("response size should not be recorded without response")
+		}
+	})
+
+	t.Run("cache_lookups", func(t *testing.T) {
+		e.RecordCacheLookup(context.Background(), "get-display-name-address", true)
+		e.RecordCacheLookup(context.Background(), "get-display-name-address", false)
+
+		lookups := provider.find(CacheLookupsMetricName)
+		if len(lookups) != 2 {
+			t.Fatalf("cache lookups should be recorded 2 times but it is recorded %d times", len(lookups))
+		}
+		if attr(lookups[0].attrs, CacheResultKey) != "hit" || attr(lookups[1].attrs, CacheResultKey) != "miss" {
+			t.Fatalf("cache lookups should be a hit and a miss but they are %v", lookups)
+		}
+		if attr(lookups[0].attrs, OperationKey) != "get-display-name-address" {
+			t.Fatalf("operation should be get-display-name-address but it is %s", attr(lookups[0].attrs, OperationKey))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if New("eta", cfg, &http.Client{}).metrics != nil {
+			t.Fatal("metrics should be disabled without meter name")
+		}
+	})
+}
+
+func TestStatusClass(t *testing.T) {
+	cases := map[int]string{0: StatusClassError, 200: "2xx", 404: "4xx", 503: "5xx"}
+	for code, expected := range cases {
+		if statusClass(code) != expected {
+			t.Fatalf("status class of %d should be %s but it is %s", code, expected, statusClass(code))
+		}
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,60 @@
+package executor
+
+import (
+	"net/http"
+
+	"github.com/snapp-incubator/smapp-sdk-go/breaker"
+	"github.com/snapp-incubator/smapp-sdk-go/coalesce"
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/failover"
+	"github.com/snapp-incubator/smapp-sdk-go/hedge"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+// TransportOptions holds the per-service settings of the transport chain of a client. nil and false settings leave
+// their transport out of the chain.
+type TransportOptions struct {
+	// RateLimiter limits the requests of the client. cfg.RateLimiter is used if it is nil.
+	RateLimiter *ratelimit.Limiter
+	// HedgePolicy enables hedging of requests.
+	HedgePolicy *hedge.Policy
+	// RetryPolicy enables retrying requests. cfg.RetryPolicy is used if it is nil.
+	RetryPolicy *retry.Policy
+	// CircuitBreaker guards the requests of the client.
+	CircuitBreaker *breaker.Breaker
+	// Coalescing shares the response of identical concurrent requests.
+	Coalescing bool
+}
+
+// NewTransport wraps base with the transports enabled by opts and cfg for the given service. from the innermost,
+// the chain is failover, rate limit, hedge, retry, circuit breaker and coalescing. the settings of opts that are
+// taken from cfg are stored in opts.
+func NewTransport(base http.RoundTripper, service string, cfg *config.Config, opts *TransportOptions) http.RoundTripper {
+	transport := base
+	if cfg.Failover != nil {
+		transport = failover.NewTransport(transport, cfg.Failover)
+	}
+	if opts.RateLimiter == nil {
+		opts.RateLimiter = cfg.RateLimiter
+	}
+	if opts.RateLimiter != nil {
+		transport = ratelimit.NewTransport(transport, opts.RateLimiter, service, cfg.APIKeyName)
+	}
+	if opts.HedgePolicy != nil {
+		transport = hedge.NewTransport(transport, *opts.HedgePolicy)
+	}
+	if opts.RetryPolicy == nil {
+		opts.RetryPolicy = cfg.RetryPolicy
+	}
+	if opts.RetryPolicy != nil {
+		transport = retry.NewTransport(transport, *opts.RetryPolicy)
+	}
+	if opts.CircuitBreaker != nil {
+		transport = breaker.NewTransport(transport, opts.CircuitBreaker)
+	}
+	if opts.Coalescing {
+		transport = coalesce.NewTransport(transport)
+	}
+	return transport
+}
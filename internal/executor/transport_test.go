@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/breaker"
+	"github.com/snapp-incubator/smapp-sdk-go/coalesce"
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/failover"
+	"github.com/snapp-incubator/smapp-sdk-go/hedge"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		if transport := NewTransport(http.DefaultTransport, "test", cfg, &TransportOptions{}); transport != http.DefaultTransport {
+			t.Fatalf("transport should be the base transport but it is %T", transport)
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("key",
+			config.WithRetryPolicy(retry.DefaultPolicy()),
+			config.WithRateLimiter(ratelimit.New(ratelimit.Limit{Rate: 10, Burst: 1})),
+			config.WithRegions("https://{REGION}.example.com", "a", "b"),
+		)
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+
+		opts := TransportOptions{
+			HedgePolicy:    &hedge.Policy{},
+			CircuitBreaker: breaker.New(breaker.Settings{Name: "test"}),
+			Coalescing:     true,
+		}
+		transport := NewTransport(http.DefaultTransport, "test", cfg, &opts)
+
+		if opts.RetryPolicy != cfg.RetryPolicy || opts.RateLimiter != cfg.RateLimiter {
+			t.Fatal("retry policy and rate limiter should be taken from config")
+		}
+
+		coalesceTransport, ok := transport.(*coalesce.Transport)
+		if !ok {
+			t.Fatalf("outermost transport should be *coalesce.Transport but it is %T", transport)
+		}
+		breakerTransport, ok := coalesceTransport.Base.(*breaker.Transport)
+		if !ok {
+			t.Fatalf("coalesce transport should wrap *breaker.Transport but it wraps %T", coalesceTransport.Base)
+		}
+		retryTransport, ok := breakerTransport.Base.(*retry.Transport)
+		if !ok {
+			t.Fatalf("breaker transport should wrap *retry.Transport but it wraps %T", breakerTransport.Base)
+		}
+		hedgeTransport, ok := retryTransport.Base.(*hedge.Transport)
+		if !ok {
+			t.Fatalf("retry transport should wrap *hedge.Transport but it wraps %T", retryTransport.Base)
+		}
+		rateLimitTransport, ok := hedgeTransport.Base.(*ratelimit.Transport)
+		if !ok {
+			t.Fatalf("hedge transport should wrap *ratelimit.Transport but it wraps %T", hedgeTransport.Base)
+		}
+		if _, ok := rateLimitTransport.Base.(*failover.Transport); !ok {
+			t.Fatalf("rate limit transport should wrap *failover.Transport but it wraps %T", rateLimitTransport.Base)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+// Package logging carries the logger of a call in the context of its requests, so transports like retries can log
+// with the fields of the call.
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	logger, _ := ctx.Value(contextKey{}).(*slog.Logger)
+	return logger
+}
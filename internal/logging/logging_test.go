@@ -0,0 +1,18 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != nil {
+		t.Fatal("FromContext should return nil for a context without logger")
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	if FromContext(WithLogger(context.Background(), logger)) != logger {
+		t.Fatal("FromContext should return the logger of the context")
+	}
+}
@@ -0,0 +1,5 @@
+// Package ratelimit contains a client-side token bucket rate limiter for service clients.
+// a Limiter keeps one bucket per API key and endpoint, so clients sharing a Limiter (e.g. through
+// config.Config.RateLimiter) share the quota of each endpoint. depending on its Mode, a Limiter either waits for
+// a token or rejects the request with a *LimitError, which matches smapperrors.ErrRateLimited.
+package ratelimit
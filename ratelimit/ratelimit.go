@@ -0,0 +1,260 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// Mode determines what a Limiter does when there is no token left.
+type Mode int
+
+const (
+	// BlockMode waits for a token until the context of the request is done.
+	BlockMode Mode = iota
+	// NoWaitMode rejects the request immediately with a *LimitError.
+	NoWaitMode
+)
+
+// String casts the mode enum to its string value.
+func (m Mode) String() string {
+	switch m {
+	case BlockMode:
+		return "block"
+	case NoWaitMode:
+		return "no-wait"
+	}
+	return "unknown"
+}
+
+// Limit is the rate of a token bucket.
+type Limit struct {
+	// Rate is the number of tokens added to the bucket per second. zero or negative values mean no limit.
+	Rate float64
+	// Burst is the capacity of the bucket. values less than 1 are replaced with 1.
+	Burst int
+}
+
+// Unlimited is a Limit that lets all requests pass.
+var Unlimited = Limit{Rate: math.Inf(1)}
+
+// Every creates a Limit that adds a token every interval.
+func Every(interval time.Duration, burst int) Limit {
+	if interval <= 0 {
+		return Unlimited
+	}
+	return Limit{Rate: float64(time.Second) / float64(interval), Burst: burst}
+}
+
+func (l Limit) unlimited() bool {
+	return l.Rate <= 0 || math.IsInf(l.Rate, 1)
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// LimitError is returned for requests rejected by a Limiter. it matches smapperrors.ErrRateLimited using errors.Is.
+type LimitError struct {
+	// Endpoint is the endpoint of the rejected request. e.g. `search/autocomplete`.
+	Endpoint string
+	// RetryAfter is the time to wait until a token is available.
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s: retry after %s", e.Endpoint, smapperrors.ErrRateLimited, e.RetryAfter)
+}
+
+// Is reports whether target is smapperrors.ErrRateLimited.
+func (e *LimitError) Is(target error) bool {
+	return target == smapperrors.ErrRateLimited
+}
+
+// Option is a function type for customizing a Limiter in a fluent way.
+type Option func(limiter *Limiter)
+
+// WithEndpointLimit sets the limit of the given endpoint. an endpoint is either a service name like `reverse` or
+// a service name and an operation like `reverse/get-address-components`. the most specific limit is used.
+func WithEndpointLimit(endpoint string, limit Limit) Option {
+	return func(limiter *Limiter) {
+		limiter.limits[endpoint] = limit
+	}
+}
+
+// WithMode sets the Mode of the Limiter. default is BlockMode.
+func WithMode(mode Mode) Option {
+	return func(limiter *Limiter) {
+		limiter.mode = mode
+	}
+}
+
+// Limiter is a token bucket rate limiter with one bucket per API key and endpoint. it is safe for concurrent use
+// and is meant to be shared by all clients calling the same API keys.
+type Limiter struct {
+	defaultLimit Limit
+	limits       map[string]Limit
+	mode         Mode
+	now          func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+type bucketKey struct {
+	apiKey   string
+	endpoint string
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New creates a Limiter applying defaultLimit to endpoints without a limit of their own.
+func New(defaultLimit Limit, opts ...Option) *Limiter {
+	limiter := &Limiter{
+		defaultLimit: defaultLimit,
+		limits:       make(map[string]Limit),
+		mode:         BlockMode,
+		now:          time.Now,
+		buckets:      make(map[bucketKey]*bucket),
+	}
+
+	for _, opt := range opts {
+		opt(limiter)
+	}
+
+	return limiter
+}
+
+// Mode returns the Mode of the limiter.
+func (l *Limiter) Mode() Mode {
+	return l.mode
+}
+
+// Limit returns the limit applied to the given endpoint and the endpoint whose bucket is used. endpoints without
+// a limit of their own or of their service get a bucket of their own with the default limit.
+func (l *Limiter) Limit(endpoint string) (Limit, string) {
+	if limit, ok := l.limits[endpoint]; ok {
+		return limit, endpoint
+	}
+	for i := len(endpoint) - 1; i > 0; i-- {
+		if endpoint[i] == '/' {
+			if limit, ok := l.limits[endpoint[:i]]; ok {
+				return limit, endpoint[:i]
+			}
+		}
+	}
+	return l.defaultLimit, endpoint
+}
+
+// Acquire takes a token for a request of apiKey to endpoint, either by calling Wait or Allow according to the
+// Mode of the limiter.
+func (l *Limiter) Acquire(ctx context.Context, apiKey, endpoint string) error {
+	if l.mode == NoWaitMode {
+		return l.Allow(apiKey, endpoint)
+	}
+	return l.Wait(ctx, apiKey, endpoint)
+}
+
+// Allow takes a token for a request of apiKey to endpoint if one is available. otherwise it returns a *LimitError.
+func (l *Limiter) Allow(apiKey, endpoint string) error {
+	wait, ok := l.reserve(apiKey, endpoint, 0)
+	if !ok {
+		return &LimitError{Endpoint: endpoint, RetryAfter: wait}
+	}
+	return nil
+}
+
+// Wait blocks until a token is available for a request of apiKey to endpoint. it returns ctx.Err() if ctx is done
+// first, or a *LimitError immediately if the deadline of ctx is sooner than the token.
+func (l *Limiter) Wait(ctx context.Context, apiKey, endpoint string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(l.now())
+	}
+
+	wait, ok := l.reserve(apiKey, endpoint, maxWait)
+	if !ok {
+		return &LimitError{Endpoint: endpoint, RetryAfter: wait}
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(apiKey, endpoint)
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket of apiKey and endpoint and returns the time to wait for it. if the wait is
+// longer than maxWait, the token is not taken.
+func (l *Limiter) reserve(apiKey, endpoint string, maxWait time.Duration) (time.Duration, bool) {
+	limit, limitEndpoint := l.Limit(endpoint)
+	if limit.unlimited() {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(bucketKey{apiKey: apiKey, endpoint: limitEndpoint}, limit, now)
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+
+	wait := time.Duration(-b.tokens / limit.Rate * float64(time.Second))
+	if wait > maxWait {
+		b.tokens++
+		return wait, false
+	}
+	return wait, true
+}
+
+// cancel gives back a token reserved by Wait.
+func (l *Limiter) cancel(apiKey, endpoint string) {
+	limit, limitEndpoint := l.Limit(endpoint)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(bucketKey{apiKey: apiKey, endpoint: limitEndpoint}, limit, l.now())
+	b.tokens = math.Min(b.tokens+1, limit.burst())
+}
+
+// bucket returns the bucket of key refilled up to now. l.mu must be held.
+func (l *Limiter) bucket(key bucketKey, limit Limit, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.burst(), last: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed.Seconds()*limit.Rate, limit.burst())
+		b.last = now
+	}
+	return b
+}
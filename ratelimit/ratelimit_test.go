@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLimiter(limit Limit, opts ...Option) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	limiter := New(limit, opts...)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestEvery(t *testing.T) {
+	limit := Every(100*time.Millisecond, 5)
+	if limit.Rate != 10 {
+		t.Fatalf("Rate should be 10 but it is %f", limit.Rate)
+	}
+	if limit.Burst != 5 {
+		t.Fatalf("Burst should be 5 but it is %d", limit.Burst)
+	}
+	if !Every(0, 1).unlimited() {
+		t.Fatalf("zero interval should be unlimited")
+	}
+}
+
+func TestLimiter_Limit(t *testing.T) {
+	limiter := New(Limit{Rate: 1},
+		WithEndpointLimit("reverse", Limit{Rate: 2}),
+		WithEndpointLimit("reverse/get-batch-reverse", Limit{Rate: 3}),
+	)
+
+	cases := []struct {
+		endpoint      string
+		rate          float64
+		limitEndpoint string
+	}{
+		{"reverse/get-batch-reverse", 3, "reverse/get-batch-reverse"},
+		{"reverse/get-address-components", 2, "reverse"},
+		{"reverse", 2, "reverse"},
+		{"search/autocomplete", 1, "search/autocomplete"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.endpoint, func(t *testing.T) {
+			limit, endpoint := limiter.Limit(c.endpoint)
+			if limit.Rate != c.rate {
+				t.Fatalf("Rate should be %f but it is %f", c.rate, limit.Rate)
+			}
+			if endpoint != c.limitEndpoint {
+				t.Fatalf("endpoint should be %q but it is %q", c.limitEndpoint, endpoint)
+			}
+		})
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Run("burst_then_reject", func(t *testing.T) {
+		limiter, clock := newTestLimiter(Limit{Rate: 2, Burst: 2})
+
+		for i := 0; i < 2; i++ {
+			if err := limiter.Allow("key", "eta"); err != nil {
+				t.Fatalf("request %d should be allowed: %s", i, err.Error())
+			}
+		}
+
+		err := limiter.Allow("key", "eta")
+		if !errors.Is(err, smapperrors.ErrRateLimited) {
+			t.Fatalf("err should be ErrRateLimited but it is %v", err)
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("errors.As should find *LimitError")
+		}
+		if limitErr.RetryAfter != 500*time.Millisecond {
+			t.Fatalf("RetryAfter should be 500ms but it is %s", limitErr.RetryAfter)
+		}
+		if limitErr.Endpoint != "eta" {
+			t.Fatalf("Endpoint should be eta but it is %s", limitErr.Endpoint)
+		}
+
+		clock.now = clock.now.Add(500 * time.Millisecond)
+		if err := limiter.Allow("key", "eta"); err != nil {
+			t.Fatalf("request should be allowed after refill: %s", err.Error())
+		}
+	})
+
+	t.Run("buckets_per_key_and_endpoint", func(t *testing.T) {
+		limiter, _ := newTestLimiter(Limit{Rate: 1, Burst: 1})
+
+		if err := limiter.Allow("key-1", "eta"); err != nil {
+			t.Fatalf("request should be allowed: %s", err.Error())
+		}
+		if err := limiter.Allow("key-2", "eta"); err != nil {
+			t.Fatalf("request of another key should be allowed: %s", err.Error())
+		}
+		if err := limiter.Allow("key-1", "matrix"); err != nil {
+			t.Fatalf("request to another endpoint should be allowed: %s", err.Error())
+		}
+		if err := limiter.Allow("key-1", "eta"); err == nil {
+			t.Fatalf("second request should be rejected")
+		}
+	})
+
+	t.Run("service_limit_shared_by_operations", func(t *testing.T) {
+		limiter, _ := newTestLimiter(Unlimited, WithEndpointLimit("search", Limit{Rate: 1, Burst: 1}))
+
+		if err := limiter.Allow("key", "search/autocomplete"); err != nil {
+			t.Fatalf("request should be allowed: %s", err.Error())
+		}
+		if err := limiter.Allow("key", "search/details"); err == nil {
+			t.Fatalf("request should be rejected because search limit is shared")
+		}
+		if err := limiter.Allow("key", "reverse/get-address-components"); err != nil {
+			t.Fatalf("unlimited endpoint should be allowed: %s", err.Error())
+		}
+	})
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Run("waits_for_token", func(t *testing.T) {
+		limiter := New(Limit{Rate: 20, Burst: 1})
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			if err := limiter.Wait(context.Background(), "key", "eta"); err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Fatalf("second request should wait about 50ms but it waited %s", elapsed)
+		}
+	})
+
+	t.Run("deadline_too_soon", func(t *testing.T) {
+		limiter := New(Limit{Rate: 1, Burst: 1})
+		_ = limiter.Allow("key", "eta")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := limiter.Wait(ctx, "key", "eta")
+		if !errors.Is(err, smapperrors.ErrRateLimited) {
+			t.Fatalf("err should be ErrRateLimited but it is %v", err)
+		}
+		if time.Since(start) > 5*time.Millisecond {
+			t.Fatalf("Wait should return immediately when the deadline is sooner than the token")
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		limiter := New(Limit{Rate: 1, Burst: 1})
+		_ = limiter.Allow("key", "eta")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		err := limiter.Wait(ctx, "key", "eta")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err should be context.Canceled but it is %v", err)
+		}
+	})
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	limiter := New(Limit{Rate: 1, Burst: 1}, WithMode(NoWaitMode))
+	if limiter.Mode() != NoWaitMode {
+		t.Fatalf("Mode should be no-wait but it is %s", limiter.Mode())
+	}
+
+	if err := limiter.Acquire(context.Background(), "key", "eta"); err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if err := limiter.Acquire(context.Background(), "key", "eta"); !errors.Is(err, smapperrors.ErrRateLimited) {
+		t.Fatalf("err should be ErrRateLimited but it is %v", err)
+	}
+}
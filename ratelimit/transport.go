@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+)
+
+type operationContextKey struct{}
+
+// WithOperation marks the requests created with the returned context as requests of the given operation of the
+// service. it is used by service clients to build the endpoint of each request.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+// Endpoint returns the endpoint of req for the given service. it is `service/operation` if the operation is marked
+// using WithOperation, and `service` otherwise.
+func Endpoint(req *http.Request, service string) string {
+	if operation, ok := req.Context().Value(operationContextKey{}).(string); ok && operation != "" {
+		return service + "/" + operation
+	}
+	return service
+}
+
+// Transport is a http.RoundTripper that takes a token from a Limiter before sending each request.
+// The API key of each request is read from its header or query param named APIKeyName.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+	// Limiter is the rate limiter guarding Base.
+	Limiter *Limiter
+	// Service is the name of the service called through the Transport. e.g. `reverse`.
+	Service string
+	// APIKeyName is the name of header or query param holding the API key.
+	APIKeyName string
+}
+
+// NewTransport wraps base with a Transport limiting the requests of the given service.
+func NewTransport(base http.RoundTripper, limiter *Limiter, service, apiKeyName string) *Transport {
+	return &Transport{
+		Base:       base,
+		Limiter:    limiter,
+		Service:    service,
+		APIKeyName: apiKeyName,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	apiKey := req.Header.Get(t.APIKeyName)
+	if apiKey == "" && req.URL != nil {
+		apiKey = req.URL.Query().Get(t.APIKeyName)
+	}
+
+	if err := t.Limiter.Acquire(req.Context(), apiKey, Endpoint(req, t.Service)); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return base.RoundTrip(req)
+}
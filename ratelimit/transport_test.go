@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestEndpoint(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if endpoint := Endpoint(req, "eta"); endpoint != "eta" {
+		t.Fatalf("endpoint should be eta but it is %s", endpoint)
+	}
+
+	req, _ = http.NewRequestWithContext(WithOperation(context.Background(), "autocomplete"), http.MethodGet, "http://localhost", nil)
+	if endpoint := Endpoint(req, "search"); endpoint != "search/autocomplete" {
+		t.Fatalf("endpoint should be search/autocomplete but it is %s", endpoint)
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer sv.Close()
+
+	limiter := New(Limit{Rate: 1, Burst: 1}, WithMode(NoWaitMode))
+	client := http.Client{Transport: NewTransport(http.DefaultTransport, limiter, "search", "X-Smapp-Key")}
+
+	send := func(key string, query bool) error {
+		req, _ := http.NewRequest(http.MethodGet, sv.URL, nil)
+		if query {
+			req.URL.RawQuery = "X-Smapp-Key=" + key
+		} else {
+			req.Header.Set("X-Smapp-Key", key)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := send("key-1", false); err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if err := send("key-1", true); !errors.Is(err, smapperrors.ErrRateLimited) {
+		t.Fatalf("err should be ErrRateLimited but it is %v", err)
+	}
+	if err := send("key-2", true); err != nil {
+		t.Fatalf("request of another key should not return error: %s", err.Error())
+	}
+	if calls != 2 {
+		t.Fatalf("server should be called 2 times but it is called %d times", calls)
+	}
+}
@@ -0,0 +1,4 @@
+// Package redact removes the API key from strings, urls, headers, errors and request dumps.
+// service clients use it for every error they return and every log they write, and to hide the key from
+// opentelemetry spans. it can be used in custom middlewares and interceptors that log or dump requests.
+package redact
@@ -0,0 +1,146 @@
+package redact
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Placeholder replaces the API key.
+const Placeholder = "REDACTED"
+
+// Redactor replaces API keys with Placeholder. the zero value redacts nothing.
+type Redactor struct {
+	keyName string
+	keys    []string
+}
+
+// New creates a Redactor for the API keys with the given header or query param name, e.g.
+// `redact.New(cfg.APIKeyName, cfg.APIKey)`. more than one key is redacted while keys are rotated. empty keys are
+// ignored.
+func New(keyName string, keys ...string) Redactor {
+	r := Redactor{keyName: keyName}
+	for _, key := range keys {
+		if key != "" {
+			r.keys = append(r.keys, key)
+		}
+	}
+	return r
+}
+
+// String replaces the keys and their escaped forms in s.
+func (r Redactor) String(s string) string {
+	for _, key := range r.keys {
+		s = strings.ReplaceAll(s, key, Placeholder)
+		s = strings.ReplaceAll(s, url.QueryEscape(key), Placeholder)
+		s = strings.ReplaceAll(s, url.PathEscape(key), Placeholder)
+	}
+	return s
+}
+
+// URL returns a copy of u whose key query param and password are redacted.
+func (r Redactor) URL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+
+	redacted := *u
+	if _, ok := u.User.Password(); ok {
+		redacted.User = url.UserPassword(u.User.Username(), Placeholder)
+	}
+	if r.keyName != "" && u.RawQuery != "" {
+		query := u.Query()
+		if query.Has(r.keyName) {
+			query.Set(r.keyName, Placeholder)
+			redacted.RawQuery = query.Encode()
+		}
+	}
+	redacted.RawQuery = r.String(redacted.RawQuery)
+	redacted.Path = r.String(redacted.Path)
+	redacted.RawPath = r.String(redacted.RawPath)
+	return &redacted
+}
+
+// Header returns a copy of h whose key header is redacted.
+func (r Redactor) Header(h http.Header) http.Header {
+	redacted := h.Clone()
+	if r.keyName != "" && redacted.Get(r.keyName) != "" {
+		redacted.Set(r.keyName, Placeholder)
+	}
+	for name, values := range redacted {
+		for i := range values {
+			values[i] = r.String(values[i])
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// Error returns err if its message does not contain the key. otherwise it returns an error with a redacted message
+// that still matches the errors in the chain of err using errors.Is and errors.As. a *url.Error is replaced with a
+// copy with a redacted URL, so the key can not be found using errors.As either.
+func (r Redactor) Error(err error) error {
+	if err == nil || len(r.keys) == 0 || r.String(err.Error()) == err.Error() {
+		return err
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr == err {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: r.String(urlErr.URL),
+			Err: r.Error(urlErr.Err),
+		}
+	}
+
+	return &redactedError{err: err, msg: r.String(err.Error()), redactor: r}
+}
+
+// DumpRequest is like httputil.DumpRequestOut, but the key is redacted from the url, headers and body.
+// if body is true, the body of req is read and replaced, so req can still be sent.
+func (r Redactor) DumpRequest(req *http.Request, body bool) ([]byte, error) {
+	clone := req.Clone(req.Context())
+	clone.URL = r.URL(req.URL)
+	clone.Header = r.Header(req.Header)
+
+	dump, err := httputil.DumpRequestOut(clone, body)
+	if body {
+		req.Body = clone.Body
+	}
+	if err != nil {
+		return nil, r.Error(err)
+	}
+	return []byte(r.String(string(dump))), nil
+}
+
+type redactedError struct {
+	err      error
+	msg      string
+	redactor Redactor
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is in the chain of the original error. it does not expose the original error using
+// Unwrap, so its message can not be read.
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// As finds the first error in the chain of the original error that matches target, like errors.As. a *url.Error is
+// set to a copy with a redacted URL, as its message carries the raw URL.
+func (e *redactedError) As(target any) bool {
+	if !errors.As(e.err, target) {
+		return false
+	}
+	value := reflect.ValueOf(target).Elem()
+	if urlErr, ok := value.Interface().(*url.Error); ok {
+		value.Set(reflect.ValueOf(e.redactor.Error(urlErr)))
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package redact
+
+import (
+	"context"
+	"net/http"
+)
+
+type hiddenKey struct {
+	name string
+}
+
+// Around wraps base with middleware, so middleware never sees the value of the query param keyName. it is used to
+// keep the API key out of the spans recorded by otelhttp. the param is removed from the url before middleware is
+// called and added back right before base is called.
+func Around(keyName string, base http.RoundTripper, middleware func(next http.RoundTripper) http.RoundTripper) http.RoundTripper {
+	return &hideTransport{
+		keyName: keyName,
+		next:    middleware(&restoreTransport{keyName: keyName, base: base}),
+	}
+}
+
+// hideTransport moves the query param keyName from the url to the context of the request.
+type hideTransport struct {
+	keyName string
+	next    http.RoundTripper
+}
+
+func (t *hideTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	if t.keyName == "" || !query.Has(t.keyName) {
+		return t.next.RoundTrip(req)
+	}
+
+	values := query[t.keyName]
+	query.Del(t.keyName)
+
+	ctx := context.WithValue(req.Context(), hiddenKey{name: t.keyName}, values)
+	hidden := req.Clone(ctx)
+	hidden.URL.RawQuery = query.Encode()
+	return t.next.RoundTrip(hidden)
+}
+
+// restoreTransport moves the query param keyName back from the context to the url of the request.
+type restoreTransport struct {
+	keyName string
+	base    http.RoundTripper
+}
+
+func (t *restoreTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	values, ok := req.Context().Value(hiddenKey{name: t.keyName}).([]string)
+	if !ok {
+		return base.RoundTrip(req)
+	}
+
+	restored := req.Clone(req.Context())
+	query := restored.URL.Query()
+	query[t.keyName] = values
+	restored.URL.RawQuery = query.Encode()
+	return base.RoundTrip(restored)
+}
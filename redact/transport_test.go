@@ -0,0 +1,53 @@
+package redact
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAround(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("monshi_key") != "secret" || r.URL.Query().Get("a") != "1" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer sv.Close()
+
+	var seen string
+	middleware := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			seen = req.URL.String()
+			return next.RoundTrip(req)
+		})
+	}
+	client := http.Client{Transport: Around("monshi_key", http.DefaultTransport, middleware)}
+
+	resp, err := client.Get(sv.URL + "/?a=1&monshi_key=secret")
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("server should receive the key but status is %d", resp.StatusCode)
+	}
+	if seen != sv.URL+"/?a=1" {
+		t.Fatalf("middleware should not see the key but it sees %s", seen)
+	}
+
+	resp, err = client.Get(sv.URL + "/?a=1")
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("requests without key should be sent as is but status is %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,4 @@
+// Package retry contains the retry policy of service clients and a http.RoundTripper applying it.
+// it can be enabled for all clients built from a config using config.WithRetryPolicy, or per client using
+// the `WithRetryPolicy` constructor option of each service.
+package retry
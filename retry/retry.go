@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 2 * time.Second
+	DefaultMultiplier     = 2
+	DefaultJitter         = 0.2
+
+	RetryAfterHeader = "Retry-After"
+)
+
+// DefaultRetryableStatusCodes are the status codes retried by DefaultPolicy.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Policy specifies how failed requests are retried.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. values less than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the growth factor of the delay after each attempt. values less than 1 are treated as 1.
+	Multiplier float64
+	// Jitter is the fraction of each delay that is randomized, between 0 and 1.
+	Jitter float64
+	// RetryableStatusCodes are response status codes that are retried.
+	RetryableStatusCodes []int
+	// RespectRetryAfter makes the delay equal to the `Retry-After` header of the response, if present.
+	RespectRetryAfter bool
+	// MaxRetryAfter caps the delay of the `Retry-After` header. responses asking for a longer delay are returned
+	// without retrying. zero uses MaxBackoff, or DefaultMaxBackoff if MaxBackoff is not set either.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultPolicy returns a Policy with 3 attempts, exponential backoff from 100ms to 2s with 20% jitter,
+// which retries 429, 502, 503 and 504 responses and honors the `Retry-After` header.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:          DefaultMaxAttempts,
+		InitialBackoff:       DefaultInitialBackoff,
+		MaxBackoff:           DefaultMaxBackoff,
+		Multiplier:           DefaultMultiplier,
+		Jitter:               DefaultJitter,
+		RetryableStatusCodes: slices.Clone(DefaultRetryableStatusCodes),
+		RespectRetryAfter:    true,
+	}
+}
+
+// retryAfterLimit returns the longest `Retry-After` delay waited for before a retry.
+func (p Policy) retryAfterLimit() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return DefaultMaxBackoff
+}
+
+// Backoff returns the delay before the given retry. retry is 1 for the first retry.
+func (p Policy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// IsRetryableStatus reports whether a response with the given status code should be retried.
+func (p Policy) IsRetryableStatus(statusCode int) bool {
+	return slices.Contains(p.RetryableStatusCodes, statusCode)
+}
+
+// RetryAfter parses the `Retry-After` header of the response. both delay-seconds and http-date formats are supported.
+func RetryAfter(response *http.Response, now time.Time) (time.Duration, bool) {
+	value := response.Header.Get(RetryAfterHeader)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks requests created with the returned context as idempotent, so they are retried
+// even if their method is not GET or HEAD.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// IsIdempotent reports whether the given request can be sent more than once.
+func IsIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentKey{}).(bool)
+	return idempotent
+}
@@ -0,0 +1,93 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := Policy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+
+	cases := map[int]time.Duration{
+		0: 0,
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+	}
+	for retry, want := range cases {
+		if got := p.Backoff(retry); got != want {
+			t.Fatalf("Backoff(%d) should be %s but it is %s", retry, want, got)
+		}
+	}
+
+	t.Run("jitter", func(t *testing.T) {
+		p.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			got := p.Backoff(2)
+			if got < 100*time.Millisecond || got > 200*time.Millisecond {
+				t.Fatalf("Backoff(2) with jitter should be between 100ms and 200ms but it is %s", got)
+			}
+		}
+	})
+}
+
+func TestPolicy_IsRetryableStatus(t *testing.T) {
+	p := DefaultPolicy()
+	if !p.IsRetryableStatus(http.StatusServiceUnavailable) {
+		t.Fatal("503 should be retryable")
+	}
+	if p.IsRetryableStatus(http.StatusBadRequest) {
+		t.Fatal("400 should not be retryable")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("seconds", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{RetryAfterHeader: []string{"3"}}}
+		d, ok := RetryAfter(resp, now)
+		if !ok || d != 3*time.Second {
+			t.Fatalf("RetryAfter should be 3s but it is %s (%t)", d, ok)
+		}
+	})
+
+	t.Run("date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{RetryAfterHeader: []string{now.Add(5 * time.Second).Format(http.TimeFormat)}}}
+		d, ok := RetryAfter(resp, now)
+		if !ok || d != 5*time.Second {
+			t.Fatalf("RetryAfter should be 5s but it is %s (%t)", d, ok)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{RetryAfterHeader: []string{"soon"}}}
+		if _, ok := RetryAfter(resp, now); ok {
+			t.Fatal("RetryAfter should not be parsed")
+		}
+	})
+}
+
+func TestIsIdempotent(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if !IsIdempotent(get) {
+		t.Fatal("GET should be idempotent")
+	}
+
+	post, _ := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	if IsIdempotent(post) {
+		t.Fatal("POST should not be idempotent")
+	}
+
+	post, _ = http.NewRequestWithContext(WithIdempotent(context.Background()), http.MethodPost, "http://localhost", nil)
+	if !IsIdempotent(post) {
+		t.Fatal("POST marked by WithIdempotent should be idempotent")
+	}
+}
@@ -0,0 +1,123 @@
+package retry
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/logging"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// Transport is a http.RoundTripper that retries idempotent requests according to a Policy.
+// Network errors and responses with one of Policy.RetryableStatusCodes are retried, as long as
+// the next attempt fits within the deadline budget of the request: the delay before it plus the duration of the
+// last attempt should end before the deadline of the context. requests rejected by a client-side rate limiter
+// are not retried, and neither are responses whose `Retry-After` is longer than the limit of the policy.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+	// Policy specifies the retry behaviour.
+	Policy Policy
+}
+
+// NewTransport wraps base with a Transport retrying with the given policy.
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	return &Transport{
+		Base:   base,
+		Policy: policy,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t.Policy.MaxAttempts < 2 || !IsIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+			if req.Header.Get(deadline.Header) != "" {
+				deadline.SetHeader(attemptReq)
+			}
+		}
+
+		start := time.Now()
+		response, err := base.RoundTrip(attemptReq)
+		elapsed := time.Since(start)
+		if attempt >= t.Policy.MaxAttempts || ctx.Err() != nil || errors.Is(err, smapperrors.ErrRateLimited) {
+			return response, err
+		}
+		if err == nil && !t.Policy.IsRetryableStatus(response.StatusCode) {
+			return response, nil
+		}
+
+		delay := t.Policy.Backoff(attempt)
+		if err == nil && t.Policy.RespectRetryAfter {
+			if retryAfter, ok := RetryAfter(response, time.Now()); ok {
+				// the server is not expected to recover soon, so the response is returned instead of blocking the
+				// caller for a delay it has no control over.
+				if retryAfter > t.Policy.retryAfterLimit() {
+					return response, nil
+				}
+				delay = retryAfter
+			}
+		}
+
+		// the next attempt is expected to take as long as the last one, and it is not sent if it would not finish
+		// within the deadline budget of the call.
+		if !deadline.Fits(ctx, delay+elapsed) {
+			return response, err
+		}
+
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("attempt", attempt+1),
+			attribute.Int("status_code", statusCode),
+			attribute.Int64("delay_ms", delay.Milliseconds()),
+		))
+
+		if logger := logging.FromContext(ctx); logger != nil {
+			logger.LogAttrs(ctx, slog.LevelDebug, "smapp request retry",
+				slog.Int("attempt", attempt+1),
+				slog.Int("status", statusCode),
+				slog.Duration("delay", delay),
+			)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
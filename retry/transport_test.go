@@ -0,0 +1,348 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/logging"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testPolicy() Policy {
+	p := DefaultPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Run("retry_until_success", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`ok`))
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status should be 200 but it is %d", resp.StatusCode)
+		}
+		if calls != 3 {
+			t.Fatalf("server should be called 3 times but it is called %d times", calls)
+		}
+	})
+
+	t.Run("logs_retries", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 2 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`ok`))
+		}))
+		defer sv.Close()
+
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		req, _ := http.NewRequestWithContext(logging.WithLogger(context.Background(), logger), http.MethodGet, sv.URL, nil)
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+
+		if !strings.Contains(logs.String(), "smapp request retry") || !strings.Contains(logs.String(), "attempt=2 status=502") {
+			t.Fatalf("retry should be logged but logs are %q", logs.String())
+		}
+	})
+
+	t.Run("max_attempts", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("status should be 503 but it is %d", resp.StatusCode)
+		}
+		if calls != DefaultMaxAttempts {
+			t.Fatalf("server should be called %d times but it is called %d times", DefaultMaxAttempts, calls)
+		}
+	})
+
+	t.Run("non_retryable_status", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+	})
+
+	t.Run("retry_after_beyond_limit", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set(RetryAfterHeader, "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		// neither the client nor the request has a deadline, so only the limit prevents waiting for an hour.
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		start := time.Now()
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("status should be 503 but it is %d", resp.StatusCode)
+		}
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Fatalf("transport should not wait when Retry-After exceeds the limit")
+		}
+	})
+
+	t.Run("retry_after_within_max_retry_after", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set(RetryAfterHeader, "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer sv.Close()
+
+		// MaxRetryAfter allows a delay longer than MaxBackoff.
+		policy := testPolicy()
+		policy.MaxRetryAfter = 2 * time.Second
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, policy)}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || calls != 2 {
+			t.Fatalf("request should be retried after 1s but status is %d after %d calls", resp.StatusCode, calls)
+		}
+	})
+
+	t.Run("retry_after_beyond_deadline", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set(RetryAfterHeader, "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy()), Timeout: time.Second}
+		start := time.Now()
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("status should be 429 but it is %d", resp.StatusCode)
+		}
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Fatalf("transport should not wait when Retry-After exceeds the deadline")
+		}
+	})
+
+	t.Run("attempt_beyond_budget", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(150 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy()), Timeout: 250 * time.Millisecond}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("status should be 503 but it is %d", resp.StatusCode)
+		}
+		if calls != 1 {
+			t.Fatalf("a retry not fitting the budget should not be sent but server is called %d times", calls)
+		}
+	})
+
+	t.Run("budget_header", func(t *testing.T) {
+		var budgets []int
+		base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			budget, _ := strconv.Atoi(req.Header.Get(deadline.Header))
+			budgets = append(budgets, budget)
+			time.Sleep(20 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+		deadline.SetHeader(req)
+		original := req.Header.Get(deadline.Header)
+
+		resp, err := NewTransport(base, testPolicy()).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if len(budgets) != 3 {
+			t.Fatalf("request should be sent 3 times but it is sent %d times", len(budgets))
+		}
+		if budgets[2] >= budgets[0] || budgets[0] > 1000 {
+			t.Fatalf("each attempt should send its remaining budget but budgets are %v", budgets)
+		}
+		if req.Header.Get(deadline.Header) != original {
+			t.Fatal("header of the original request should not be modified")
+		}
+	})
+
+	t.Run("non_idempotent_post", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		resp, err := client.Post(sv.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+	})
+
+	t.Run("idempotent_post", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"a":1}` {
+				t.Errorf("body should be resent in each attempt but it is %q", string(body))
+			}
+			if atomic.AddInt32(&calls, 1) < 2 {
+				w.WriteHeader(http.StatusGatewayTimeout)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, testPolicy())}
+		req, _ := http.NewRequestWithContext(WithIdempotent(context.Background()), http.MethodPost, sv.URL, bytes.NewReader([]byte(`{"a":1}`)))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status should be 200 but it is %d", resp.StatusCode)
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		client := http.Client{Transport: NewTransport(http.DefaultTransport, Policy{MaxAttempts: 1})}
+		resp, err := client.Get(sv.URL)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if calls != 1 {
+			t.Fatalf("server should be called once but it is called %d times", calls)
+		}
+	})
+	t.Run("rate_limited", func(t *testing.T) {
+		var calls int32
+		base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, fmt.Errorf("search: %w", smapperrors.ErrRateLimited)
+		})
+
+		client := http.Client{Transport: NewTransport(base, testPolicy())}
+		_, err := client.Get("http://localhost")
+		if !errors.Is(err, smapperrors.ErrRateLimited) {
+			t.Fatalf("err should be ErrRateLimited but it is %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("base should be called once but it is called %d times", calls)
+		}
+	})
+}
@@ -1,19 +1,16 @@
 package area_gateways
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
-	"github.com/snapp-incubator/smapp-sdk-go/version"
-	"go.opentelemetry.io/otel"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/trace"
-	"io"
+	"log/slog"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 )
@@ -34,14 +31,21 @@ const (
 	V1 Version = "v1"
 
 	AcceptLanguageHeader = "Accept-Language"
+
+	serviceName = "area-gateways"
 )
 
 // Client is the main implementation of Interface for area-gateways service
 type Client struct {
-	cfg        *config.Config
-	url        string
-	httpClient http.Client
-	tracerName string
+	cfg          *config.Config
+	url          string
+	httpClient   http.Client
+	tracerName   string
+	meterName    string
+	transport    executor.TransportOptions
+	interceptors []interceptor.Interceptor
+	logger       *slog.Logger
+	executor     *executor.Executor
 }
 
 // Force Client to implement Interface at compile time
@@ -55,23 +59,7 @@ func (c *Client) GetGateways(lat, lon float64, options CallOptions) (Area, error
 
 // GetGatewaysWithContext is like GetGateways, but with context.Context support
 func (c *Client) GetGatewaysWithContext(ctx context.Context, lat, lon float64, options CallOptions) (Area, error) {
-	if ctx == nil {
-		return Area{}, fmt.Errorf("smapp area-gateways: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "get-gateways")
-	defer span.End()
-	span.SetAttributes(
-		attribute.Float64("lat", lat),
-		attribute.Float64("lon", lon),
-	)
-
-	// Request initialization span start
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	params := url.Values{}
+	const operation = "get-gateways"
 	point := Point{
 		Lat: lat,
 		Lon: lon,
@@ -79,84 +67,39 @@ func (c *Client) GetGatewaysWithContext(ctx context.Context, lat, lon float64, o
 
 	err := point.Validate()
 	if err != nil {
-		reqInitSpan.RecordError(err, trace.WithAttributes(
-			attribute.Float64("lat", point.Lat),
-			attribute.Float64("lon", point.Lon),
-		))
-		reqInitSpan.End()
-		return Area{}, fmt.Errorf("smapp area-gateways: input lat and lon are invalid due to: %s", err.Error())
+		return Area{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "input lat and lon are invalid: %w", err)
 	}
 
 	body, err := json.Marshal(&point)
 	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return Area{}, fmt.Errorf("smapp area-gateways: could not marshal request body due to: %s", err.Error())
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, bytes.NewBuffer(body))
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return Area{}, fmt.Errorf("smapp area-gateways: could not create request. err: %s", err.Error())
+		return Area{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "could not marshal request body: %w", err)
 	}
 
+	headers := make(map[string]string, len(options.Headers)+1)
 	if options.UseLanguage {
-		req.Header.Set(AcceptLanguageHeader, string(options.Language))
+		headers[AcceptLanguageHeader] = string(options.Language)
 	}
-
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return Area{}, fmt.Errorf("smapp area-gateways: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
 	for key, val := range options.Headers {
-		req.Header.Set(key, val)
+		headers[key] = val
 	}
 
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-	// End of request initialization
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var resp Area
+	err = c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Headers:   headers,
+		Timeout:   options.Timeout,
+		Body:      body,
+		Attributes: []attribute.KeyValue{
+			attribute.Float64("lat", lat),
+			attribute.Float64("lon", lon),
+		},
+	}, executor.JSON(&resp))
 	if err != nil {
-		return Area{}, fmt.Errorf("smapp area-gateways: could not make a request due to this error: %s", err.Error())
+		return Area{}, err
 	}
 
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	if response.StatusCode == http.StatusOK {
-		resp := Area{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return Area{}, fmt.Errorf("smapp area-gateways: could not serialize response due to: %s", err.Error())
-		}
-
-		responseSpan.End()
-		return resp, nil
-	}
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.End()
-	return Area{}, fmt.Errorf("smapp area-gateways: non 200 status: %d", response.StatusCode)
+	return resp, nil
 }
 
 // NewAreaGatewaysClient is the constructor of area-gateways client.
@@ -174,6 +117,16 @@ func NewAreaGatewaysClient(cfg *config.Config, version Version, timeout time.Dur
 		opt(client)
 	}
 
+	client.httpClient.Transport = executor.NewTransport(client.httpClient.Transport, serviceName, cfg, &client.transport)
+
+	client.executor = executor.New(serviceName, cfg, &client.httpClient,
+		executor.WithTracerName(client.tracerName),
+		executor.WithMeterName(client.meterName),
+		executor.WithAPIVersion(string(version)),
+		executor.WithInterceptors(client.interceptors...),
+		executor.WithLogger(client.logger),
+	)
+
 	return client, nil
 }
 
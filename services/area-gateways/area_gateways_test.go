@@ -2,7 +2,9 @@ package area_gateways
 
 import (
 	"context"
+	"errors"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -88,6 +90,13 @@ func TestClient_GetGateways(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil.")
 		}
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err should be of type *smapperrors.APIError but it is %T", err)
+		}
+		if apiErr.StatusCode != 500 {
+			t.Fatalf("apiErr.StatusCode should be %d but it is %d", 500, apiErr.StatusCode)
+		}
 	})
 	t.Run("invalid_response", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -129,6 +138,9 @@ func TestClient_GetGateways(t *testing.T) {
 		if err == nil {
 			t.Fatalf("err should not be nil due to invalid input lat and lon")
 		}
+		if !errors.Is(err, smapperrors.ErrInvalidInput) {
+			t.Fatalf("err should be smapperrors.ErrInvalidInput but it is: %s", err.Error())
+		}
 	})
 	t.Run("invalid_apikey_source", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1,5 +1,7 @@
 package area_gateways
 
+import "time"
+
 type Language string
 
 const (
@@ -15,6 +17,9 @@ type CallOptions struct {
 	Language Language
 	// Headers is a map that contains all custom headers to be sent.
 	Headers map[string]string
+	// Timeout overrides the timeout of the client for this call, including its retries. zero keeps the timeout of
+	// the client.
+	Timeout time.Duration
 }
 
 // CallOptionSetter is a function for defining custom call options in a fluent way.
@@ -45,6 +50,14 @@ func WithHeaders(headers map[string]string) CallOptionSetter {
 	}
 }
 
+// WithTimeout will set the timeout of the call, overriding the timeout of the client. the remaining time is the
+// deadline budget of the call, which retries should fit within and is sent to the server.
+func WithTimeout(timeout time.Duration) CallOptionSetter {
+	return func(options *CallOptions) {
+		options.Timeout = timeout
+	}
+}
+
 // NewDefaultCallOptions is the constructor of a default CallOptions
 func NewDefaultCallOptions(opts ...CallOptionSetter) CallOptions {
 	callOptions := CallOptions{
@@ -1,6 +1,9 @@
 package area_gateways
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestWithFarsiLanguage(t *testing.T) {
 	callOptions := CallOptions{
@@ -49,3 +52,11 @@ func TestNewDefaultCallOptions(t *testing.T) {
 		t.Fatalf("Language should be Farsi")
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	callOptions := NewDefaultCallOptions(WithTimeout(300 * time.Millisecond))
+
+	if callOptions.Timeout != 300*time.Millisecond {
+		t.Fatalf("Timeout should be 300ms but it is %s", callOptions.Timeout)
+	}
+}
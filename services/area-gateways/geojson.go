@@ -0,0 +1,87 @@
+package area_gateways
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+// gateProperty is the property marking gate features of areas.
+const gateProperty = "gate"
+
+// MarshalGeoJSON encodes a as a GeoJSON FeatureCollection: a Polygon Feature of the area, with its id and its name and
+// type as properties, followed by a Point Feature for each gate, with its name and type as properties and a `gate`
+// property of true. coordinates are kept as they are, so they round-trip exactly.
+func (a Area) MarshalGeoJSON() ([]byte, error) {
+	polygon, err := geo.NewGeometry(geo.TypePolygon, a.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	var id any
+	if a.ID != "" {
+		id = a.ID
+	}
+
+	features := make([]geo.Feature, 0, 1+len(a.Gates))
+	features = append(features, geo.NewFeature(id, polygon, map[string]any{"name": a.Name, "type": a.Type}))
+	for _, gate := range a.Gates {
+		point, err := geo.NewGeometry(geo.TypePoint, gate.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, geo.NewFeature(nil, point, map[string]any{"name": gate.Name, "type": gate.Type, gateProperty: true}))
+	}
+	return json.Marshal(geo.NewFeatureCollection(features...))
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON FeatureCollection encoded by MarshalGeoJSON into a. a single Polygon Feature is
+// also accepted as an area without gates. It returns an error matching geo.ErrInvalidGeoJSON if data is not a
+// collection of one Polygon Feature and any number of Point Features.
+func (a *Area) UnmarshalGeoJSON(data []byte) error {
+	var features []geo.Feature
+	if fc, err := geo.DecodeFeatureCollection(data); err == nil {
+		features = fc.Features
+	} else if f, featureErr := geo.DecodeFeature(data); featureErr == nil {
+		features = []geo.Feature{f}
+	} else {
+		return err
+	}
+
+	area := Area{}
+	found := false
+	for i, f := range features {
+		switch f.Geometry.Type {
+		case geo.TypePolygon:
+			if found {
+				return fmt.Errorf("%w: feature %d is a second polygon", geo.ErrInvalidGeoJSON, i)
+			}
+			found = true
+			if err := json.Unmarshal(f.Geometry.Coordinates, &area.Coordinates); err != nil {
+				return fmt.Errorf("%w: feature %d: %w", geo.ErrInvalidGeoJSON, i, err)
+			}
+			if id, ok := f.ID.(string); ok {
+				area.ID = id
+			} else if f.ID != nil {
+				area.ID = fmt.Sprint(f.ID)
+			}
+			area.Name, _ = f.Properties["name"].(string)
+			area.Type, _ = f.Properties["type"].(string)
+		case geo.TypePoint:
+			gate := Gate{}
+			if err := json.Unmarshal(f.Geometry.Coordinates, &gate.Coordinates); err != nil {
+				return fmt.Errorf("%w: feature %d: %w", geo.ErrInvalidGeoJSON, i, err)
+			}
+			gate.Name, _ = f.Properties["name"].(string)
+			gate.Type, _ = f.Properties["type"].(string)
+			area.Gates = append(area.Gates, gate)
+		default:
+			return fmt.Errorf("%w: feature %d has unexpected %q geometry", geo.ErrInvalidGeoJSON, i, f.Geometry.Type)
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: no polygon feature is found", geo.ErrInvalidGeoJSON)
+	}
+	*a = area
+	return nil
+}
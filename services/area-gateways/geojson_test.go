@@ -0,0 +1,67 @@
+package area_gateways
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestArea_GeoJSON(t *testing.T) {
+	data, err := airport().MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+
+	fc, err := geo.DecodeFeatureCollection(data)
+	if err != nil {
+		t.Fatalf("area should be encoded as a feature collection: %s", err)
+	}
+	if len(fc.Features) != 4 || fc.Features[0].Geometry.Type != geo.TypePolygon || fc.Features[0].ID != "1" {
+		t.Fatalf("first feature should be the polygon of the area: %s", data)
+	}
+	if fc.Features[1].Geometry.Type != geo.TypePoint || fc.Features[1].Properties["gate"] != true {
+		t.Fatalf("gates should be point features: %s", data)
+	}
+
+	var decoded Area
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, airport()) {
+		t.Fatalf("area should round-trip:\n got %+v\nwant %+v", decoded, airport())
+	}
+}
+
+func TestArea_UnmarshalGeoJSON(t *testing.T) {
+	polygon, _ := geo.NewGeometry(geo.TypePolygon, airport().Coordinates)
+	feature, _ := json.Marshal(geo.NewFeature(2, polygon, map[string]any{"name": "Station"}))
+
+	var area Area
+	if err := area.UnmarshalGeoJSON(feature); err != nil {
+		t.Fatalf("a single polygon feature should be accepted: %s", err)
+	}
+	if area.ID != "2" || area.Name != "Station" || len(area.Coordinates) != 2 || area.Gates != nil {
+		t.Fatalf("unexpected area: %+v", area)
+	}
+
+	line, _ := geo.NewGeometry("LineString", [][]float64{{0, 0}, {1, 1}})
+	point := geo.PointGeometry(geo.LatLng{})
+	tests := map[string]geo.FeatureCollection{
+		"empty":       geo.NewFeatureCollection(),
+		"only_gates":  geo.NewFeatureCollection(geo.NewFeature(nil, point, nil)),
+		"two_areas":   geo.NewFeatureCollection(geo.NewFeature(nil, polygon, nil), geo.NewFeature(nil, polygon, nil)),
+		"line_string": geo.NewFeatureCollection(geo.NewFeature(nil, polygon, nil), geo.NewFeature(nil, line, nil)),
+	}
+	for name, fc := range tests {
+		data, _ := json.Marshal(fc)
+		if err := area.UnmarshalGeoJSON(data); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+			t.Errorf("%s: UnmarshalGeoJSON should return geo.ErrInvalidGeoJSON but it returned %v", name, err)
+		}
+	}
+	if err := area.UnmarshalGeoJSON([]byte(`{"type":"Polygon"}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("bare geometry should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
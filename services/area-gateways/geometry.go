@@ -0,0 +1,106 @@
+package area_gateways
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+// ErrInvalidGeometry is returned for areas and gates whose coordinates can not be converted to geometry.
+var ErrInvalidGeometry = errors.New("smapp area-gateways: invalid geometry")
+
+// Polygon returns the geometry of a. Coordinates of an area are polygon rings of [lon, lat] pairs, as in GeoJSON;
+// the first ring is the boundary of the area and the others are its holes.
+// It returns an error matching ErrInvalidGeometry if a has no rings, or any of them is invalid.
+func (a Area) Polygon() (geo.Polygon, error) {
+	if len(a.Coordinates) == 0 {
+		return geo.Polygon{}, fmt.Errorf("%w: area has no coordinates", ErrInvalidGeometry)
+	}
+
+	rings := make([]geo.Ring, 0, len(a.Coordinates))
+	for i, coordinates := range a.Coordinates {
+		ring := make(geo.Ring, 0, len(coordinates))
+		for j, pair := range coordinates {
+			ll, err := latLng(pair)
+			if err != nil {
+				return geo.Polygon{}, fmt.Errorf("%w: point %d of ring %d: %w", ErrInvalidGeometry, j, i, err)
+			}
+			ring = append(ring, ll)
+		}
+		if err := ring.Validate(); err != nil {
+			return geo.Polygon{}, fmt.Errorf("%w: ring %d: %w", ErrInvalidGeometry, i, err)
+		}
+		rings = append(rings, ring)
+	}
+	return geo.Polygon{Boundary: rings[0], Holes: rings[1:]}, nil
+}
+
+// Contains reports whether ll is inside a. it is false for areas with invalid geometry, like the empty area
+// returned when no area is found.
+func (a Area) Contains(ll geo.LatLng) bool {
+	polygon, err := a.Polygon()
+	return err == nil && polygon.Contains(ll)
+}
+
+// BoundingBox returns the smallest box containing the boundary of a.
+func (a Area) BoundingBox() (geo.BoundingBox, error) {
+	polygon, err := a.Polygon()
+	if err != nil {
+		return geo.BoundingBox{}, err
+	}
+	return polygon.BoundingBox(), nil
+}
+
+// GeohashCover returns the geohashes of the cells of the given precision intersecting a, e.g. to prefetch or bucket
+// requests of the locations inside of it. cells inside of the holes of a are left out ([geo.GeohashCover]).
+// It returns an error matching ErrInvalidGeometry if the geometry of a is invalid.
+func (a Area) GeohashCover(precision int) ([]string, error) {
+	polygon, err := a.Polygon()
+	if err != nil {
+		return nil, err
+	}
+	return geo.GeohashCover(polygon, precision)
+}
+
+// NearestGate returns the gate of a closest to ll and its distance in meters. gates with invalid coordinates are
+// skipped, and ok is false if no gate is left.
+func (a Area) NearestGate(ll geo.LatLng) (gate Gate, distance float64, ok bool) {
+	distance = math.Inf(1)
+	for _, g := range a.Gates {
+		location, err := g.LatLng()
+		if err != nil {
+			continue
+		}
+		if d := geo.Distance(ll, location); d < distance {
+			gate, distance, ok = g, d, true
+		}
+	}
+	if !ok {
+		return Gate{}, 0, false
+	}
+	return gate, distance, true
+}
+
+// LatLng returns the location of g. Coordinates of a gate are a [lon, lat] pair, as in GeoJSON.
+// It returns an error matching ErrInvalidGeometry if the coordinates are invalid.
+func (g Gate) LatLng() (geo.LatLng, error) {
+	ll, err := latLng(g.Coordinates)
+	if err != nil {
+		return geo.LatLng{}, fmt.Errorf("%w: gate %q: %w", ErrInvalidGeometry, g.Name, err)
+	}
+	return ll, nil
+}
+
+// latLng converts a [lon, lat] pair to a coordinate and validates it.
+func latLng(pair []float64) (geo.LatLng, error) {
+	if len(pair) < 2 {
+		return geo.LatLng{}, fmt.Errorf("expected [lon, lat] pair but got %v", pair)
+	}
+	ll := geo.LatLng{Lat: pair[1], Lon: pair[0]}
+	if err := ll.Validate(); err != nil {
+		return geo.LatLng{}, err
+	}
+	return ll, nil
+}
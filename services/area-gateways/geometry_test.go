@@ -0,0 +1,123 @@
+package area_gateways
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+// airport returns an area with a hole and three gates, one of which has invalid coordinates.
+func airport() Area {
+	return Area{
+		ID:   "1",
+		Name: "Airport",
+		Type: "Polygon",
+		Coordinates: [][][]float64{
+			{{51.30, 35.40}, {51.34, 35.40}, {51.34, 35.44}, {51.30, 35.44}, {51.30, 35.40}},
+			{{51.31, 35.41}, {51.32, 35.41}, {51.32, 35.42}, {51.31, 35.42}, {51.31, 35.41}},
+		},
+		Gates: []Gate{
+			{Name: "Terminal 1", Type: "Point", Coordinates: []float64{51.30, 35.42}},
+			{Name: "Broken", Type: "Point", Coordinates: []float64{51.33}},
+			{Name: "Terminal 2", Type: "Point", Coordinates: []float64{51.34, 35.43}},
+		},
+	}
+}
+
+func TestArea_Polygon(t *testing.T) {
+	polygon, err := airport().Polygon()
+	if err != nil {
+		t.Fatalf("Polygon should not return error: %s", err)
+	}
+	if len(polygon.Boundary) != 5 || len(polygon.Holes) != 1 {
+		t.Fatalf("unexpected polygon: %+v", polygon)
+	}
+	if polygon.Boundary[1] != (geo.LatLng{Lat: 35.40, Lon: 51.34}) {
+		t.Fatalf("coordinates should be converted from [lon, lat] but they are %+v", polygon.Boundary[1])
+	}
+
+	tests := map[string]Area{
+		"empty":          {},
+		"short_pair":     {Coordinates: [][][]float64{{{51.30}, {51.34, 35.40}, {51.34, 35.44}}}},
+		"swapped_pair":   {Coordinates: [][][]float64{{{35.40, 51.30}, {35.40, 151.34}, {35.44, 51.34}}}},
+		"too_few_points": {Coordinates: [][][]float64{{{51.30, 35.40}, {51.34, 35.40}}}},
+	}
+	for name, area := range tests {
+		if _, err := area.Polygon(); !errors.Is(err, ErrInvalidGeometry) {
+			t.Errorf("%s: Polygon should return ErrInvalidGeometry but it returned %v", name, err)
+		}
+	}
+}
+
+func TestArea_Contains(t *testing.T) {
+	area := airport()
+	if !area.Contains(geo.LatLng{Lat: 35.43, Lon: 51.33}) {
+		t.Fatal("area should contain points inside of it")
+	}
+	if area.Contains(geo.LatLng{Lat: 35.415, Lon: 51.315}) {
+		t.Fatal("area should not contain points in its hole")
+	}
+	if area.Contains(geo.LatLng{Lat: 35.50, Lon: 51.33}) {
+		t.Fatal("area should not contain points out of it")
+	}
+	if (Area{}).Contains(geo.LatLng{Lat: 35.43, Lon: 51.33}) {
+		t.Fatal("empty area should not contain any point")
+	}
+
+	box, err := area.BoundingBox()
+	if err != nil {
+		t.Fatalf("BoundingBox should not return error: %s", err)
+	}
+	if box.Min != (geo.LatLng{Lat: 35.40, Lon: 51.30}) || box.Max != (geo.LatLng{Lat: 35.44, Lon: 51.34}) {
+		t.Fatalf("unexpected bounding box: %+v", box)
+	}
+}
+
+func TestArea_GeohashCover(t *testing.T) {
+	area := airport()
+	cells, err := area.GeohashCover(7)
+	if err != nil {
+		t.Fatalf("GeohashCover should not return error: %s", err)
+	}
+	inside, _ := geo.EncodeGeohash(geo.LatLng{Lat: 35.43, Lon: 51.33}, 7)
+	hole, _ := geo.EncodeGeohash(geo.LatLng{Lat: 35.415, Lon: 51.315}, 7)
+	outside, _ := geo.EncodeGeohash(geo.LatLng{Lat: 35.50, Lon: 51.33}, 7)
+	covered := map[string]bool{}
+	for _, cell := range cells {
+		covered[cell] = true
+	}
+	if !covered[inside] || covered[hole] || covered[outside] {
+		t.Fatalf("cover should have cells of the area except its hole: %v", cells)
+	}
+
+	if _, err := (Area{}).GeohashCover(7); !errors.Is(err, ErrInvalidGeometry) {
+		t.Fatalf("empty area should return ErrInvalidGeometry but it returned %v", err)
+	}
+}
+
+func TestArea_NearestGate(t *testing.T) {
+	area := airport()
+
+	gate, distance, ok := area.NearestGate(geo.LatLng{Lat: 35.43, Lon: 51.335})
+	if !ok || gate.Name != "Terminal 2" {
+		t.Fatalf("nearest gate should be Terminal 2 but it is %+v", gate)
+	}
+	if want := geo.Distance(geo.LatLng{Lat: 35.43, Lon: 51.335}, geo.LatLng{Lat: 35.43, Lon: 51.34}); distance != want {
+		t.Fatalf("distance should be %v but it is %v", want, distance)
+	}
+
+	if _, _, ok := (Area{Gates: []Gate{{Name: "Broken"}}}).NearestGate(geo.LatLng{}); ok {
+		t.Fatal("area without valid gates should have no nearest gate")
+	}
+}
+
+func TestGate_LatLng(t *testing.T) {
+	ll, err := airport().Gates[0].LatLng()
+	if err != nil || ll != (geo.LatLng{Lat: 35.42, Lon: 51.30}) {
+		t.Fatalf("unexpected location %+v, err: %v", ll, err)
+	}
+	if _, err := airport().Gates[1].LatLng(); !errors.Is(err, ErrInvalidGeometry) {
+		t.Fatalf("gate with short coordinates should return ErrInvalidGeometry but it returned %v", err)
+	}
+}
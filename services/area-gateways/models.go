@@ -1,6 +1,10 @@
 package area_gateways
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
 
 // Area is the response type of are-gateways service in golang
 type Area struct {
@@ -33,3 +37,13 @@ func (p Point) Validate() error {
 	}
 	return nil
 }
+
+// PointFromLatLng creates a Point of ll.
+func PointFromLatLng(ll geo.LatLng) Point {
+	return Point{Lat: ll.Lat, Lon: ll.Lon}
+}
+
+// LatLng returns the coordinate of p.
+func (p Point) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: p.Lat, Lon: p.Lon}
+}
@@ -1,6 +1,10 @@
 package area_gateways
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
 
 func TestPoint_Validate(t *testing.T) {
 	t.Run("invalid_lat", func(t *testing.T) {
@@ -34,3 +38,14 @@ func TestPoint_Validate(t *testing.T) {
 		}
 	})
 }
+
+func TestPoint_LatLng(t *testing.T) {
+	ll := geo.LatLng{Lat: 35.7, Lon: 51.4}
+	p := PointFromLatLng(ll)
+	if p.Lat != 35.7 || p.Lon != 51.4 {
+		t.Fatalf("point should have the coordinate of ll but it is %+v", p)
+	}
+	if p.LatLng() != ll {
+		t.Fatalf("LatLng should return the coordinate of the point but it returned %+v", p.LatLng())
+	}
+}
@@ -1,5 +1,7 @@
 package eta
 
+import "time"
+
 // EtaEngine type is for defining different engines
 // that can be used in calculating the eta.
 type EtaEngine int
@@ -72,10 +74,16 @@ type CallOptions struct {
 	DepartureDateTime string
 	// Headers is a map that contains all custom headers to be sent.
 	Headers map[string]string
+	// Timeout overrides the timeout of the client for this call, including its retries. zero keeps the timeout of
+	// the client.
+	Timeout time.Duration
 	// Engine is the value of `engine` query param.
 	Engine EtaEngine
 	// Engine is the value of `engine` in query param as string.
 	EngineStr string
+
+	// engineSet is true if Engine is set using WithEngine, so the default engine of the client is not used.
+	engineSet bool
 }
 
 // CallOptionSetter is a function for defining custom call options in a fluent way.
@@ -90,6 +98,14 @@ func WithHeaders(headers map[string]string) CallOptionSetter {
 	}
 }
 
+// WithTimeout will set the timeout of the call, overriding the timeout of the client. the remaining time is the
+// deadline budget of the call, which retries should fit within and is sent to the server.
+func WithTimeout(timeout time.Duration) CallOptionSetter {
+	return func(options *CallOptions) {
+		options.Timeout = timeout
+	}
+}
+
 // WithNoTraffic will set `no_traffic` query param ro true. with this option eta requests does not involve traffic data in response.
 func WithNoTraffic() CallOptionSetter {
 	return func(options *CallOptions) {
@@ -118,6 +134,7 @@ func WithDepartureDateTime(dateTime string) CallOptionSetter {
 func WithEngine(engine EtaEngine) CallOptionSetter {
 	return func(options *CallOptions) {
 		options.Engine = engine
+		options.engineSet = true
 	}
 }
 
@@ -1,6 +1,9 @@
 package eta
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestMartixEngineString(t *testing.T) {
 	t.Run("test engine v1", func(t *testing.T) {
@@ -115,3 +118,11 @@ func TestNewDefaultCallOptions(t *testing.T) {
 		t.Fatalf("Language should be Farsi")
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	callOptions := NewDefaultCallOptions(WithTimeout(300 * time.Millisecond))
+
+	if callOptions.Timeout != 300*time.Millisecond {
+		t.Fatalf("Timeout should be 300ms but it is %s", callOptions.Timeout)
+	}
+}
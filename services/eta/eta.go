@@ -4,19 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/trace"
-
 	"github.com/snapp-incubator/smapp-sdk-go/config"
-	"github.com/snapp-incubator/smapp-sdk-go/version"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 // Interface consists of functions of different functionalities of ETA service. there are two implementation of this service.
@@ -40,14 +38,22 @@ const (
 	NoTrafficQueryParameter = "no_traffic"
 	JSONInputQueryParam     = "json"
 	EngineQueryParameter    = "engine"
+
+	serviceName = "eta"
 )
 
 // Client is the main implementation of Interface for area-gateways service
 type Client struct {
-	cfg        *config.Config
-	url        string
-	httpClient http.Client
-	tracerName string
+	cfg           *config.Config
+	url           string
+	httpClient    http.Client
+	tracerName    string
+	meterName     string
+	transport     executor.TransportOptions
+	defaultEngine string
+	interceptors  []interceptor.Interceptor
+	logger        *slog.Logger
+	executor      *executor.Executor
 }
 
 // Force Client to implement Interface at compile time
@@ -66,26 +72,12 @@ func (c *Client) GetETAWithContext(ctx context.Context, points []Point, options
 
 // GetETAWithInputMeta is like GetETAWithContext, but with request-level metadata support
 func (c *Client) GetETAWithInputMeta(ctx context.Context, points []Point, options CallOptions, metadata map[string]string) (ETA, error) {
-	if ctx == nil {
-		return ETA{}, fmt.Errorf("smapp eta: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	spanName := "get-eta"
+	operation := "get-eta"
 	if len(metadata) > 0 {
-		spanName = "get-eta-with-input-meta"
+		operation = "get-eta-with-input-meta"
 	}
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, spanName)
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return ETA{}, fmt.Errorf("smapp eta: could not create request. err: %s", err.Error())
+	if len(points) < 2 {
+		return ETA{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "at least 2 points are required but %d is given", len(points))
 	}
 
 	params := url.Values{}
@@ -102,68 +94,42 @@ func (c *Client) GetETAWithInputMeta(ctx context.Context, points []Point, option
 		data.DepartureDateTime = options.DepartureDateTime
 	}
 
-	if options.EngineStr != "" {
-		params.Set(EngineQueryParameter, options.EngineStr)
-	} else {
-		params.Set(EngineQueryParameter, options.Engine.String())
+	engine := options.EngineStr
+	if engine == "" {
+		engine = c.engine(options)
 	}
+	params.Set(EngineQueryParameter, engine)
 
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return ETA{}, fmt.Errorf("smapp eta: could not marshal input data")
+		return ETA{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "could not marshal input data: %w", err)
 	}
 
 	params.Set(JSONInputQueryParam, string(jsonData))
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return ETA{}, fmt.Errorf("smapp eta: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var result ETA
+	err = c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+		Engine:    engine,
+	}, executor.JSON(&result))
 	if err != nil {
-		return ETA{}, fmt.Errorf("smapp eta: could not make a request due to this error: %s", err.Error())
+		return ETA{}, err
 	}
 
-	var responseSpan trace.Span
-	_, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		var result ETA
-		err := json.NewDecoder(response.Body).Decode(&result)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return ETA{}, fmt.Errorf("smapp eta: could not serialize response due to: %s", err.Error())
-		}
-		responseSpan.End()
-		return result, nil
+	return result, nil
+}
+
+// engine returns the engine of a call whose options have no EngineStr. the default engine of the client is used,
+// unless the options set an engine using WithEngine or a non zero Engine.
+func (c *Client) engine(options CallOptions) string {
+	if c.defaultEngine != "" && !options.engineSet && options.Engine == EtaEngineV1 {
+		return c.defaultEngine
 	}
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.End()
-	return ETA{}, fmt.Errorf("smapp eta: non 200 status: %d", response.StatusCode)
+	return options.Engine.String()
 }
 
 // NewETAClient is the constructor of ETA client.
@@ -180,10 +146,20 @@ func NewETAClient(cfg *config.Config, version Version, timeout time.Duration, op
 		opt(client)
 	}
 
+	client.httpClient.Transport = executor.NewTransport(client.httpClient.Transport, serviceName, cfg, &client.transport)
+
 	if client.url == "" {
 		client.url = getETADefaultURL(cfg, version)
 	}
 
+	client.executor = executor.New(serviceName, cfg, &client.httpClient,
+		executor.WithTracerName(client.tracerName),
+		executor.WithMeterName(client.meterName),
+		executor.WithAPIVersion(string(version)),
+		executor.WithInterceptors(client.interceptors...),
+		executor.WithLogger(client.logger),
+	)
+
 	return client, nil
 }
 
@@ -2,12 +2,18 @@ package eta
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/snapp-incubator/smapp-sdk-go/breaker"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/hedge"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 func TestNewETAClient(t *testing.T) {
@@ -74,6 +80,13 @@ func TestClient_GetETA(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil.")
 		}
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err should be of type *smapperrors.APIError but it is %T", err)
+		}
+		if apiErr.StatusCode != 500 {
+			t.Fatalf("apiErr.StatusCode should be %d but it is %d", 500, apiErr.StatusCode)
+		}
 	})
 
 	t.Run("invalid_response", func(t *testing.T) {
@@ -106,6 +119,9 @@ func TestClient_GetETA(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil because response is invalid")
 		}
+		if !errors.Is(err, smapperrors.ErrDecode) {
+			t.Fatalf("err should be smapperrors.ErrDecode but it is: %s", err.Error())
+		}
 	})
 
 	t.Run("invalid_input_points", func(t *testing.T) {
@@ -134,6 +150,9 @@ func TestClient_GetETA(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil because input points are invalid")
 		}
+		if !errors.Is(err, smapperrors.ErrInvalidInput) {
+			t.Fatalf("err should be smapperrors.ErrInvalidInput but it is: %s", err.Error())
+		}
 	})
 
 	t.Run("invalid_apikey_source", func(t *testing.T) {
@@ -168,6 +187,9 @@ func TestClient_GetETA(t *testing.T) {
 		if err == nil {
 			t.Fatalf("there should be an error with api key source")
 		}
+		if !errors.Is(err, smapperrors.ErrInvalidAPIKeySource) {
+			t.Fatalf("err should be smapperrors.ErrInvalidAPIKeySource but it is: %s", err.Error())
+		}
 	})
 
 	t.Run("timeout", func(t *testing.T) {
@@ -271,5 +293,127 @@ func TestClient_GetETAWithContext(t *testing.T) {
 		if err == nil {
 			t.Fatalf("there should be an error when creating request")
 		}
+		if !errors.Is(err, smapperrors.ErrNilContext) {
+			t.Fatalf("err should be smapperrors.ErrNilContext but it is: %s", err.Error())
+		}
 	})
 }
+
+func TestClient_GetETA_CircuitBreaker(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	b := breaker.New(breaker.Settings{Name: "eta", MinRequests: 2, CoolDown: time.Minute})
+	client, err := NewETAClient(cfg, V1, time.Second, WithURL(sv.URL), WithCircuitBreaker(b))
+	if err != nil {
+		t.Fatalf("could not create eta client due to: %s", err.Error())
+	}
+
+	points := []Point{
+		{Lat: 35.70973799747619, Lon: 51.40869855880737},
+		{Lat: 35.70973799747619, Lon: 51.40969855880737},
+	}
+	for i := 0; i < 2; i++ {
+		_, err = client.GetETA(points, NewDefaultCallOptions())
+		if smapperrors.StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("status code should be 503 but it is: %d", smapperrors.StatusCode(err))
+		}
+	}
+	if b.State() != breaker.StateOpen {
+		t.Fatalf("breaker state should be open but it is %s", b.State())
+	}
+
+	_, err = client.GetETA(points, NewDefaultCallOptions())
+	if !errors.Is(err, smapperrors.ErrCircuitOpen) {
+		t.Fatalf("err should be smapperrors.ErrCircuitOpen but it is: %v", err)
+	}
+	if smapperrors.IsRetryable(err) {
+		t.Fatalf("circuit open error should not be retryable")
+	}
+	if calls != 2 {
+		t.Fatalf("server should be called 2 times but it is called %d times", calls)
+	}
+}
+
+func TestClient_GetETA_Hedging(t *testing.T) {
+	var calls int32
+	cancelled := make(chan struct{}, 1)
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// the first request is stuck until the hedge wins and it is cancelled.
+			<-r.Context().Done()
+			cancelled <- struct{}{}
+			return
+		}
+		_, _ = w.Write([]byte(`{"trip": {"legs": [{"time": 10, "length": 100}]}}`))
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	client, err := NewETAClient(cfg, V1, time.Second, WithURL(sv.URL),
+		WithHedging(hedge.Policy{Delay: 20 * time.Millisecond, MaxHedgeRatio: 1}))
+	if err != nil {
+		t.Fatalf("could not create eta client due to: %s", err.Error())
+	}
+
+	eta, err := client.GetETA([]Point{
+		{Lat: 35.70973799747619, Lon: 51.40869855880737},
+		{Lat: 35.70973799747619, Lon: 51.40969855880737},
+	}, NewDefaultCallOptions())
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if len(eta.Trip.Legs) != 1 || eta.Trip.Legs[0].Time != 10 {
+		t.Fatalf("response of the hedge should be returned but it is %+v", eta)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("the slow request should be cancelled")
+	}
+}
+
+func TestClient_GetETA_Failover(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trip": {"legs": [{"time": 10, "length": 100}]}}`))
+	}))
+	defer secondary.Close()
+
+	// the hosts of test servers are used as region names.
+	cfg, err := config.NewDefaultConfig("key", config.WithRegions("http://{REGION}",
+		strings.TrimPrefix(primary.URL, "http://"), strings.TrimPrefix(secondary.URL, "http://")))
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	client, err := NewETAClient(cfg, V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create eta client due to: %s", err.Error())
+	}
+
+	eta, err := client.GetETA([]Point{
+		{Lat: 35.70973799747619, Lon: 51.40869855880737},
+		{Lat: 35.70973799747619, Lon: 51.40969855880737},
+	}, NewDefaultCallOptions())
+	if err != nil {
+		t.Fatalf("should not return error: %s", err.Error())
+	}
+	if len(eta.Trip.Legs) != 1 || eta.Trip.Legs[0].Time != 10 {
+		t.Fatalf("response of the secondary region should be returned but it is %+v", eta)
+	}
+}
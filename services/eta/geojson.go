@@ -0,0 +1,65 @@
+package eta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+// indexProperty is the property holding the index of a point in the features of a request.
+const indexProperty = "index"
+
+// MarshalGeoJSON encodes r as a GeoJSON FeatureCollection of a Point Feature for each location, in order. the
+// properties of each feature are its index and its metadata as `m`, and the departure date time and metadata of the
+// request are kept in the `properties` member of the collection.
+func (r ETARequest) MarshalGeoJSON() ([]byte, error) {
+	features := make([]geo.Feature, 0, len(r.Locations))
+	for i, p := range r.Locations {
+		properties, err := geo.Properties(p, "lat", "lon")
+		if err != nil {
+			return nil, err
+		}
+		properties[indexProperty] = i
+		features = append(features, geo.NewFeature(nil, geo.PointGeometry(p.LatLng()), properties))
+	}
+
+	fc := geo.NewFeatureCollection(features...)
+	properties, err := geo.Properties(r, "locations")
+	if err != nil {
+		return nil, err
+	}
+	if len(properties) > 0 {
+		fc.Properties = properties
+	}
+	return json.Marshal(fc)
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON FeatureCollection of Point Features encoded by MarshalGeoJSON into r. locations
+// are in the order of features.
+func (r *ETARequest) UnmarshalGeoJSON(data []byte) error {
+	fc, err := geo.DecodeFeatureCollection(data)
+	if err != nil {
+		return err
+	}
+
+	request := ETARequest{}
+	if err := geo.DecodeProperties(fc.Properties, &request); err != nil {
+		return err
+	}
+	request.Locations = make([]Point, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		ll, err := f.Geometry.Point()
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		p := PointFromLatLng(ll)
+		if err := geo.DecodeProperties(f.Properties, &p); err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		p.Lat, p.Lon = ll.Lat, ll.Lon
+		request.Locations = append(request.Locations, p)
+	}
+	*r = request
+	return nil
+}
@@ -0,0 +1,48 @@
+package eta
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestETARequest_GeoJSON(t *testing.T) {
+	r := ETARequest{
+		Locations: []Point{
+			{Lat: 35.7, Lon: 51.4, Metadata: map[string]string{"name": "origin"}},
+			{Lat: 35.75, Lon: 51.45},
+		},
+		DepartureDateTime: "2024-01-01T08:00:00Z",
+		Metadata:          map[string]string{"ride": "1"},
+	}
+
+	data, err := r.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	fc, err := geo.DecodeFeatureCollection(data)
+	if err != nil {
+		t.Fatalf("request should be encoded as a feature collection: %s", err)
+	}
+	if len(fc.Features) != 2 || fc.Features[1].Properties["index"] != json.Number("1") {
+		t.Fatalf("unexpected collection: %s", data)
+	}
+	if fc.Properties["departure_date_time"] != r.DepartureDateTime {
+		t.Fatalf("request fields should be collection properties: %s", data)
+	}
+
+	var decoded ETARequest
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Fatalf("request should round-trip:\n got %+v\nwant %+v", decoded, r)
+	}
+
+	if err := decoded.UnmarshalGeoJSON([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[]},"properties":{}}]}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("non-point feature should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
@@ -1,5 +1,7 @@
 package eta
 
+import "github.com/snapp-incubator/smapp-sdk-go/geo"
+
 // ETA is the response type of eta service
 type ETA struct {
 	Trip struct {
@@ -23,3 +25,32 @@ type ETARequest struct {
 	DepartureDateTime string            `json:"departure_date_time,omitempty"`
 	Metadata          map[string]string `json:"m,omitempty"`
 }
+
+// PointFromLatLng creates a Point of ll.
+func PointFromLatLng(ll geo.LatLng) Point {
+	return Point{Lat: ll.Lat, Lon: ll.Lon}
+}
+
+// PointsFromLatLngs converts a list of coordinates, e.g. a route, to a list of Point s.
+func PointsFromLatLngs(lls []geo.LatLng) []Point {
+	points := make([]Point, 0, len(lls))
+	for _, ll := range lls {
+		points = append(points, PointFromLatLng(ll))
+	}
+	return points
+}
+
+// PointsFromPolyline decodes an encoded polyline, e.g. a route of trip history, to a list of Point s. precision is
+// the number of decimal digits the polyline is encoded with, geo.PolylinePrecision for Google polylines.
+func PointsFromPolyline(polyline string, precision int) ([]Point, error) {
+	lls, err := geo.DecodePolyline(polyline, precision)
+	if err != nil {
+		return nil, err
+	}
+	return PointsFromLatLngs(lls), nil
+}
+
+// LatLng returns the coordinate of p.
+func (p Point) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: p.Lat, Lon: p.Lon}
+}
@@ -0,0 +1,41 @@
+package eta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestPoint_LatLng(t *testing.T) {
+	ll := geo.LatLng{Lat: 35.7, Lon: 51.4}
+	p := PointFromLatLng(ll)
+	if p.Lat != 35.7 || p.Lon != 51.4 {
+		t.Fatalf("point should have the coordinate of ll but it is %+v", p)
+	}
+	if p.LatLng() != ll {
+		t.Fatalf("LatLng should return the coordinate of the point but it returned %+v", p.LatLng())
+	}
+
+	points := PointsFromLatLngs([]geo.LatLng{ll, {Lat: 35.8, Lon: 51.5}})
+	if len(points) != 2 || points[1].Lat != 35.8 || points[1].Lon != 51.5 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+}
+
+func TestPointsFromPolyline(t *testing.T) {
+	polyline, err := geo.EncodePolyline([]geo.LatLng{{Lat: 35.699739, Lon: 51.338097}, {Lat: 35.7, Lon: 51.4}}, geo.PolylinePrecision6)
+	if err != nil {
+		t.Fatalf("EncodePolyline should not return error: %s", err)
+	}
+	points, err := PointsFromPolyline(polyline, geo.PolylinePrecision6)
+	if err != nil {
+		t.Fatalf("PointsFromPolyline should not return error: %s", err)
+	}
+	if len(points) != 2 || points[0].Lat != 35.699739 || points[0].Lon != 51.338097 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+	if _, err := PointsFromPolyline("?", geo.PolylinePrecision); !errors.Is(err, geo.ErrInvalidPolyline) {
+		t.Fatalf("invalid polyline should return geo.ErrInvalidPolyline but it returned %v", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/hedge"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
 )
 
 func TestWithURL(t *testing.T) {
@@ -63,3 +65,52 @@ func TestWithRequestOpenTelemetryTracing(t *testing.T) {
 		t.Fatalf("client.tracerName should be %s but it is %s", "test", client.tracerName)
 	}
 }
+
+func TestWithHedging(t *testing.T) {
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	policy := hedge.Policy{Delay: 50 * time.Millisecond, MaxHedgeRatio: 0.05}
+	client, err := NewETAClient(cfg, V1, time.Second, WithHedging(policy), WithRetryPolicy(retry.DefaultPolicy()))
+	if err != nil {
+		t.Fatalf("could not create client due to: %s", err.Error())
+	}
+
+	transport, ok := client.httpClient.Transport.(*retry.Transport)
+	if !ok {
+		t.Fatal("client.httpClient.Transport should be of type *retry.Transport")
+	}
+	hedgeTransport, ok := transport.Base.(*hedge.Transport)
+	if !ok {
+		t.Fatal("retry transport should wrap the hedge transport")
+	}
+	if hedgeTransport.Policy() != policy {
+		t.Fatalf("hedge policy should be %+v but it is %+v", policy, hedgeTransport.Policy())
+	}
+}
+
+func TestWithDefaultEngine(t *testing.T) {
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	client, err := NewETAClient(cfg, V1, time.Second, WithDefaultEngine("ocelot"))
+	if err != nil {
+		t.Fatalf("could not create client due to: %s", err.Error())
+	}
+
+	cases := map[string]struct {
+		options  CallOptions
+		expected string
+	}{
+		"no_engine":       {NewDefaultCallOptions(), "ocelot"},
+		"with_engine":     {NewDefaultCallOptions(WithEngine(EtaEngineV1)), "v1"},
+		"non_zero_engine": {CallOptions{Engine: EtaEngineNostradamus}, EtaEngineNostradamus.String()},
+	}
+	for name, c := range cases {
+		if engine := client.engine(c.options); engine != c.expected {
+			t.Fatalf("%s: engine should be %s but it is %s", name, c.expected, engine)
+		}
+	}
+}
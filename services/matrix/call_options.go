@@ -1,5 +1,7 @@
 package matrix
 
+import "time"
+
 // MatrixEngine type is for defining different engines
 // that can be used in calculating the matrix eta.
 type MatrixEngine int
@@ -75,8 +77,14 @@ type CallOptions struct {
 	Engine MatrixEngine
 	// Engine is the value of `engine` in query param as string.
 	EngineStr string
+
+	// engineSet is true if Engine is set using WithEngine, so the default engine of the client is not used.
+	engineSet bool
 	// Headers is a map that contains all custom headers to be sent.
 	Headers map[string]string
+	// Timeout overrides the timeout of the client for this call, including its retries. zero keeps the timeout of
+	// the client.
+	Timeout time.Duration
 	// UsePost to use post http call for bigger matrix calls
 	UsePost bool
 }
@@ -93,6 +101,14 @@ func WithHeaders(headers map[string]string) CallOptionSetter {
 	}
 }
 
+// WithTimeout will set the timeout of the call, overriding the timeout of the client. the remaining time is the
+// deadline budget of the call, which retries should fit within and is sent to the server.
+func WithTimeout(timeout time.Duration) CallOptionSetter {
+	return func(options *CallOptions) {
+		options.Timeout = timeout
+	}
+}
+
 // WithUsePost will use post http call for bigger matrix calls
 func WithUsePost() CallOptionSetter {
 	return func(options *CallOptions) {
@@ -120,6 +136,7 @@ func WithTraffic() CallOptionSetter {
 func WithEngine(engine MatrixEngine) CallOptionSetter {
 	return func(options *CallOptions) {
 		options.Engine = engine
+		options.engineSet = true
 	}
 }
 
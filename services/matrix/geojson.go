@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+const (
+	// roleProperty is the property telling sources and targets apart in the features of an Input.
+	roleProperty = "role"
+	// indexProperty is the property holding the index of a point in its sources or targets.
+	indexProperty = "index"
+
+	roleSource = "source"
+	roleTarget = "target"
+)
+
+// MarshalGeoJSON encodes in as a GeoJSON FeatureCollection of a Point Feature for each source, followed by one for
+// each target. the properties of each feature are its `role`, `source` or `target`, its index and its metadata as
+// `m`, and the metadata of the input is kept in the `properties` member of the collection.
+func (in Input) MarshalGeoJSON() ([]byte, error) {
+	features := make([]geo.Feature, 0, len(in.Sources)+len(in.Targets))
+	for _, group := range []struct {
+		role   string
+		points []Point
+	}{{role: roleSource, points: in.Sources}, {role: roleTarget, points: in.Targets}} {
+		for i, p := range group.points {
+			properties, err := geo.Properties(p, "lat", "lon")
+			if err != nil {
+				return nil, err
+			}
+			properties[roleProperty] = group.role
+			properties[indexProperty] = i
+			features = append(features, geo.NewFeature(nil, geo.PointGeometry(p.LatLng()), properties))
+		}
+	}
+
+	fc := geo.NewFeatureCollection(features...)
+	properties, err := geo.Properties(in, "sources", "targets")
+	if err != nil {
+		return nil, err
+	}
+	if len(properties) > 0 {
+		fc.Properties = properties
+	}
+	return json.Marshal(fc)
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON FeatureCollection of Point Features encoded by MarshalGeoJSON into in. sources
+// and targets are in the order of features, and features without a valid role return an error matching
+// geo.ErrInvalidGeoJSON.
+func (in *Input) UnmarshalGeoJSON(data []byte) error {
+	fc, err := geo.DecodeFeatureCollection(data)
+	if err != nil {
+		return err
+	}
+
+	input := Input{}
+	if err := geo.DecodeProperties(fc.Properties, &input); err != nil {
+		return err
+	}
+	input.Sources, input.Targets = []Point{}, []Point{}
+	for i, f := range fc.Features {
+		ll, err := f.Geometry.Point()
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		p := PointFromLatLng(ll)
+		if err := geo.DecodeProperties(f.Properties, &p); err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		p.Lat, p.Lon = ll.Lat, ll.Lon
+
+		switch f.Properties[roleProperty] {
+		case roleSource:
+			input.Sources = append(input.Sources, p)
+		case roleTarget:
+			input.Targets = append(input.Targets, p)
+		default:
+			return fmt.Errorf("%w: feature %d should have a %q property of %q or %q", geo.ErrInvalidGeoJSON, i, roleProperty, roleSource, roleTarget)
+		}
+	}
+	*in = input
+	return nil
+}
@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestInput_GeoJSON(t *testing.T) {
+	in := Input{
+		Sources:  []Point{{Lat: 35.7, Lon: 51.4, Metadata: map[string]string{"driver": "1"}}},
+		Targets:  []Point{{Lat: 35.75, Lon: 51.45}, {Lat: 35.8, Lon: 51.5}},
+		Metadata: map[string]string{"ride": "1"},
+	}
+
+	data, err := in.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	fc, err := geo.DecodeFeatureCollection(data)
+	if err != nil {
+		t.Fatalf("input should be encoded as a feature collection: %s", err)
+	}
+	if len(fc.Features) != 3 || fc.Features[0].Properties["role"] != "source" || fc.Features[2].Properties["role"] != "target" {
+		t.Fatalf("unexpected collection: %s", data)
+	}
+
+	var decoded Input
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, in) {
+		t.Fatalf("input should round-trip:\n got %+v\nwant %+v", decoded, in)
+	}
+
+	if err := decoded.UnmarshalGeoJSON([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[51.4,35.7]},"properties":{}}]}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("point without role should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
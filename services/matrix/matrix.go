@@ -1,23 +1,20 @@
 package matrix
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/trace"
-
 	"github.com/snapp-incubator/smapp-sdk-go/config"
-	"github.com/snapp-incubator/smapp-sdk-go/version"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 // Interface consists of functions of different functionalities of Matrix service. there are two implementation of this service.
@@ -41,14 +38,22 @@ const (
 	NoTrafficQueryParameter = "no_traffic"
 	EngineQueryParameter    = "engine"
 	JSONInputQueryParam     = "json"
+
+	serviceName = "matrix"
 )
 
 // Client is the main implementation of Interface for area-gateways service
 type Client struct {
-	cfg        *config.Config
-	url        string
-	httpClient http.Client
-	tracerName string
+	cfg           *config.Config
+	url           string
+	httpClient    http.Client
+	tracerName    string
+	meterName     string
+	transport     executor.TransportOptions
+	defaultEngine string
+	interceptors  []interceptor.Interceptor
+	logger        *slog.Logger
+	executor      *executor.Executor
 }
 
 // Force Client to implement Interface at compile time
@@ -67,23 +72,12 @@ func (c *Client) GetMatrixWithContext(ctx context.Context, sources []Point, targ
 
 // GetMatrixWithInputMeta is like GetMatrixWithContext, but with request-level metadata support
 func (c *Client) GetMatrixWithInputMeta(ctx context.Context, sources []Point, targets []Point, options CallOptions, metadata map[string]string) (Output, error) {
-	if ctx == nil {
-		return Output{}, fmt.Errorf("smapp matrix: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	spanName := "get-matrix"
+	operation := "get-matrix"
 	if len(metadata) > 0 {
-		spanName = "get-matrix-with-input-meta"
+		operation = "get-matrix-with-input-meta"
 	}
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, spanName)
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
 	if len(sources) == 0 || len(targets) == 0 {
-		return Output{}, fmt.Errorf("smapp matrix: both sources and targets should not be empty")
+		return Output{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "both sources and targets should not be empty")
 	}
 
 	params := url.Values{}
@@ -91,115 +85,62 @@ func (c *Client) GetMatrixWithInputMeta(ctx context.Context, sources []Point, ta
 		params.Set(NoTrafficQueryParameter, strconv.FormatBool(options.NoTraffic))
 	}
 
-	if options.EngineStr != "" {
-		params.Set(EngineQueryParameter, options.EngineStr)
-	} else {
-		params.Set(EngineQueryParameter, options.Engine.String())
+	engine := options.EngineStr
+	if engine == "" {
+		engine = c.engine(options)
 	}
+	params.Set(EngineQueryParameter, engine)
 
 	input := Input{Sources: sources, Targets: targets}
 	if len(metadata) > 0 {
 		input.Metadata = metadata
 	}
-	var (
-		req *http.Request
-		err error
-	)
+
+	request := executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+		Engine:    engine,
+	}
 
 	if options.UsePost {
-		postInput := PostInput{Json: input}
 		// ---------- HTTP POST ----------
-		body, err := json.Marshal(postInput)
-		if err != nil {
-			reqInitSpan.RecordError(err)
-			reqInitSpan.End()
-			return Output{}, fmt.Errorf("smapp matrix: could not marshal input data: %w", err)
-		}
-
-		req, err = http.NewRequestWithContext(
-			ctx,
-			http.MethodPost,
-			c.url,
-			bytes.NewReader(body),
-		)
+		body, err := json.Marshal(PostInput{Json: input})
 		if err != nil {
-			reqInitSpan.RecordError(err)
-			reqInitSpan.End()
-			return Output{}, fmt.Errorf("smapp matrix: could not create POST request: %w", err)
+			return Output{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "could not marshal input data: %w", err)
 		}
-		req.Header.Set("Content-Type", "application/json")
-
+		request.Method = http.MethodPost
+		request.Body = body
+		// matrix calculation has no side effect, so POST requests are safe to be retried.
+		request.Idempotent = true
 	} else {
 		// ---------- HTTP GET (legacy) ----------
 		jsonData, err := json.Marshal(input)
 		if err != nil {
-			reqInitSpan.RecordError(err)
-			reqInitSpan.End()
-			return Output{}, fmt.Errorf("smapp matrix: could not marshal input data: %w", err)
+			return Output{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput, "could not marshal input data: %w", err)
 		}
 		params.Set(JSONInputQueryParam, string(jsonData))
-
-		req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
-		if err != nil {
-			reqInitSpan.RecordError(err)
-			reqInitSpan.End()
-			return Output{}, fmt.Errorf("smapp matrix: could not create GET request: %w", err)
-		}
-	}
-
-	// ---- API-key handling ----
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return Output{}, fmt.Errorf("smapp matrix: invalid api key source: %s", c.cfg.APIKeySource)
-	}
-
-	// apply query string (for both GET and POST paths)
-	req.URL.RawQuery = params.Encode()
-
-	// extra headers
-	for k, v := range options.Headers {
-		req.Header.Set(k, v)
-	}
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	reqInitSpan.End()
-
-	// ---- perform request ----
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return Output{}, fmt.Errorf("smapp matrix: request failed: %w", err)
-	}
-
-	var respSpan trace.Span
-	_, respSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-	defer func() {
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		respSpan.SetStatus(codes.Error, "non 200 status code")
-		respSpan.End()
-		return Output{}, fmt.Errorf("smapp matrix: non 200 status: %d", resp.StatusCode)
 	}
 
 	var out Output
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		respSpan.RecordError(err)
-		respSpan.End()
-		return Output{}, fmt.Errorf("smapp matrix: could not decode response: %w", err)
+	if err := c.executor.Do(ctx, request, executor.JSON(&out)); err != nil {
+		return Output{}, err
 	}
 
-	respSpan.End()
 	return out, nil
 }
 
+// engine returns the engine of a call whose options have no EngineStr. the default engine of the client is used,
+// unless the options set an engine using WithEngine or a non zero Engine.
+func (c *Client) engine(options CallOptions) string {
+	if c.defaultEngine != "" && !options.engineSet && options.Engine == MatrixEngineV1 {
+		return c.defaultEngine
+	}
+	return options.Engine.String()
+}
+
 // NewMatrixClient is the constructor of Matrix client.
 func NewMatrixClient(cfg *config.Config, version Version, timeout time.Duration, opts ...ConstructorOption) (*Client, error) {
 	client := &Client{
@@ -214,10 +155,20 @@ func NewMatrixClient(cfg *config.Config, version Version, timeout time.Duration,
 		opt(client)
 	}
 
+	client.httpClient.Transport = executor.NewTransport(client.httpClient.Transport, serviceName, cfg, &client.transport)
+
 	if client.url == "" {
 		client.url = getMatrixDefaultURL(cfg, version)
 	}
 
+	client.executor = executor.New(serviceName, cfg, &client.httpClient,
+		executor.WithTracerName(client.tracerName),
+		executor.WithMeterName(client.meterName),
+		executor.WithAPIVersion(string(version)),
+		executor.WithInterceptors(client.interceptors...),
+		executor.WithLogger(client.logger),
+	)
+
 	return client, nil
 }
 
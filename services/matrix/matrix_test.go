@@ -2,12 +2,16 @@ package matrix
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 func TestNewMatrixClient(t *testing.T) {
@@ -84,6 +88,13 @@ func TestClient_GetMatrix(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil.")
 		}
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err should be of type *smapperrors.APIError but it is %T", err)
+		}
+		if apiErr.StatusCode != 500 {
+			t.Fatalf("apiErr.StatusCode should be %d but it is %d", 500, apiErr.StatusCode)
+		}
 	})
 
 	t.Run("invalid_response", func(t *testing.T) {
@@ -126,6 +137,9 @@ func TestClient_GetMatrix(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil because response is invalid")
 		}
+		if !errors.Is(err, smapperrors.ErrDecode) {
+			t.Fatalf("err should be smapperrors.ErrDecode but it is: %s", err.Error())
+		}
 	})
 
 	t.Run("invalid_input", func(t *testing.T) {
@@ -149,6 +163,9 @@ func TestClient_GetMatrix(t *testing.T) {
 		if err == nil {
 			t.Fatalf("should not be nil because input points are invalid")
 		}
+		if !errors.Is(err, smapperrors.ErrInvalidInput) {
+			t.Fatalf("err should be smapperrors.ErrInvalidInput but it is: %s", err.Error())
+		}
 	})
 
 	t.Run("invalid_apikey_source", func(t *testing.T) {
@@ -327,3 +344,45 @@ func TestClient_GetETAWithContext(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_GetMatrix_Retry(t *testing.T) {
+	t.Run("post", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("method should be POST but it is %s", r.Method)
+			}
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"sources_to_targets":[[{"distance":15023,"time":935,"to_index":0,"from_index":0,"status":"Success"}]]}`))
+		}))
+		defer sv.Close()
+
+		policy := retry.DefaultPolicy()
+		policy.InitialBackoff = time.Millisecond
+		cfg, err := config.NewDefaultConfig("key", config.WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		client, err := NewMatrixClient(cfg, V1, time.Second, WithURL(sv.URL))
+		if err != nil {
+			t.Fatalf("could not create matrix client due to: %s", err.Error())
+		}
+		out, err := client.GetMatrix(
+			[]Point{{Lat: 35.7733304928583, Lon: 51.418322660028934}},
+			[]Point{{Lat: 35.70033104179786, Lon: 51.351492404937744}},
+			NewDefaultCallOptions(WithUsePost()),
+		)
+		if err != nil {
+			t.Fatalf("there should not be an error after retry: %s", err.Error())
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+		if len(out.SourcesToTargets) != 1 {
+			t.Fatalf("output should have 1 source")
+		}
+	})
+}
@@ -1,5 +1,7 @@
 package matrix
 
+import "github.com/snapp-incubator/smapp-sdk-go/geo"
+
 // Point is the type for representing a point in a map.
 type Point struct {
 	Lat      float64           `json:"lat"`
@@ -34,3 +36,22 @@ type Output struct {
 		Status string `json:"status"`
 	} `json:"sources_to_targets"`
 }
+
+// PointFromLatLng creates a Point of ll.
+func PointFromLatLng(ll geo.LatLng) Point {
+	return Point{Lat: ll.Lat, Lon: ll.Lon}
+}
+
+// PointsFromLatLngs converts a list of coordinates, e.g. sources or targets, to a list of Point s.
+func PointsFromLatLngs(lls []geo.LatLng) []Point {
+	points := make([]Point, 0, len(lls))
+	for _, ll := range lls {
+		points = append(points, PointFromLatLng(ll))
+	}
+	return points
+}
+
+// LatLng returns the coordinate of p.
+func (p Point) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: p.Lat, Lon: p.Lon}
+}
@@ -0,0 +1,23 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestPoint_LatLng(t *testing.T) {
+	ll := geo.LatLng{Lat: 35.7, Lon: 51.4}
+	p := PointFromLatLng(ll)
+	if p.Lat != 35.7 || p.Lon != 51.4 {
+		t.Fatalf("point should have the coordinate of ll but it is %+v", p)
+	}
+	if p.LatLng() != ll {
+		t.Fatalf("LatLng should return the coordinate of the point but it returned %+v", p.LatLng())
+	}
+
+	points := PointsFromLatLngs([]geo.LatLng{ll, {Lat: 35.8, Lon: 51.5}})
+	if len(points) != 2 || points[1].Lat != 35.8 || points[1].Lon != 51.5 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+}
@@ -0,0 +1,487 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/snapp-incubator/smapp-sdk-go/breaker"
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	areagateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// features holds the constructor options shared by all service clients. zero fields are not given to the clients.
+type features struct {
+	retryPolicy    *retry.Policy
+	circuitBreaker *breaker.Breaker
+	rateLimiter    *ratelimit.Limiter
+	interceptors   []interceptor.Interceptor
+	meterName      string
+	logger         *slog.Logger
+	coalescing     bool
+}
+
+// setters holds the constructor options of a service whose option type is O.
+type setters[O any] struct {
+	retryPolicy    func(retry.Policy) O
+	circuitBreaker func(*breaker.Breaker) O
+	rateLimiter    func(*ratelimit.Limiter) O
+	interceptors   func(...interceptor.Interceptor) O
+	metrics        func(string) O
+	logger         func(*slog.Logger) O
+	coalescing     func() O
+}
+
+// options returns the options of s that apply f.
+func (s setters[O]) options(f features) []O {
+	var opts []O
+	if f.retryPolicy != nil {
+		opts = append(opts, s.retryPolicy(*f.retryPolicy))
+	}
+	if f.circuitBreaker != nil {
+		opts = append(opts, s.circuitBreaker(f.circuitBreaker))
+	}
+	if f.rateLimiter != nil {
+		opts = append(opts, s.rateLimiter(f.rateLimiter))
+	}
+	if len(f.interceptors) > 0 {
+		opts = append(opts, s.interceptors(f.interceptors...))
+	}
+	if f.meterName != "" {
+		opts = append(opts, s.metrics(f.meterName))
+	}
+	if f.logger != nil {
+		opts = append(opts, s.logger(f.logger))
+	}
+	if f.coalescing {
+		opts = append(opts, s.coalescing())
+	}
+	return opts
+}
+
+// service creates a client of a service and calls one of its endpoints.
+type service struct {
+	name string
+	// body is a successful response of the endpoint.
+	body    string
+	newCall func(cfg *config.Config, f features) (func() error, error)
+}
+
+var services = []service{
+	{
+		name: "reverse",
+		body: `{"status":"OK","result":{"displayName":"Azadi Square"}}`,
+		newCall: func(cfg *config.Config, f features) (func() error, error) {
+			client, err := reverse.NewReverseClient(cfg, reverse.V1, time.Second, setters[reverse.ConstructorOption]{
+				retryPolicy:    reverse.WithRetryPolicy,
+				circuitBreaker: reverse.WithCircuitBreaker,
+				rateLimiter:    reverse.WithRateLimiter,
+				interceptors:   reverse.WithInterceptors,
+				metrics:        reverse.WithOpenTelemetryMetrics,
+				logger:         reverse.WithLogger,
+				coalescing:     reverse.WithRequestCoalescing,
+			}.options(f)...)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				_, err := client.GetDisplayNameWithContext(context.Background(), 35.6997, 51.3380, reverse.NewDefaultCallOptions())
+				return err
+			}, nil
+		},
+	},
+	{
+		name: "search",
+		body: `{"status":"OK","predictions":[]}`,
+		newCall: func(cfg *config.Config, f features) (func() error, error) {
+			client, err := search.NewSearchClient(cfg, search.V1, time.Second, setters[search.ConstructorOption]{
+				retryPolicy:    search.WithRetryPolicy,
+				circuitBreaker: search.WithCircuitBreaker,
+				rateLimiter:    search.WithRateLimiter,
+				interceptors:   search.WithInterceptors,
+				metrics:        search.WithOpenTelemetryMetrics,
+				logger:         search.WithLogger,
+				coalescing:     search.WithRequestCoalescing,
+			}.options(f)...)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				_, err := client.GetCitiesWithContext(context.Background(), search.NewDefaultCallOptions())
+				return err
+			}, nil
+		},
+	},
+	{
+		name: "eta",
+		body: `{"trip":{"legs":[{"time":300,"length":1200}]}}`,
+		newCall: func(cfg *config.Config, f features) (func() error, error) {
+			client, err := eta.NewETAClient(cfg, eta.V1, time.Second, setters[eta.ConstructorOption]{
+				retryPolicy:    eta.WithRetryPolicy,
+				circuitBreaker: eta.WithCircuitBreaker,
+				rateLimiter:    eta.WithRateLimiter,
+				interceptors:   eta.WithInterceptors,
+				metrics:        eta.WithOpenTelemetryMetrics,
+				logger:         eta.WithLogger,
+				coalescing:     eta.WithRequestCoalescing,
+			}.options(f)...)
+			if err != nil {
+				return nil, err
+			}
+			points := []eta.Point{{Lat: 35.6997, Lon: 51.3380}, {Lat: 35.7575, Lon: 51.4100}}
+			return func() error {
+				_, err := client.GetETAWithContext(context.Background(), points, eta.NewDefaultCallOptions())
+				return err
+			}, nil
+		},
+	},
+	{
+		name: "matrix",
+		body: `{"sources_to_targets":[[{"distance":1200,"time":300,"status":"Ok"}]]}`,
+		newCall: func(cfg *config.Config, f features) (func() error, error) {
+			client, err := matrix.NewMatrixClient(cfg, matrix.V1, time.Second, setters[matrix.ConstructorOption]{
+				retryPolicy:    matrix.WithRetryPolicy,
+				circuitBreaker: matrix.WithCircuitBreaker,
+				rateLimiter:    matrix.WithRateLimiter,
+				interceptors:   matrix.WithInterceptors,
+				metrics:        matrix.WithOpenTelemetryMetrics,
+				logger:         matrix.WithLogger,
+				coalescing:     matrix.WithRequestCoalescing,
+			}.options(f)...)
+			if err != nil {
+				return nil, err
+			}
+			sources := []matrix.Point{{Lat: 35.6997, Lon: 51.3380}}
+			targets := []matrix.Point{{Lat: 35.7575, Lon: 51.4100}}
+			return func() error {
+				_, err := client.GetMatrixWithContext(context.Background(), sources, targets, matrix.NewDefaultCallOptions())
+				return err
+			}, nil
+		},
+	},
+	{
+		name: "area-gateways",
+		body: `{"id":"1","name":"Azadi","type":"Polygon","gates":[]}`,
+		newCall: func(cfg *config.Config, f features) (func() error, error) {
+			client, err := areagateways.NewAreaGatewaysClient(cfg, areagateways.V1, time.Second, setters[areagateways.ConstructorOption]{
+				retryPolicy:    areagateways.WithRetryPolicy,
+				circuitBreaker: areagateways.WithCircuitBreaker,
+				rateLimiter:    areagateways.WithRateLimiter,
+				interceptors:   areagateways.WithInterceptors,
+				metrics:        areagateways.WithOpenTelemetryMetrics,
+				logger:         areagateways.WithLogger,
+				coalescing:     areagateways.WithRequestCoalescing,
+			}.options(f)...)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				_, err := client.GetGatewaysWithContext(context.Background(), 35.6997, 51.3380, areagateways.NewDefaultCallOptions())
+				return err
+			}, nil
+		},
+	},
+}
+
+// newConfig starts a server serving handler and returns a config whose base url is the server.
+func newConfig(t *testing.T, handler http.HandlerFunc, opts ...config.Option) *config.Config {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg, err := config.NewDefaultConfig("key", append([]config.Option{config.WithAPIBaseURL(server.URL)}, opts...)...)
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	return cfg
+}
+
+// client returns a function calling the endpoint of a client of s created with cfg and f.
+func (s service) client(t *testing.T, cfg *config.Config, f features) func() error {
+	t.Helper()
+	call, err := s.newCall(cfg, f)
+	if err != nil {
+		t.Fatalf("could not create %s client due to: %s", s.name, err.Error())
+	}
+	return call
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := retry.DefaultPolicy()
+	policy.InitialBackoff = time.Millisecond
+
+	cases := map[string]struct {
+		features features
+		options  []config.Option
+	}{
+		"from_option": {features: features{retryPolicy: &policy}},
+		"from_config": {options: []config.Option{config.WithRetryPolicy(policy)}},
+	}
+	for _, s := range services {
+		for name, c := range cases {
+			t.Run(s.name+"/"+name, func(t *testing.T) {
+				var calls atomic.Int32
+				cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+					if calls.Add(1) == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					_, _ = w.Write([]byte(s.body))
+				}, c.options...)
+
+				if err := s.client(t, cfg, c.features)(); err != nil || calls.Load() != 2 {
+					t.Fatalf("failed call should be retried once but err is %v after %d calls", err, calls.Load())
+				}
+			})
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	for _, s := range services {
+		t.Run(s.name, func(t *testing.T) {
+			var calls atomic.Int32
+			cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			b := breaker.New(breaker.Settings{Name: s.name, MinRequests: 2, CoolDown: time.Minute})
+			call := s.client(t, cfg, features{circuitBreaker: b})
+
+			for i := 0; i < 2; i++ {
+				if err := call(); err == nil {
+					t.Fatal("call should fail")
+				}
+			}
+			if err := call(); !errors.Is(err, smapperrors.ErrCircuitOpen) {
+				t.Fatalf("err should be ErrCircuitOpen after 2 failures but it is %v", err)
+			}
+			if calls.Load() != 2 {
+				t.Fatalf("open circuit should not send requests but server got %d requests", calls.Load())
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	for _, s := range services {
+		for _, name := range []string{"from_option", "from_config"} {
+			t.Run(s.name+"/"+name, func(t *testing.T) {
+				limiter := ratelimit.New(ratelimit.Limit{Rate: 0.001, Burst: 1}, ratelimit.WithMode(ratelimit.NoWaitMode))
+				var f features
+				var opts []config.Option
+				if name == "from_option" {
+					f.rateLimiter = limiter
+				} else {
+					opts = append(opts, config.WithRateLimiter(limiter))
+				}
+
+				var calls atomic.Int32
+				cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+					calls.Add(1)
+					_, _ = w.Write([]byte(s.body))
+				}, opts...)
+				call := s.client(t, cfg, f)
+
+				if err := call(); err != nil {
+					t.Fatalf("first call should pass the limiter but err is %v", err)
+				}
+				if err := call(); !errors.Is(err, smapperrors.ErrRateLimited) {
+					t.Fatalf("err should be ErrRateLimited when there is no token but it is %v", err)
+				}
+				if calls.Load() != 1 {
+					t.Fatalf("limited call should not send a request but server got %d requests", calls.Load())
+				}
+			})
+		}
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	for _, s := range services {
+		t.Run(s.name, func(t *testing.T) {
+			cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-First") != "1" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(s.body))
+			})
+
+			var hooks []string
+			record := func(name string) interceptor.Funcs {
+				return interceptor.Funcs{
+					BeforeRequestFunc: func(_ context.Context, _ interceptor.Call, _ *http.Request) error {
+						hooks = append(hooks, name+".before")
+						return nil
+					},
+					AfterResponseFunc: func(_ context.Context, _ interceptor.Call, _ *http.Request, resp *http.Response) error {
+						hooks = append(hooks, name+".after")
+						return nil
+					},
+				}
+			}
+			interceptors := []interceptor.Interceptor{
+				interceptor.WithHeaders(map[string]string{"X-First": "1"}),
+				record("first"),
+				record("second"),
+			}
+			if err := s.client(t, cfg, features{interceptors: interceptors})(); err != nil {
+				t.Fatalf("header of the first interceptor should reach the server but err is %v", err)
+			}
+
+			expected := "first.before,second.before,first.after,second.after"
+			if got := strings.Join(hooks, ","); got != expected {
+				t.Fatalf("hooks should run as %s but they ran as %s", expected, got)
+			}
+		})
+	}
+}
+
+// meterProvider records the service label of the request durations recorded by its meters.
+type meterProvider struct {
+	embedded.MeterProvider
+
+	mu       sync.Mutex
+	services []string
+}
+
+func (p *meterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return durationMeter{provider: p}
+}
+
+type durationMeter struct {
+	noop.Meter
+	provider *meterProvider
+}
+
+func (m durationMeter) Float64Histogram(name string, _ ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	if name != executor.RequestDurationMetricName {
+		return noop.Float64Histogram{}, nil
+	}
+	return durationHistogram{provider: m.provider}, nil
+}
+
+type durationHistogram struct {
+	noop.Float64Histogram
+	provider *meterProvider
+}
+
+func (h durationHistogram) Record(_ context.Context, _ float64, opts ...metric.RecordOption) {
+	attrs := metric.NewRecordConfig(opts).Attributes()
+	service, _ := attrs.Value(executor.ServiceKey)
+
+	h.provider.mu.Lock()
+	defer h.provider.mu.Unlock()
+	h.provider.services = append(h.provider.services, service.AsString())
+}
+
+func TestOpenTelemetryMetrics(t *testing.T) {
+	previous := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	for _, s := range services {
+		t.Run(s.name, func(t *testing.T) {
+			provider := &meterProvider{}
+			otel.SetMeterProvider(provider)
+
+			cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(s.body))
+			})
+			if err := s.client(t, cfg, features{meterName: "test"})(); err != nil {
+				t.Fatalf("call should not fail but err is %v", err)
+			}
+
+			if len(provider.services) != 1 || provider.services[0] != s.name {
+				t.Fatalf("one request duration should be recorded for %s but %v are recorded", s.name, provider.services)
+			}
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	for _, s := range services {
+		t.Run(s.name, func(t *testing.T) {
+			cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(s.body))
+			})
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			if err := s.client(t, cfg, features{logger: logger})(); err != nil {
+				t.Fatalf("call should not fail but err is %v", err)
+			}
+
+			for _, expected := range []string{`"msg":"smapp request completed"`, `"service":"` + s.name + `"`} {
+				if !strings.Contains(buf.String(), expected) {
+					t.Fatalf("log should contain %s but it is %s", expected, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRequestCoalescing(t *testing.T) {
+	const callers = 5
+
+	for _, s := range services {
+		t.Run(s.name, func(t *testing.T) {
+			var calls atomic.Int32
+			release := make(chan struct{})
+			cfg := newConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				<-release
+				_, _ = w.Write([]byte(s.body))
+			})
+			call := s.client(t, cfg, features{coalescing: true})
+
+			var wg sync.WaitGroup
+			errs := make(chan error, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- call()
+				}()
+			}
+			// the first request is held by the server until the other callers have had time to join it.
+			for calls.Load() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("call should not fail but err is %v", err)
+				}
+			}
+			if calls.Load() != 1 {
+				t.Fatalf("identical calls should share one request but server got %d requests", calls.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package reverse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+const (
+	// DefaultCacheTTL is the TTL of cached responses if CacheSettings.TTL is not set.
+	DefaultCacheTTL = time.Hour
+	// DefaultCachePrecision is the number of decimal places of snapped coordinates if CacheSettings.Precision is
+	// not set. 4 decimal places are about 11 meters.
+	DefaultCachePrecision = 4
+)
+
+// CacheSettings specifies the behaviour of the response cache of a Client. zero values are replaced with defaults.
+type CacheSettings struct {
+	// TTL is how long a response is cached. default is 1h.
+	TTL time.Duration
+	// Precision is the number of decimal places coordinates are rounded to before they are used in cache keys.
+	// calls for locations rounded to the same coordinates share a cached response. default is 4.
+	Precision int
+	// GeohashPrecision is the length of geohashes of locations used in cache keys instead of rounded coordinates, so
+	// calls for locations in the same geohash cell share a cached response. locations are keyed by geo.CellKey. it is
+	// not used by default, and Precision is ignored if it is set.
+	GeohashPrecision int
+}
+
+// responseCache holds the cache of a Client and builds its keys. keys do not contain the API key, so a store must not
+// be shared by clients of different tenants or API keys.
+type responseCache struct {
+	store            cache.Cache
+	ttl              time.Duration
+	precision        int
+	geohashPrecision int
+	prefix           string
+}
+
+// newResponseCache creates the responseCache of a client sending requests to url. the prefix of its keys contains a
+// hash of url, so clients of different URLs can share a store.
+func newResponseCache(store cache.Cache, settings CacheSettings, version Version, url string) *responseCache {
+	if settings.TTL <= 0 {
+		settings.TTL = DefaultCacheTTL
+	}
+	if settings.Precision <= 0 {
+		settings.Precision = DefaultCachePrecision
+	}
+
+	return &responseCache{
+		store:            store,
+		ttl:              settings.TTL,
+		precision:        settings.Precision,
+		geohashPrecision: settings.GeohashPrecision,
+		prefix:           fmt.Sprintf("smapp:%s:%s:%s", serviceName, version, urlHash(url)),
+	}
+}
+
+// key returns the cache key of a call. coordinates are snapped to the precision or the geohash cell of the cache and
+// only the call options changing the response are used. headers are not part of the key.
+func (rc *responseCache) key(operation string, lat, lon float64, options CallOptions) string {
+	var responseType, language, zoomLevel string
+	if options.UseResponseType {
+		responseType = string(options.ResponseType)
+	}
+	if options.UseLanguage {
+		language = string(options.Language)
+	}
+	if options.UseZoomLevel {
+		zoomLevel = strconv.Itoa(options.ZoomLevel)
+	}
+
+	location := rc.snap(lat) + ":" + rc.snap(lon)
+	if rc.geohashPrecision > 0 {
+		location = geo.CellKey(geo.LatLng{Lat: lat, Lon: lon}, rc.geohashPrecision)
+	}
+
+	return fmt.Sprintf("%s:%s:%s:type=%s:lang=%s:zoom=%s:normalize=%t",
+		rc.prefix, operation, location, responseType, language, zoomLevel, options.Normalize)
+}
+
+// urlHash returns a short hash of url for cache keys.
+func urlHash(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:8])
+}
+
+// snap rounds the coordinate to the precision of the cache.
+func (rc *responseCache) snap(coordinate float64) string {
+	scale := math.Pow10(rc.precision)
+	snapped := math.Round(coordinate*scale) / scale
+	if snapped == 0 {
+		// avoid separate keys for -0 and 0.
+		snapped = 0
+	}
+	return strconv.FormatFloat(snapped, 'f', rc.precision, 64)
+}
+
+// cached returns the cached response of a call, or calls fetch and caches its response if it succeeds.
+// failures of the cache are logged and ignored.
+func cached[T any](ctx context.Context, c *Client, operation string, lat, lon float64, options CallOptions,
+	fetch func() (T, error)) (T, error) {
+	if c.cache == nil || ctx == nil {
+		return fetch()
+	}
+
+	key := c.cache.key(operation, lat, lon, options)
+	data, found, err := c.cache.store.Get(ctx, key)
+	if err != nil {
+		c.executor.LogCacheError(ctx, operation, err)
+	}
+	if found {
+		var result T
+		if err := json.Unmarshal(data, &result); err == nil {
+			c.executor.RecordCacheLookup(ctx, operation, true)
+			return result, nil
+		}
+	}
+	c.executor.RecordCacheLookup(ctx, operation, false)
+
+	result, err := fetch()
+	if err != nil {
+		return result, err
+	}
+
+	data, err = json.Marshal(result)
+	if err == nil {
+		err = c.cache.store.Set(ctx, key, data, c.cache.ttl)
+	}
+	if err != nil {
+		c.executor.LogCacheError(ctx, operation, err)
+	}
+
+	return result, nil
+}
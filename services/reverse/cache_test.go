@@ -0,0 +1,182 @@
+package reverse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestResponseCache_Key(t *testing.T) {
+	rc := newResponseCache(cache.NewLRU(10), CacheSettings{Precision: 3}, V1, "http://example.com/reverse/v1")
+
+	base := rc.key("get-display-name-address", 35.70001, 51.40001, NewDefaultCallOptions())
+	if base != "smapp:reverse:v1:314e2e2eef303432:get-display-name-address:35.700:51.400:type=:lang=:zoom=:normalize=false" {
+		t.Fatalf("key is not as expected: %s", base)
+	}
+
+	if key := rc.key("get-display-name-address", 35.70049, 51.39951, NewDefaultCallOptions(WithHeaders(map[string]string{"a": "b"}))); key != base {
+		t.Fatalf("nearby locations and headers should not change the key but it is %s", key)
+	}
+
+	different := []string{
+		rc.key("get-address-components", 35.7, 51.4, NewDefaultCallOptions()),
+		rc.key("get-display-name-address", 35.7006, 51.4, NewDefaultCallOptions()),
+		rc.key("get-display-name-address", 35.7, 51.4, NewDefaultCallOptions(WithEnglishLanguage())),
+		rc.key("get-display-name-address", 35.7, 51.4, NewDefaultCallOptions(WithPassengerResponseType())),
+		rc.key("get-display-name-address", 35.7, 51.4, NewDefaultCallOptions(WithZoomLevel(12))),
+		rc.key("get-display-name-address", 35.7, 51.4, NewDefaultCallOptions(WithNormalize())),
+	}
+	for _, key := range different {
+		if key == base {
+			t.Fatalf("key should be different from %s", base)
+		}
+	}
+
+	if rc.snap(-0.0001) != rc.snap(0.0001) {
+		t.Fatalf("-0 and 0 should have the same key but they are %s and %s", rc.snap(-0.0001), rc.snap(0.0001))
+	}
+}
+
+func TestResponseCache_GeohashKey(t *testing.T) {
+	rc := newResponseCache(cache.NewLRU(10), CacheSettings{GeohashPrecision: 7}, V1, "http://example.com/reverse/v1")
+
+	base := rc.key("get-display-name-address", 42.6, -5.6, NewDefaultCallOptions())
+	if base != "smapp:reverse:v1:314e2e2eef303432:get-display-name-address:geohash=ezs42e4:type=:lang=:zoom=:normalize=false" {
+		t.Fatalf("key is not as expected: %s", base)
+	}
+	cell, _ := geo.DecodeGeohash("ezs42e4")
+	if key := rc.key("get-display-name-address", cell.Max.Lat-1e-9, cell.Min.Lon, NewDefaultCallOptions()); key != base {
+		t.Fatalf("locations in the same cell should have the same key but it is %s", key)
+	}
+	if key := rc.key("get-display-name-address", 42.61, -5.6, NewDefaultCallOptions()); key == base {
+		t.Fatalf("locations in different cells should have different keys but both are %s", key)
+	}
+	if key := rc.key("get-display-name-address", 95, -5.6, NewDefaultCallOptions()); key != "smapp:reverse:v1:314e2e2eef303432:get-display-name-address:95,-5.6:type=:lang=:zoom=:normalize=false" {
+		t.Fatalf("invalid locations should have their exact coordinates but key is %s", key)
+	}
+}
+
+func TestNewResponseCache(t *testing.T) {
+	rc := newResponseCache(cache.NewLRU(10), CacheSettings{}, V1, "http://example.com/reverse/v1")
+	if rc.ttl != DefaultCacheTTL {
+		t.Fatalf("ttl should be %s but it is %s", DefaultCacheTTL, rc.ttl)
+	}
+	if rc.precision != DefaultCachePrecision {
+		t.Fatalf("precision should be %d but it is %d", DefaultCachePrecision, rc.precision)
+	}
+
+	// clients of different URLs, e.g. regions or environments, can share a store without sharing responses.
+	other := newResponseCache(cache.NewLRU(10), CacheSettings{}, V1, "http://other.example.com/reverse/v1")
+	if other.prefix == rc.prefix {
+		t.Fatalf("prefixes of different URLs should differ but both are %s", rc.prefix)
+	}
+}
+
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache is down")
+}
+
+func (failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("cache is down")
+}
+
+func TestClient_Cache(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Query().Get(Lat) == "0.000000" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Query().Get(Display) == "true" {
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"displayName":"Tehran"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","result":{"components":[{"name":"Tehran","type":"city"}]}}`))
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	t.Run("hits", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		lru := cache.NewLRU(10)
+		client, err := NewReverseClient(cfg, V1, time.Second, WithURL(sv.URL), WithCache(lru, CacheSettings{}))
+		if err != nil {
+			t.Fatalf("could not create reverse client due to: %s", err.Error())
+		}
+
+		for _, lat := range []float64{35.70001, 35.70002} {
+			name, err := client.GetDisplayName(lat, 51.4, NewDefaultCallOptions())
+			if err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+			if name != "Tehran" {
+				t.Fatalf("display name should be Tehran but it is %s", name)
+			}
+		}
+
+		for i := 0; i < 2; i++ {
+			result, err := client.GetStructuralResult(35.7, 51.4, NewDefaultCallOptions())
+			if err != nil {
+				t.Fatalf("should not return error: %s", err.Error())
+			}
+			if result.City != "Tehran" {
+				t.Fatalf("city should be Tehran but it is %s", result.City)
+			}
+		}
+
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+		if s := lru.Stats(); s.Hits != 2 || s.Misses != 2 {
+			t.Fatalf("stats should be 2 hits and 2 misses but it is %+v", s)
+		}
+	})
+
+	t.Run("errors_not_cached", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		lru := cache.NewLRU(10)
+		client, err := NewReverseClient(cfg, V1, time.Second, WithURL(sv.URL), WithCache(lru, CacheSettings{}))
+		if err != nil {
+			t.Fatalf("could not create reverse client due to: %s", err.Error())
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetDisplayName(0, 0, NewDefaultCallOptions()); err == nil {
+				t.Fatal("should return error")
+			}
+		}
+		if calls != 2 || lru.Len() != 0 {
+			t.Fatalf("failed calls should not be cached but server is called %d times and cache has %d entries", calls, lru.Len())
+		}
+	})
+
+	t.Run("failing_cache", func(t *testing.T) {
+		client, err := NewReverseClient(cfg, V1, time.Second, WithURL(sv.URL), WithCache(failingCache{}, CacheSettings{}))
+		if err != nil {
+			t.Fatalf("could not create reverse client due to: %s", err.Error())
+		}
+
+		name, err := client.GetDisplayName(35.7, 51.4, NewDefaultCallOptions())
+		if err != nil {
+			t.Fatalf("failures of cache should not fail the call: %s", err.Error())
+		}
+		if name != "Tehran" {
+			t.Fatalf("display name should be Tehran but it is %s", name)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package reverse
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestWithDriverResponseType(t *testing.T) {
 	callOptions := CallOptions{
@@ -253,3 +256,11 @@ func TestWithNormalize(t *testing.T) {
 		t.Fatalf("Normalize should be true")
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	callOptions := NewDefaultCallOptions(WithTimeout(300 * time.Millisecond))
+
+	if callOptions.Timeout != 300*time.Millisecond {
+		t.Fatalf("Timeout should be 300ms but it is %s", callOptions.Timeout)
+	}
+}
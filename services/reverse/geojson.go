@@ -0,0 +1,67 @@
+package reverse
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+const (
+	componentsProperty  = "components"
+	displayNameProperty = "displayName"
+)
+
+// Address is the reverse geocode result of a location, which is converted to and from a GeoJSON Feature. either of
+// Components or DisplayName may be empty, depending on the operation it is the result of.
+type Address struct {
+	// Location is the location the address is requested for.
+	Location geo.LatLng
+	// Components of the address, e.g. the result of GetComponents.
+	Components []Component
+	// DisplayName of the address, e.g. the result of GetDisplayName.
+	DisplayName string
+}
+
+// MarshalGeoJSON encodes a as a GeoJSON Point Feature of its location. the properties are `components`, the list of
+// its components, `displayName` if it is not empty, and the name of each component keyed by its type, e.g. `city`,
+// for labeling features in map tools. the first component of each type is used if types are repeated.
+func (a Address) MarshalGeoJSON() ([]byte, error) {
+	components := a.Components
+	if components == nil {
+		components = []Component{}
+	}
+	properties := map[string]any{componentsProperty: components}
+	for _, component := range components {
+		if _, ok := properties[component.Type]; !ok && component.Type != "" {
+			properties[component.Type] = component.Name
+		}
+	}
+	if a.DisplayName != "" {
+		properties[displayNameProperty] = a.DisplayName
+	}
+	return json.Marshal(geo.NewFeature(nil, geo.PointGeometry(a.Location), properties))
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON Point Feature encoded by MarshalGeoJSON into a. components are read from the
+// `components` property only.
+func (a *Address) UnmarshalGeoJSON(data []byte) error {
+	f, err := geo.DecodeFeature(data)
+	if err != nil {
+		return err
+	}
+	ll, err := f.Geometry.Point()
+	if err != nil {
+		return err
+	}
+
+	var properties struct {
+		Components  []Component `json:"components"`
+		DisplayName string      `json:"displayName"`
+	}
+	if err := geo.DecodeProperties(f.Properties, &properties); err != nil {
+		return fmt.Errorf("could not decode address: %w", err)
+	}
+	*a = Address{Location: ll, Components: properties.Components, DisplayName: properties.DisplayName}
+	return nil
+}
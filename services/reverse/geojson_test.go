@@ -0,0 +1,53 @@
+package reverse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestAddress_GeoJSON(t *testing.T) {
+	a := Address{
+		Location: geo.LatLng{Lat: 35.7, Lon: 51.4},
+		Components: []Component{
+			{Name: "Tehran", Type: "city"},
+			{Name: "Azadi", Type: "primary"},
+			{Name: "Tarasht", Type: "primary"},
+		},
+		DisplayName: "Tehran, Azadi",
+	}
+
+	data, err := a.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	f, err := geo.DecodeFeature(data)
+	if err != nil {
+		t.Fatalf("address should be encoded as a feature: %s", err)
+	}
+	if f.Properties["city"] != "Tehran" || f.Properties["primary"] != "Azadi" || f.Properties["displayName"] != "Tehran, Azadi" {
+		t.Fatalf("unexpected properties: %v", f.Properties)
+	}
+
+	var decoded Address
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, a) {
+		t.Fatalf("address should round-trip:\n got %+v\nwant %+v", decoded, a)
+	}
+
+	data, err = (Address{Location: a.Location}).MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	if err := decoded.UnmarshalGeoJSON(data); err != nil || decoded.DisplayName != "" || len(decoded.Components) != 0 {
+		t.Fatalf("empty address should round-trip but it is %+v, err: %v", decoded, err)
+	}
+
+	if err := decoded.UnmarshalGeoJSON([]byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[51.4,35.7]},"properties":{"components":"city"}}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("invalid components should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package reverse
 
-import "reflect"
+import (
+	"reflect"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
 
 // FrequentStrategy is the type that specifies the strategy of a frequent/frequent-v2 type request.
 type FrequentStrategy string
@@ -43,6 +47,11 @@ type Request struct {
 	Normalize string       `json:"normalize"`
 }
 
+// LatLng returns the coordinate of r.
+func (r Request) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: r.Lat, Lon: r.Lon}
+}
+
 type BatchReverseRequest struct {
 	Requests []Request `json:"requests"`
 }
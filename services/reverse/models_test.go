@@ -0,0 +1,14 @@
+package reverse
+
+import (
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestRequest_LatLng(t *testing.T) {
+	r := Request{Lat: 35.7, Lon: 51.4, ID: 1}
+	if r.LatLng() != (geo.LatLng{Lat: 35.7, Lon: 51.4}) {
+		t.Fatalf("unexpected coordinate: %+v", r.LatLng())
+	}
+}
@@ -1,8 +1,17 @@
 package reverse
 
 import (
-	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"log/slog"
 	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/snapp-incubator/smapp-sdk-go/breaker"
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/redact"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
 )
 
 // ConstructorOption is a function type for customizing constructor behaviour in a fluent way.
@@ -33,6 +42,76 @@ func WithTransport(transport http.RoundTripper) ConstructorOption {
 func WithRequestOpenTelemetryTracing(tracerName string) ConstructorOption {
 	return func(client *Client) {
 		client.tracerName = tracerName
-		client.httpClient.Transport = otelhttp.NewTransport(client.httpClient.Transport)
+		// the API key is hidden from otelhttp, so it is not recorded in the url attribute of spans.
+		client.httpClient.Transport = redact.Around(client.cfg.APIKeyName, client.httpClient.Transport,
+			func(next http.RoundTripper) http.RoundTripper {
+				return otelhttp.NewTransport(next)
+			},
+		)
+	}
+}
+
+// WithOpenTelemetryMetrics will record metrics of requests using the meter with the given name of the global
+// opentelemetry meter provider.
+func WithOpenTelemetryMetrics(meterName string) ConstructorOption {
+	return func(client *Client) {
+		client.meterName = meterName
+	}
+}
+
+// WithRetryPolicy will retry failed requests according to the given policy. it overrides config.Config.RetryPolicy.
+func WithRetryPolicy(policy retry.Policy) ConstructorOption {
+	return func(client *Client) {
+		client.transport.RetryPolicy = &policy
+	}
+}
+
+// WithCircuitBreaker will guard requests of the client with the given circuit breaker.
+// requests fail with an error matching smapperrors.ErrCircuitOpen while the breaker is open.
+func WithCircuitBreaker(b *breaker.Breaker) ConstructorOption {
+	return func(client *Client) {
+		client.transport.CircuitBreaker = b
+	}
+}
+
+// WithRequestCoalescing will send identical concurrent requests of the client once and share the response between
+// their callers. a caller giving up does not cancel the request for the others.
+func WithRequestCoalescing() ConstructorOption {
+	return func(client *Client) {
+		client.transport.Coalescing = true
+	}
+}
+
+// WithRateLimiter will take a token from the given rate limiter before sending each request. it overrides
+// config.Config.RateLimiter.
+func WithRateLimiter(limiter *ratelimit.Limiter) ConstructorOption {
+	return func(client *Client) {
+		client.transport.RateLimiter = limiter
+	}
+}
+
+// WithInterceptors will call the hooks of the given interceptors around each request of the client.
+// interceptors are called in the order they are added.
+func WithInterceptors(interceptors ...interceptor.Interceptor) ConstructorOption {
+	return func(client *Client) {
+		client.interceptors = append(client.interceptors, interceptors...)
+	}
+}
+
+// WithLogger will log the lifecycle of requests using the given logger. successful requests are logged at debug level
+// and failures at warn or error level. the API key is never logged.
+func WithLogger(logger *slog.Logger) ConstructorOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// WithCache will cache the responses of GetComponents, GetDisplayName, GetFrequent and GetStructuralResult in the
+// given cache. coordinates are snapped to settings.Precision decimal places, so nearby locations share a response.
+// keys contain a hash of the URL of the client but not its API key, so c must not be shared across tenants or keys.
+func WithCache(c cache.Cache, settings CacheSettings) ConstructorOption {
+	return func(client *Client) {
+		client.cacheStore = c
+		client.cacheSettings = settings
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
 )
 
@@ -63,3 +64,25 @@ func TestWithRequestOpenTelemetryTracing(t *testing.T) {
 		t.Fatalf("client.tracerName should be %s but it is %s", "test", client.tracerName)
 	}
 }
+
+func TestWithCache(t *testing.T) {
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	lru := cache.NewLRU(10)
+	client, err := NewReverseClient(cfg, V1, time.Second, WithCache(lru, CacheSettings{TTL: time.Minute, Precision: 5}))
+	if err != nil {
+		t.Fatalf("could not create client due to: %s", err.Error())
+	}
+
+	if client.cache == nil || client.cache.store != lru {
+		t.Fatal("client.cache should use the given cache")
+	}
+	if client.cache.ttl != time.Minute || client.cache.precision != 5 {
+		t.Fatalf("client.cache should use the given settings but it is %+v", client.cache)
+	}
+	if prefix := "smapp:reverse:v1:" + urlHash(client.url); client.cache.prefix != prefix {
+		t.Fatalf("client.cache.prefix should be %s but it is %s", prefix, client.cache.prefix)
+	}
+}
@@ -2,21 +2,19 @@ package reverse
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
-	"github.com/snapp-incubator/smapp-sdk-go/version"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/trace"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 // Interface consists of functions of different functionalities of a reverse geocode service. there are two implementation of this service.
@@ -60,14 +58,24 @@ const (
 
 	OKStatus    = "OK"
 	ErrorStatus = "ERROR"
+
+	serviceName = "reverse"
 )
 
 // Client is the main implementation of Interface for reverse service
 type Client struct {
-	cfg        *config.Config
-	url        string
-	httpClient http.Client
-	tracerName string
+	cfg           *config.Config
+	url           string
+	httpClient    http.Client
+	tracerName    string
+	meterName     string
+	transport     executor.TransportOptions
+	interceptors  []interceptor.Interceptor
+	logger        *slog.Logger
+	cacheStore    cache.Cache
+	cacheSettings CacheSettings
+	cache         *responseCache
+	executor      *executor.Executor
 }
 
 // Force Client to implement Interface at compile time
@@ -85,28 +93,18 @@ func (c *Client) GetDisplayName(lat, lon float64, options CallOptions) (string,
 
 // GetComponentsWithContext is like GetComponents, but with context.Context support.
 func (c *Client) GetComponentsWithContext(ctx context.Context, lat, lon float64, options CallOptions) ([]Component, error) {
-	if ctx == nil {
-		return nil, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "get-address-components")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return nil, errors.New("smapp reverse geo-code: could not create request. err: " + err.Error())
-	}
+	const operation = "get-address-components"
+	return cached(ctx, c, operation, lat, lon, options, func() ([]Component, error) {
+		return c.getComponents(ctx, operation, lat, lon, options)
+	})
+}
 
+func (c *Client) getComponents(ctx context.Context, operation string, lat, lon float64, options CallOptions) ([]Component, error) {
 	params := url.Values{}
 
 	params.Set(Lat, fmt.Sprintf("%f", lat))
 	params.Set(Lon, fmt.Sprintf("%f", lon))
+
 	if options.UseLanguage {
 		params.Set(Lang, string(options.Language))
 	}
@@ -125,92 +123,35 @@ func (c *Client) GetComponentsWithContext(ctx context.Context, lat, lon float64,
 
 	params.Set(Display, "false")
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return nil, fmt.Errorf("smapp reverse geo-code: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var resp struct {
+		Status string `json:"status"`
+		Result struct {
+			Components []Component `json:"components"`
+		} `json:"result"`
+	}
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return nil, fmt.Errorf("smapp reverse geo-code: could not make a request due to this error: %s", err.Error())
-	}
-
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status string `json:"status"`
-			Result struct {
-				Components []Component `json:"components"`
-			} `json:"result"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return nil, fmt.Errorf("smapp reverse geo-code: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.SetStatus(codes.Error, "status not OK")
-			responseSpan.End()
-			return nil, errors.New("smapp reverse geo-code: status of request is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Result.Components, nil
+		return nil, err
 	}
 
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.End()
-	return nil, fmt.Errorf("smapp reverse geo-code: non 200 status: %d", response.StatusCode)
+	return resp.Result.Components, nil
 }
 
 // GetDisplayNameWithContext is like GetDisplayName, but with context.Context support.
 func (c *Client) GetDisplayNameWithContext(ctx context.Context, lat, lon float64, options CallOptions) (string, error) {
-	if ctx == nil {
-		return "", fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "get-display-name-address")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return "", errors.New("smapp reverse geo-code: could not create request. err: " + err.Error())
-	}
+	const operation = "get-display-name-address"
+	return cached(ctx, c, operation, lat, lon, options, func() (string, error) {
+		return c.getDisplayName(ctx, operation, lat, lon, options)
+	})
+}
 
+func (c *Client) getDisplayName(ctx context.Context, operation string, lat, lon float64, options CallOptions) (string, error) {
 	params := url.Values{}
 
 	params.Set(Lat, fmt.Sprintf("%f", lat))
@@ -234,69 +175,24 @@ func (c *Client) GetDisplayNameWithContext(ctx context.Context, lat, lon float64
 
 	params.Set(Display, "true")
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.End()
-		return "", fmt.Errorf("smapp reverse geo-code: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var resp struct {
+		Status string `json:"status"`
+		Result struct {
+			DisplayName string `json:"displayName"`
+		} `json:"result"`
+	}
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return "", fmt.Errorf("smapp reverse geo-code: could not make a request due to this error: %s", err.Error())
-	}
-
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status string `json:"status"`
-			Result struct {
-				DisplayName string `json:"displayName"`
-			} `json:"result"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return "", fmt.Errorf("smapp reverse geo-code: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return "", errors.New("smapp reverse geo-code: status of request is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Result.DisplayName, nil
+		return "", err
 	}
 
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.End()
-	return "", fmt.Errorf("smapp reverse geo-code: non 200 status: %d", response.StatusCode)
+	return resp.Result.DisplayName, nil
 }
 
 // GetFrequent receives `lat`, `lon` as a location and CallOptions and returns FrequentAddress for the given location.
@@ -315,24 +211,13 @@ func (r ResponseType) IsValidFrequentType() bool {
 
 // GetFrequentWithContext is like GetFrequent, but with context.Context support
 func (c *Client) GetFrequentWithContext(ctx context.Context, lat, lon float64, options CallOptions) (FrequentAddress, error) {
-	if ctx == nil {
-		return FrequentAddress{}, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "get-frequent-address")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return FrequentAddress{}, errors.New("smapp reverse geo-code: could not create request. err: " + err.Error())
-	}
+	const operation = "get-frequent-address"
+	return cached(ctx, c, operation, lat, lon, options, func() (FrequentAddress, error) {
+		return c.getFrequent(ctx, operation, lat, lon, options)
+	})
+}
 
+func (c *Client) getFrequent(ctx context.Context, operation string, lat, lon float64, options CallOptions) (FrequentAddress, error) {
 	params := url.Values{}
 
 	params.Set(Lat, fmt.Sprintf("%f", lat))
@@ -348,15 +233,15 @@ func (c *Client) GetFrequentWithContext(ctx context.Context, lat, lon float64, o
 
 	// ResponseType and UseResponseType must either both be set or both be unset.
 	if options.UseResponseType != (options.ResponseType != "") {
-		return FrequentAddress{}, fmt.Errorf(
-			"smapp reverse geo-code: ResponseType and UseResponseType must be used together",
+		return FrequentAddress{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput,
+			"ResponseType and UseResponseType must be used together",
 		)
 	}
 
 	if options.UseResponseType {
 		if !options.ResponseType.IsValidFrequentType() {
-			return FrequentAddress{}, fmt.Errorf(
-				"smapp reverse geo-code: invalid frequent response type: %s",
+			return FrequentAddress{}, smapperrors.Newf(serviceName, operation, smapperrors.ErrInvalidInput,
+				"invalid frequent response type: %s",
 				options.ResponseType,
 			)
 		}
@@ -366,57 +251,19 @@ func (c *Client) GetFrequentWithContext(ctx context.Context, lat, lon float64, o
 		params.Set(Type, string(Frequent))
 	}
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.End()
-		return FrequentAddress{}, fmt.Errorf("smapp reverse geo-code: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var resp FrequentAddress
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       c.url,
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSON(&resp))
 	if err != nil {
-		return FrequentAddress{}, fmt.Errorf("smapp reverse geo-code: could not make a request due to this error: %s", err.Error())
+		return FrequentAddress{}, err
 	}
 
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := FrequentAddress{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return FrequentAddress{}, fmt.Errorf("smapp reverse geo-code: could not serialize response due to: %s", err.Error())
-		}
-
-		responseSpan.End()
-		return resp, nil
-	}
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.End()
-	return FrequentAddress{}, fmt.Errorf("smapp reverse geo-code: non 200 status: %d", response.StatusCode)
+	return resp, nil
 }
 
 // NewReverseClient is the constructor of reverse geocode client.
@@ -434,6 +281,20 @@ func NewReverseClient(cfg *config.Config, version Version, timeout time.Duration
 		opt(client)
 	}
 
+	if client.cacheStore != nil {
+		client.cache = newResponseCache(client.cacheStore, client.cacheSettings, version, client.url)
+	}
+
+	client.httpClient.Transport = executor.NewTransport(client.httpClient.Transport, serviceName, cfg, &client.transport)
+
+	client.executor = executor.New(serviceName, cfg, &client.httpClient,
+		executor.WithTracerName(client.tracerName),
+		executor.WithMeterName(client.meterName),
+		executor.WithAPIVersion(string(version)),
+		executor.WithInterceptors(client.interceptors...),
+		executor.WithLogger(client.logger),
+	)
+
 	return client, nil
 }
 
@@ -451,11 +312,13 @@ func (c *Client) GetStructuralResultWithContext(ctx context.Context, lat, lon fl
 	if err != nil {
 		return nil, err
 	}
-	response := c.convertComponentIntoStructureModel(components)
+	response := NewStructuralComponent(components)
 	return response, nil
 }
 
-func (c *Client) convertComponentIntoStructureModel(components []Component) *StructuralComponent {
+// NewStructuralComponent converts Component s of an address into a StructuralComponent, like GetStructuralResult.
+// components with unknown types are set as ClosedWay.
+func NewStructuralComponent(components []Component) *StructuralComponent {
 	response := &StructuralComponent{}
 	for _, component := range components {
 		if _, ok := convertReverseTypes[component.Type]; ok {
@@ -2,12 +2,16 @@ package reverse
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 )
 
 func TestNewReverseClient(t *testing.T) {
@@ -149,6 +153,9 @@ func TestClient_GetComponents(t *testing.T) {
 		if err == nil {
 			t.Fatalf("there should be an error. status is ERROR")
 		}
+		if !errors.Is(err, smapperrors.ErrStatusNotOK) {
+			t.Fatalf("err should be smapperrors.ErrStatusNotOK but it is: %s", err.Error())
+		}
 	})
 	t.Run("non_200_status", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -175,6 +182,13 @@ func TestClient_GetComponents(t *testing.T) {
 		if err == nil {
 			t.Fatalf("there should be an error. status is 500")
 		}
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err should be of type *smapperrors.APIError but it is %T", err)
+		}
+		if apiErr.StatusCode != 500 {
+			t.Fatalf("apiErr.StatusCode should be %d but it is %d", 500, apiErr.StatusCode)
+		}
 	})
 	t.Run("timeout", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -230,6 +244,9 @@ func TestClient_GetComponentsWithContext(t *testing.T) {
 		if err == nil {
 			t.Fatalf("there should be an error when creating request")
 		}
+		if !errors.Is(err, smapperrors.ErrNilContext) {
+			t.Fatalf("err should be smapperrors.ErrNilContext but it is: %s", err.Error())
+		}
 	})
 }
 
@@ -507,6 +524,9 @@ func TestClient_GetFrequent(t *testing.T) {
 		if err == nil {
 			t.Fatalf("could not get components: %s", err.Error())
 		}
+		if !errors.Is(err, smapperrors.ErrInvalidInput) {
+			t.Fatalf("err should be smapperrors.ErrInvalidInput but it is: %s", err.Error())
+		}
 	})
 	t.Run("not_provided_response_version", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -711,3 +731,60 @@ func TestClient_GetStructuralResultWithContext(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_GetBatch(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"result":{"components":[{"name":"تهران","type":"city"}]}}]}`))
+		}))
+		defer sv.Close()
+
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		client, err := NewReverseClient(cfg, V1, time.Second, WithURL(sv.URL))
+		if err != nil {
+			t.Fatalf("could not create reverse client due to: %s", err.Error())
+		}
+		results, err := client.GetBatch(BatchReverseRequest{Requests: []Request{{Lat: 35.77, Lon: 51.41, ID: 1}}})
+		if err != nil {
+			t.Fatalf("could not get batch results: %s", err.Error())
+		}
+		if len(results) != 1 || results[0].ID != 1 {
+			t.Fatalf("there should be 1 result with id 1")
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		var calls int32
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set(retry.RetryAfterHeader, "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"result":{"components":[{"name":"تهران","type":"city"}]}}]}`))
+		}))
+		defer sv.Close()
+
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		client, err := NewReverseClient(cfg, V1, time.Second, WithURL(sv.URL), WithRetryPolicy(retry.DefaultPolicy()))
+		if err != nil {
+			t.Fatalf("could not create reverse client due to: %s", err.Error())
+		}
+		results, err := client.GetBatch(BatchReverseRequest{Requests: []Request{{Lat: 35.77, Lon: 51.41, ID: 1}}})
+		if err != nil {
+			t.Fatalf("there should not be an error after retry: %s", err.Error())
+		}
+		if calls != 2 {
+			t.Fatalf("server should be called 2 times but it is called %d times", calls)
+		}
+		if len(results) != 1 {
+			t.Fatalf("there should be 1 result")
+		}
+	})
+}
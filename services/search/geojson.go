@@ -0,0 +1,75 @@
+package search
+
+import (
+	"encoding/json"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+// MarshalGeoJSON encodes r as a GeoJSON Point Feature of its location, with its place id as the id of the feature and
+// its other fields as properties.
+func (r Result) MarshalGeoJSON() ([]byte, error) {
+	ll, err := r.LatLng()
+	if err != nil {
+		return nil, err
+	}
+	properties, err := geo.Properties(r, "location")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geo.NewFeature(r.PlaceID, geo.PointGeometry(ll), properties))
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON Point Feature encoded by MarshalGeoJSON into r. coordinates are formatted like
+// the responses of the service, so trailing zeros of the original strings are not kept.
+func (r *Result) UnmarshalGeoJSON(data []byte) error {
+	f, err := geo.DecodeFeature(data)
+	if err != nil {
+		return err
+	}
+	ll, err := f.Geometry.Point()
+	if err != nil {
+		return err
+	}
+	result := Result{}
+	if err := geo.DecodeProperties(f.Properties, &result); err != nil {
+		return err
+	}
+	result.SetLatLng(ll)
+	*r = result
+	return nil
+}
+
+// MarshalGeoJSON encodes c as a GeoJSON Point Feature of its centroid, with its id as the id of the feature and its
+// other fields as properties.
+func (c City) MarshalGeoJSON() ([]byte, error) {
+	ll, err := c.LatLng()
+	if err != nil {
+		return nil, err
+	}
+	properties, err := geo.Properties(c, "centroid")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geo.NewFeature(c.ID, geo.PointGeometry(ll), properties))
+}
+
+// UnmarshalGeoJSON decodes a GeoJSON Point Feature encoded by MarshalGeoJSON into c. coordinates are formatted like
+// the responses of the service, so trailing zeros of the original strings are not kept.
+func (c *City) UnmarshalGeoJSON(data []byte) error {
+	f, err := geo.DecodeFeature(data)
+	if err != nil {
+		return err
+	}
+	ll, err := f.Geometry.Point()
+	if err != nil {
+		return err
+	}
+	city := City{}
+	if err := geo.DecodeProperties(f.Properties, &city); err != nil {
+		return err
+	}
+	city.SetLatLng(ll)
+	*c = city
+	return nil
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestResult_GeoJSON(t *testing.T) {
+	r := Result{PlaceID: "p1", Name: "Azadi Tower", Type: "landmark", Distance: 1200.5, AllTags: []string{"tower"}}
+	r.StructuredFormatting.MainText = "Azadi Tower"
+	r.SetLatLng(geo.LatLng{Lat: 35.6997, Lon: 51.338})
+
+	data, err := r.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	f, err := geo.DecodeFeature(data)
+	if err != nil {
+		t.Fatalf("result should be encoded as a feature: %s", err)
+	}
+	if f.ID != "p1" || f.Properties["name"] != "Azadi Tower" || f.Properties["location"] != nil {
+		t.Fatalf("unexpected feature: %s", data)
+	}
+
+	var decoded Result
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Fatalf("result should round-trip:\n got %+v\nwant %+v", decoded, r)
+	}
+
+	if _, err := (Result{}).MarshalGeoJSON(); err == nil {
+		t.Fatal("MarshalGeoJSON of a result without location should return error")
+	}
+	if err := decoded.UnmarshalGeoJSON([]byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[51.3]},"properties":{}}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("invalid point should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
+
+func TestCity_GeoJSON(t *testing.T) {
+	c := City{ID: 1, Name: "Tehran", Description: "Capital"}
+	c.Metadata.CityDetail.HexagonId = 617733123439853567
+	c.SetLatLng(geo.LatLng{Lat: 35.7, Lon: 51.4})
+
+	data, err := c.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON should not return error: %s", err)
+	}
+	var decoded City
+	if err := decoded.UnmarshalGeoJSON(data); err != nil {
+		t.Fatalf("UnmarshalGeoJSON should not return error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, c) {
+		t.Fatalf("city should round-trip:\n got %+v\nwant %+v", decoded, c)
+	}
+
+	if err := decoded.UnmarshalGeoJSON([]byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[51.4,35.7]},"properties":{"id":"one"}}`)); !errors.Is(err, geo.ErrInvalidGeoJSON) {
+		t.Fatalf("invalid properties should return geo.ErrInvalidGeoJSON but it returned %v", err)
+	}
+}
@@ -1,5 +1,12 @@
 package search
 
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
 // City is the struct for city candidate in city search
 type City struct {
 	ID       int    `json:"id"`
@@ -47,3 +54,49 @@ type Detail struct {
 		} `json:"location"`
 	} `json:"geometry"`
 }
+
+// LatLng parses the centroid of c.
+func (c City) LatLng() (geo.LatLng, error) {
+	return parseLatLng(c.Centroid.Latitude, c.Centroid.Longitude)
+}
+
+// SetLatLng sets the centroid of c to ll, formatted like the responses of the service.
+func (c *City) SetLatLng(ll geo.LatLng) {
+	c.Centroid.Latitude, c.Centroid.Longitude = formatCoordinate(ll.Lat), formatCoordinate(ll.Lon)
+}
+
+// LatLng parses the location of r.
+func (r Result) LatLng() (geo.LatLng, error) {
+	return parseLatLng(r.Location.Latitude, r.Location.Longitude)
+}
+
+// SetLatLng sets the location of r to ll, formatted like the responses of the service.
+func (r *Result) SetLatLng(ll geo.LatLng) {
+	r.Location.Latitude, r.Location.Longitude = formatCoordinate(ll.Lat), formatCoordinate(ll.Lon)
+}
+
+// LatLng returns the location of d.
+func (d Detail) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: d.Geometry.Location.Lat, Lon: d.Geometry.Location.Lng}
+}
+
+// parseLatLng parses the string coordinates of search responses and validates them.
+func parseLatLng(lat, lon string) (geo.LatLng, error) {
+	var ll geo.LatLng
+	var err error
+	if ll.Lat, err = strconv.ParseFloat(lat, 64); err != nil {
+		return geo.LatLng{}, fmt.Errorf("%w: %w", geo.ErrInvalidLatitude, err)
+	}
+	if ll.Lon, err = strconv.ParseFloat(lon, 64); err != nil {
+		return geo.LatLng{}, fmt.Errorf("%w: %w", geo.ErrInvalidLongitude, err)
+	}
+	if err := ll.Validate(); err != nil {
+		return geo.LatLng{}, err
+	}
+	return ll, nil
+}
+
+// formatCoordinate formats a coordinate like the string coordinates of search responses.
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
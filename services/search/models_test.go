@@ -0,0 +1,53 @@
+package search
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestResult_LatLng(t *testing.T) {
+	var r Result
+	r.SetLatLng(geo.LatLng{Lat: 35.6997, Lon: 51.338})
+	if r.Location.Latitude != "35.6997" || r.Location.Longitude != "51.338" {
+		t.Fatalf("location should be formatted like responses but it is %+v", r.Location)
+	}
+	ll, err := r.LatLng()
+	if err != nil {
+		t.Fatalf("LatLng should not return error: %s", err)
+	}
+	if ll != (geo.LatLng{Lat: 35.6997, Lon: 51.338}) {
+		t.Fatalf("unexpected coordinate: %+v", ll)
+	}
+
+	r.Location.Latitude = "north"
+	if _, err := r.LatLng(); !errors.Is(err, geo.ErrInvalidLatitude) {
+		t.Fatalf("invalid latitude should be geo.ErrInvalidLatitude but it is %v", err)
+	}
+	r.Location.Latitude, r.Location.Longitude = "35.6997", "251.338"
+	if _, err := r.LatLng(); !errors.Is(err, geo.ErrInvalidLongitude) {
+		t.Fatalf("out of range longitude should be geo.ErrInvalidLongitude but it is %v", err)
+	}
+}
+
+func TestCity_LatLng(t *testing.T) {
+	var c City
+	c.SetLatLng(geo.LatLng{Lat: 35.7, Lon: 51.4})
+	ll, err := c.LatLng()
+	if err != nil || ll != (geo.LatLng{Lat: 35.7, Lon: 51.4}) {
+		t.Fatalf("unexpected centroid %+v, err: %v", ll, err)
+	}
+
+	if _, err := (City{}).LatLng(); err == nil {
+		t.Fatal("LatLng of a city without centroid should return error")
+	}
+}
+
+func TestDetail_LatLng(t *testing.T) {
+	var d Detail
+	d.Geometry.Location.Lat, d.Geometry.Location.Lng = 35.7, 51.4
+	if d.LatLng() != (geo.LatLng{Lat: 35.7, Lon: 51.4}) {
+		t.Fatalf("unexpected location: %+v", d.LatLng())
+	}
+}
@@ -2,16 +2,11 @@ package search
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
-	"github.com/snapp-incubator/smapp-sdk-go/version"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/trace"
-	"io"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/internal/executor"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -55,14 +50,21 @@ const (
 
 	OKStatus    = "OK"
 	ErrorStatus = "ERROR"
+
+	serviceName = "search"
 )
 
 // Client is the main implementation of Interface for search service
 type Client struct {
-	cfg        *config.Config
-	url        string
-	httpClient http.Client
-	tracerName string
+	cfg          *config.Config
+	url          string
+	httpClient   http.Client
+	tracerName   string
+	meterName    string
+	transport    executor.TransportOptions
+	interceptors []interceptor.Interceptor
+	logger       *slog.Logger
+	executor     *executor.Executor
 }
 
 // Force Client to implement Interface at compile time
@@ -75,27 +77,8 @@ func (c *Client) GetCities(options CallOptions) ([]City, error) {
 
 // GetCitiesWithContext is like GetCities, but with context.Context support.
 func (c *Client) GetCitiesWithContext(ctx context.Context, options CallOptions) ([]City, error) {
-	if ctx == nil {
-		return nil, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "get-cities")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	reqURL := fmt.Sprintf("%s/place/cities", c.url)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return nil, errors.New("smapp search get-cities: could not create request. err: " + err.Error())
-	}
-
+	const operation = "get-cities"
 	params := url.Values{}
-
 	if options.UseLocation {
 		locationString := fmt.Sprintf("%f,%f", options.Location.Lat, options.Location.Lon)
 		params.Set(Location, locationString)
@@ -109,69 +92,22 @@ func (c *Client) GetCitiesWithContext(ctx context.Context, options CallOptions)
 		params.Set(ReqContext, string(options.RequestContext))
 	}
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return nil, fmt.Errorf("smapp search get-cities: invalid api key source: %s", string(c.cfg.APIKeySource))
+	var resp struct {
+		Status      string `json:"status"`
+		Predictions []City `json:"predictions"`
 	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       fmt.Sprintf("%s/place/cities", c.url),
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return nil, fmt.Errorf("smapp search get-cities: could not make a request due to this error: %s", err.Error())
-	}
-
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status      string `json:"status"`
-			Predictions []City `json:"predictions"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return nil, fmt.Errorf("smapp search get-cities: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.SetStatus(codes.Error, "status not OK")
-			responseSpan.End()
-			return nil, errors.New("smapp search get-cities: status of request is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Predictions, nil
+		return nil, err
 	}
 
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.SetAttributes(attribute.Int("status_code", response.StatusCode))
-	responseSpan.End()
-	return nil, fmt.Errorf("smapp search get-cities: non 200 status: %d", response.StatusCode)
+	return resp.Predictions, nil
 }
 
 // SearchCity  receives an input string for search and CallOptions and returns list of City s according to input string.
@@ -181,27 +117,8 @@ func (c *Client) SearchCity(input string, options CallOptions) ([]City, error) {
 
 // SearchCityWithContext is like SearchCity, but with context.Context support.
 func (c *Client) SearchCityWithContext(ctx context.Context, input string, options CallOptions) ([]City, error) {
-	if ctx == nil {
-		return nil, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "search-cities")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	reqURL := fmt.Sprintf("%s/place/search/city", c.url)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return nil, errors.New("smapp search search-cities: could not create request. err: " + err.Error())
-	}
-
+	const operation = "search-cities"
 	params := url.Values{}
-
 	params.Set(Input, input)
 
 	if options.UseLocation {
@@ -217,68 +134,22 @@ func (c *Client) SearchCityWithContext(ctx context.Context, input string, option
 		params.Set(ReqContext, string(options.RequestContext))
 	}
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return nil, fmt.Errorf("smapp search search-cities: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
+	var resp struct {
+		Status      string `json:"status"`
+		Predictions []City `json:"predictions"`
 	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       fmt.Sprintf("%s/place/search/city", c.url),
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return nil, fmt.Errorf("smapp search search-cities: could not make a request due to this error: %s", err.Error())
+		return nil, err
 	}
 
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status      string `json:"status"`
-			Predictions []City `json:"predictions"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return nil, fmt.Errorf("smapp search search-cities: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.SetStatus(codes.Error, "status not OK")
-			responseSpan.End()
-			return nil, errors.New("smapp search search-cities: status of request is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Predictions, nil
-	}
-
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.SetAttributes(attribute.Int("status_code", response.StatusCode))
-	responseSpan.End()
-	return nil, fmt.Errorf("smapp search search-cities: non 200 status: %d", response.StatusCode)
+	return resp.Predictions, nil
 }
 
 // AutoComplete receives an input string and CallOptions and returns all possible Result s according to input string.
@@ -288,27 +159,8 @@ func (c *Client) AutoComplete(input string, options CallOptions) ([]Result, erro
 
 // AutoCompleteWithContext is like AutoComplete, but with context.Context support.
 func (c *Client) AutoCompleteWithContext(ctx context.Context, input string, options CallOptions) ([]Result, error) {
-	if ctx == nil {
-		return nil, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "autocomplete")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	reqURL := fmt.Sprintf("%s/place/autocomplete/json", c.url)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return nil, errors.New("smapp search autocomplete: could not create request. err: " + err.Error())
-	}
-
+	const operation = "autocomplete"
 	params := url.Values{}
-
 	params.Set(Input, input)
 
 	if options.UseLocation {
@@ -333,69 +185,22 @@ func (c *Client) AutoCompleteWithContext(ctx context.Context, input string, opti
 		params.Set(CityID, strconv.Itoa(options.CityID))
 	}
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return nil, fmt.Errorf("smapp search autocomplete: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
+	var resp struct {
+		Status      string   `json:"status"`
+		Predictions []Result `json:"predictions"`
 	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       fmt.Sprintf("%s/place/autocomplete/json", c.url),
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return nil, fmt.Errorf("smapp search autocomplete: could not make a request due to this error: %s", err.Error())
-	}
-
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status      string   `json:"status"`
-			Predictions []Result `json:"predictions"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return nil, fmt.Errorf("smapp search autocomplete: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.SetStatus(codes.Error, "status not OK")
-			responseSpan.End()
-			return nil, errors.New("smapp search autocomplete: status of request is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Predictions, nil
+		return nil, err
 	}
 
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.SetAttributes(attribute.Int("status_code", response.StatusCode))
-	responseSpan.End()
-	return nil, fmt.Errorf("smapp search autocomplete: non 200 status: %d", response.StatusCode)
+	return resp.Predictions, nil
 }
 
 // Details receives a `placeId` string and CallOptions and returns Details on that place id.
@@ -405,92 +210,26 @@ func (c *Client) Details(placeId string, options CallOptions) (Detail, error) {
 
 // DetailsWithContext is like Details, but with context.Context support.
 func (c *Client) DetailsWithContext(ctx context.Context, placeId string, options CallOptions) (Detail, error) {
-	if ctx == nil {
-		return Detail{}, fmt.Errorf("smapp reverse geo-code: nil context")
-	}
-	// Start of parent span
-	var span trace.Span
-	ctx, span = otel.Tracer(c.tracerName).Start(ctx, "details")
-	defer span.End()
-
-	var reqInitSpan trace.Span
-	ctx, reqInitSpan = otel.Tracer(c.tracerName).Start(ctx, "request-initialization")
-
-	reqURL := fmt.Sprintf("%s/place/details/json", c.url)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		reqInitSpan.RecordError(err)
-		reqInitSpan.End()
-		return Detail{}, errors.New("smapp search details: could not create request. err: " + err.Error())
-	}
-
+	const operation = "details"
 	params := url.Values{}
-
 	params.Set(PlaceID, placeId)
 
-	switch c.cfg.APIKeySource {
-	case config.HeaderSource:
-		req.Header.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	case config.QueryParamSource:
-		params.Set(c.cfg.APIKeyName, c.cfg.APIKey)
-	default:
-		reqInitSpan.SetStatus(codes.Error, "invalid api key source")
-		reqInitSpan.End()
-		return Detail{}, fmt.Errorf("smapp search details: invalid api key source: %s", string(c.cfg.APIKeySource))
-	}
-
-	for key, val := range options.Headers {
-		req.Header.Set(key, val)
-	}
-
-	req.Header.Set(version.UserAgentHeader, version.GetUserAgent())
-
-	req.URL.RawQuery = params.Encode()
-
-	reqInitSpan.End()
-
-	response, err := c.httpClient.Do(req)
+	var resp struct {
+		Status string `json:"status"`
+		Result Detail `json:"result"`
+	}
+	err := c.executor.Do(ctx, executor.Request{
+		Operation: operation,
+		URL:       fmt.Sprintf("%s/place/details/json", c.url),
+		Query:     params,
+		Headers:   options.Headers,
+		Timeout:   options.Timeout,
+	}, executor.JSONWithStatus(&resp, &resp.Status, OKStatus))
 	if err != nil {
-		return Detail{}, fmt.Errorf("smapp search details: could not make a request due to this error: %s", err.Error())
+		return Detail{}, err
 	}
 
-	//nolint
-	var responseSpan trace.Span
-	//nolint
-	ctx, responseSpan = otel.Tracer(c.tracerName).Start(ctx, "response-deserialization")
-
-	defer func() {
-		_, _ = io.Copy(io.Discard, response.Body)
-		_ = response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusOK {
-		resp := struct {
-			Status string `json:"status"`
-			Result Detail `json:"result"`
-		}{}
-
-		err := json.NewDecoder(response.Body).Decode(&resp)
-		if err != nil {
-			responseSpan.RecordError(err)
-			responseSpan.End()
-			return Detail{}, fmt.Errorf("smapp search details: could not serialize response due to: %s", err.Error())
-		}
-
-		if strings.ToUpper(resp.Status) != OKStatus {
-			responseSpan.SetStatus(codes.Error, "status not OK")
-			responseSpan.End()
-			return Detail{}, fmt.Errorf("smapp search details: request status is not OK")
-		}
-
-		responseSpan.End()
-		return resp.Result, nil
-	}
-
-	responseSpan.SetStatus(codes.Error, "non 200 status code")
-	responseSpan.SetAttributes(attribute.Int("status_code", response.StatusCode))
-	responseSpan.End()
-	return Detail{}, fmt.Errorf("smapp search details: non 200 status: %d", response.StatusCode)
+	return resp.Result, nil
 }
 
 // NewSearchClient is the constructor of search client.
@@ -508,6 +247,16 @@ func NewSearchClient(cfg *config.Config, version Version, timeout time.Duration,
 		opt(client)
 	}
 
+	client.httpClient.Transport = executor.NewTransport(client.httpClient.Transport, serviceName, cfg, &client.transport)
+
+	client.executor = executor.New(serviceName, cfg, &client.httpClient,
+		executor.WithTracerName(client.tracerName),
+		executor.WithMeterName(client.meterName),
+		executor.WithAPIVersion(string(version)),
+		executor.WithInterceptors(client.interceptors...),
+		executor.WithLogger(client.logger),
+	)
+
 	return client, nil
 }
 
@@ -3,9 +3,13 @@ package search
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/ratelimit"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -269,6 +273,9 @@ func TestClient_GetCities(t *testing.T) {
 		if err == nil {
 			t.Fatalf("request status should not be ok")
 		}
+		if !errors.Is(err, smapperrors.ErrStatusNotOK) {
+			t.Fatalf("err should be smapperrors.ErrStatusNotOK but it is: %s", err.Error())
+		}
 	})
 	t.Run("non_200_status", func(t *testing.T) {
 		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -296,6 +303,13 @@ func TestClient_GetCities(t *testing.T) {
 		if err == nil {
 			t.Fatalf("request status should not be 200")
 		}
+		var apiErr *smapperrors.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("err should be of type *smapperrors.APIError but it is %T", err)
+		}
+		if apiErr.StatusCode != 500 {
+			t.Fatalf("apiErr.StatusCode should be %d but it is %d", 500, apiErr.StatusCode)
+		}
 	})
 }
 
@@ -780,3 +794,101 @@ func TestClient_Details_ErrorStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_AutoComplete_RateLimit(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write(response)
+	}))
+	defer sv.Close()
+
+	limiter := ratelimit.New(ratelimit.Unlimited,
+		ratelimit.WithEndpointLimit("search/autocomplete", ratelimit.Limit{Rate: 0.1, Burst: 1}),
+		ratelimit.WithMode(ratelimit.NoWaitMode),
+	)
+	cfg, err := config.NewDefaultConfig("key", config.WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	// clients built from the same config share the limiter.
+	first, err := NewSearchClient(cfg, V1, time.Second, WithURL(sv.URL))
+	if err != nil {
+		t.Fatalf("could not create search client due to: %s", err.Error())
+	}
+	second, err := NewSearchClient(cfg, V1, time.Second, WithURL(sv.URL))
+	if err != nil {
+		t.Fatalf("could not create search client due to: %s", err.Error())
+	}
+
+	if _, err := first.AutoComplete("tehran", NewDefaultCallOptions()); err != nil {
+		t.Fatalf("first request should not return error: %s", err.Error())
+	}
+	_, err = second.AutoComplete("tehran", NewDefaultCallOptions())
+	if !errors.Is(err, smapperrors.ErrRateLimited) {
+		t.Fatalf("err should be smapperrors.ErrRateLimited but it is: %v", err)
+	}
+	if _, err := second.Details("id", NewDefaultCallOptions()); err != nil {
+		t.Fatalf("details should not be limited: %s", err.Error())
+	}
+	if calls != 2 {
+		t.Fatalf("server should be called 2 times but it is called %d times", calls)
+	}
+}
+
+func TestClient_AutoComplete_Coalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write(response)
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key")
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+	client, err := NewSearchClient(cfg, V1, time.Second, WithURL(sv.URL), WithRequestCoalescing())
+	if err != nil {
+		t.Fatalf("could not create search client due to: %s", err.Error())
+	}
+
+	const callers = 5
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		callCtx := context.Background()
+		if i == 0 {
+			callCtx = ctx
+		}
+		go func() {
+			_, err := client.AutoCompleteWithContext(callCtx, "tehran", NewDefaultCallOptions())
+			errs <- err
+		}()
+	}
+
+	// the first caller gives up, while the others are still waiting.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	var cancelled int
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("err should be nil or context.Canceled but it is %v", err)
+			}
+			cancelled++
+		}
+	}
+	if cancelled != 1 {
+		t.Fatalf("only the cancelled caller should fail but %d callers failed", cancelled)
+	}
+	if calls != 1 {
+		t.Fatalf("server should be called once but it is called %d times", calls)
+	}
+}
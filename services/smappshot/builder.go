@@ -33,6 +33,7 @@ type RideRequestBuilder struct {
 	origin         *Location
 	destinations   []Location
 	markerType     MarkerType
+	err            error
 }
 
 // NewRideRequestBuilder creates a builder for the ride photo URL.
@@ -98,6 +99,27 @@ func (b *RideRequestBuilder) WithDestinations(dests []Location) *RideRequestBuil
 	return b
 }
 
+// WithRoutePolyline sets route mode from an encoded polyline, e.g. a route of trip history: its first point is the
+// origin and the others are destinations. precision is the number of decimal digits the polyline is encoded with,
+// geo.PolylinePrecision for Google polylines. Long routes should be simplified, e.g. by geo.SimplifyN, to keep the
+// URL short. Clears Here. Errors of decoding are returned by Build.
+func (b *RideRequestBuilder) WithRoutePolyline(polyline string, precision int) *RideRequestBuilder {
+	b.here = nil
+	locations, err := LocationsFromPolyline(polyline, precision)
+	if err != nil {
+		b.err = fmt.Errorf("smapp smappshot: invalid route polyline: %w", err)
+		return b
+	}
+	b.err = nil
+	if len(locations) < 2 {
+		b.err = fmt.Errorf("smapp smappshot: route polyline should have at least two points, got %d", len(locations))
+		return b
+	}
+	b.origin = &locations[0]
+	b.destinations = locations[1:]
+	return b
+}
+
 func (b *RideRequestBuilder) WithMarkerType(mt MarkerType) *RideRequestBuilder {
 	b.markerType = mt
 	return b
@@ -140,6 +162,9 @@ func (b *RideRequestBuilder) Build() (string, error) {
 }
 
 func (b *RideRequestBuilder) validate() error {
+	if b.err != nil {
+		return b.err
+	}
 	if b.baseURL == "" {
 		return fmt.Errorf("smapp smappshot: baseURL is required")
 	}
@@ -0,0 +1,46 @@
+package smappshot
+
+import "time"
+
+// Client creates ride and preview builders sharing the same base URL, secret, version and expiry.
+// it is safe for concurrent use, since each call returns a new builder.
+//
+// Usage:
+//
+//	client := smappshot.NewClient(baseURL, secret, smappshot.V1)
+//	url, err := client.Ride().
+//	    WithHere(smappshot.Location{Lon: 51.338, Lat: 35.699}).
+//	    Build()
+type Client struct {
+	baseURL        string
+	secret         string
+	version        Version
+	expiryDuration time.Duration
+}
+
+// NewClient creates a Client for the given base URL, signing secret and version.
+func NewClient(baseURL string, secret string, version Version) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		secret:         secret,
+		version:        version,
+		expiryDuration: defaultExpiryDuration,
+	}
+}
+
+// WithExpiry returns a copy of the client whose builders sign URLs valid for d. Default is 10 minutes.
+func (c *Client) WithExpiry(d time.Duration) *Client {
+	clone := *c
+	clone.expiryDuration = d
+	return &clone
+}
+
+// Ride creates a builder for the ride photo URL.
+func (c *Client) Ride() *RideRequestBuilder {
+	return NewRideRequestBuilder(c.baseURL, c.secret, c.version).WithExpiry(c.expiryDuration)
+}
+
+// Preview creates a builder for the preview photo URL.
+func (c *Client) Preview() *PreviewRequestBuilder {
+	return NewPreviewRequestBuilder(c.baseURL, c.secret, c.version).WithExpiry(c.expiryDuration)
+}
@@ -0,0 +1,68 @@
+package smappshot
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_Ride(t *testing.T) {
+	client := NewClient(testBaseURL, testSecret, V1)
+
+	rawURL, err := client.Ride().
+		WithHere(Location{Lon: 51.338, Lat: 35.699}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, _, sig := parseSigned(t, rawURL)
+	if path != "/api/v1/photo/ride" {
+		t.Errorf("path = %q", path)
+	}
+	if sig == "" {
+		t.Error("sig param missing")
+	}
+}
+
+func TestClient_Preview(t *testing.T) {
+	client := NewClient(testBaseURL, testSecret, V2)
+
+	rawURL, err := client.Preview().
+		WithCenter(Location{Lon: 51.338, Lat: 35.699}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, _, _ := parseSigned(t, rawURL)
+	if path != "/api/v2/photo/preview" {
+		t.Errorf("path = %q", path)
+	}
+}
+
+func TestClient_WithExpiry(t *testing.T) {
+	client := NewClient(testBaseURL, testSecret, V1)
+	short := client.WithExpiry(time.Minute)
+
+	if client.expiryDuration != defaultExpiryDuration {
+		t.Errorf("WithExpiry should not change the original client")
+	}
+
+	before := time.Now().Add(time.Minute).Unix()
+	rawURL, err := short.Ride().
+		WithHere(Location{Lon: 51.338, Lat: 35.699}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, params, _ := parseSigned(t, rawURL)
+	expires, err := strconv.ParseInt(params.Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid expires param: %v", err)
+	}
+	if expires < before || expires > before+2 {
+		t.Errorf("expires = %d, want about %d", expires, before)
+	}
+}
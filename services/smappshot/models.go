@@ -1,5 +1,7 @@
 package smappshot
 
+import "github.com/snapp-incubator/smapp-sdk-go/geo"
+
 // Version represents the SmappShot API version.
 type Version string
 
@@ -39,3 +41,32 @@ type Location struct {
 	Lon float64 // longitude
 	Lat float64 // latitude
 }
+
+// LocationFromLatLng creates a Location of ll.
+func LocationFromLatLng(ll geo.LatLng) Location {
+	return Location{Lon: ll.Lon, Lat: ll.Lat}
+}
+
+// LocationsFromLatLngs converts a list of coordinates, e.g. destinations of a ride, to a list of Location s.
+func LocationsFromLatLngs(lls []geo.LatLng) []Location {
+	locations := make([]Location, 0, len(lls))
+	for _, ll := range lls {
+		locations = append(locations, LocationFromLatLng(ll))
+	}
+	return locations
+}
+
+// LocationsFromPolyline decodes an encoded polyline, e.g. a route of trip history, to a list of Location s. precision
+// is the number of decimal digits the polyline is encoded with, geo.PolylinePrecision for Google polylines.
+func LocationsFromPolyline(polyline string, precision int) ([]Location, error) {
+	lls, err := geo.DecodePolyline(polyline, precision)
+	if err != nil {
+		return nil, err
+	}
+	return LocationsFromLatLngs(lls), nil
+}
+
+// LatLng returns the coordinate of l.
+func (l Location) LatLng() geo.LatLng {
+	return geo.LatLng{Lat: l.Lat, Lon: l.Lon}
+}
@@ -0,0 +1,37 @@
+package smappshot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+)
+
+func TestLocation_LatLng(t *testing.T) {
+	ll := geo.LatLng{Lat: 35.7, Lon: 51.4}
+	loc := LocationFromLatLng(ll)
+	if formatLocation(loc) != "51.4,35.7" {
+		t.Fatalf("location should be formatted lon first but it is %s", formatLocation(loc))
+	}
+	if loc.LatLng() != ll {
+		t.Fatalf("LatLng should return the coordinate of the location but it returned %+v", loc.LatLng())
+	}
+
+	locations := LocationsFromLatLngs([]geo.LatLng{ll, {Lat: 35.8, Lon: 51.5}})
+	if len(locations) != 2 || locations[1] != (Location{Lon: 51.5, Lat: 35.8}) {
+		t.Fatalf("unexpected locations: %+v", locations)
+	}
+}
+
+func TestLocationsFromPolyline(t *testing.T) {
+	locations, err := LocationsFromPolyline("_p~iF~ps|U_ulLnnqC", geo.PolylinePrecision)
+	if err != nil {
+		t.Fatalf("LocationsFromPolyline should not return error: %s", err)
+	}
+	if len(locations) != 2 || locations[1] != (Location{Lon: -120.95, Lat: 40.7}) {
+		t.Fatalf("unexpected locations: %+v", locations)
+	}
+	if _, err := LocationsFromPolyline("_p~iF", geo.PolylinePrecision); !errors.Is(err, geo.ErrInvalidPolyline) {
+		t.Fatalf("invalid polyline should return geo.ErrInvalidPolyline but it returned %v", err)
+	}
+}
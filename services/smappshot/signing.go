@@ -4,6 +4,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
@@ -12,6 +13,45 @@ import (
 	"time"
 )
 
+var (
+	// ErrInvalidSignature is returned by Verify for URLs whose signature does not match their path and params.
+	ErrInvalidSignature = errors.New("smapp smappshot: invalid signature")
+	// ErrExpired is returned by Verify for URLs whose expiry time is passed.
+	ErrExpired = errors.New("smapp smappshot: url is expired")
+)
+
+// Verify checks a signed URL like the SmappShot server: its sig param should be the signature of its path and other
+// params with secret, and its expires param should not be before now.
+func Verify(signedURL, secret string, now time.Time) error {
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		return fmt.Errorf("smapp smappshot: invalid url: %w", err)
+	}
+	params := u.Query()
+	sig, err := hex.DecodeString(params.Get("sig"))
+	if err != nil || len(sig) == 0 {
+		return ErrInvalidSignature
+	}
+	params.Del("sig")
+
+	expected, err := computeSignature(secret, u.Path, params)
+	if err != nil {
+		return fmt.Errorf("smapp smappshot: signing failed: %w", err)
+	}
+	if decoded, _ := hex.DecodeString(expected); !hmac.Equal(sig, decoded) {
+		return ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(params.Get("expires"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("smapp smappshot: invalid expires param: %w", err)
+	}
+	if now.Unix() > expires {
+		return fmt.Errorf("%w at %s", ErrExpired, time.Unix(expires, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
 // signURL appends expires, computes HMAC-SHA256 sig, returns full signed URL.
 func signURL(baseURL, path, secret string, expiry time.Duration, params url.Values) (string, error) {
 	p := make(url.Values, len(params)+1)
@@ -1,10 +1,13 @@
 package smappshot
 
 import (
+	"errors"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
 )
 
 const (
@@ -64,6 +67,48 @@ func TestRideRequestBuilder_RouteMode(t *testing.T) {
 	}
 }
 
+func TestRideRequestBuilder_RoutePolyline(t *testing.T) {
+	// origin 35.699,51.338 and destinations 35.72,51.4 and 35.73,51.42.
+	polyline, err := geo.EncodePolyline([]geo.LatLng{{Lat: 35.699, Lon: 51.338}, {Lat: 35.72, Lon: 51.4}, {Lat: 35.73, Lon: 51.42}}, geo.PolylinePrecision)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rawURL, err := NewRideRequestBuilder(testBaseURL, testSecret, V2).
+		WithHere(Location{Lon: 51.338, Lat: 35.699}).
+		WithRoutePolyline(polyline, geo.PolylinePrecision).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, params, _ := parseSigned(t, rawURL)
+	if params.Get("here") != "" {
+		t.Error("here should be cleared by the route")
+	}
+	if params.Get("origin") != "51.338,35.699" {
+		t.Errorf("origin = %q", params.Get("origin"))
+	}
+	if params.Get("destinations") != "51.4,35.72;51.42,35.73" {
+		t.Errorf("destinations = %q", params.Get("destinations"))
+	}
+}
+
+func TestRideRequestBuilder_ErrorInvalidRoutePolyline(t *testing.T) {
+	_, err := NewRideRequestBuilder(testBaseURL, testSecret, V1).
+		WithRoutePolyline("_p~iF~ps|U_ulL", geo.PolylinePrecision).
+		Build()
+	if !errors.Is(err, geo.ErrInvalidPolyline) {
+		t.Fatalf("expected geo.ErrInvalidPolyline, got %v", err)
+	}
+
+	_, err = NewRideRequestBuilder(testBaseURL, testSecret, V1).
+		WithRoutePolyline("_p~iF~ps|U", geo.PolylinePrecision).
+		Build()
+	if err == nil {
+		t.Fatal("expected error for route polyline with a single point")
+	}
+}
+
 func TestRideRequestBuilder_DefaultDimensions(t *testing.T) {
 	rawURL, err := NewRideRequestBuilder(testBaseURL, testSecret, V1).
 		WithHere(Location{Lon: 51.338, Lat: 35.699}).
@@ -380,6 +425,52 @@ func TestPreviewRequestBuilder_ErrorInvalidLanguage(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Verify
+// ---------------------------------------------------------------------------
+
+func TestVerify_Success(t *testing.T) {
+	rawURL, err := NewPreviewRequestBuilder(testBaseURL, testSecret, V2).
+		WithCenter(Location{Lon: 51.338, Lat: 35.699}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Verify(rawURL, testSecret, time.Now()); err != nil {
+		t.Errorf("Verify = %v, want nil", err)
+	}
+}
+
+func TestVerify_ErrorTampered(t *testing.T) {
+	rawURL, err := NewRideRequestBuilder(testBaseURL, testSecret, V1).
+		WithHere(Location{Lon: 51.338, Lat: 35.699}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tampered := strings.Replace(rawURL, "51.338", "51.339", 1)
+	if err := Verify(tampered, testSecret, time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(tampered) = %v, want ErrInvalidSignature", err)
+	}
+	if err := Verify(rawURL, "another-secret", time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(another secret) = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerify_ErrorExpired(t *testing.T) {
+	rawURL, err := NewRideRequestBuilder(testBaseURL, testSecret, V1).
+		WithHere(Location{Lon: 51.338, Lat: 35.699}).
+		WithExpiry(time.Minute).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Verify(rawURL, testSecret, time.Now().Add(2*time.Minute)); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify = %v, want ErrExpired", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // formatLocation
 // ---------------------------------------------------------------------------
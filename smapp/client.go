@@ -0,0 +1,187 @@
+package smapp
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	areagateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/services/smappshot"
+)
+
+// DefaultTimeout is the timeout of service clients if WithTimeout is not used.
+const DefaultTimeout = 5 * time.Second
+
+// ErrNilConfig is returned when NewClient is called with a nil config.
+var ErrNilConfig = errors.New("smapp: nil config")
+
+// Client holds the clients of all smapp services. fields are interfaces, so each of them can be replaced with
+// a mock or fake in tests.
+type Client struct {
+	Reverse      reverse.Interface
+	Search       search.Interface
+	ETA          eta.Interface
+	Matrix       matrix.Interface
+	AreaGateways areagateways.Interface
+	// SmappShot is nil unless WithSmappShot is used.
+	SmappShot *smappshot.Client
+
+	base      http.RoundTripper
+	transport http.RoundTripper
+}
+
+// NewClient builds the clients of all services from cfg. all services use version V1 and DefaultTimeout unless
+// overridden by options.
+func NewClient(cfg *config.Config, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	o := &options{
+		timeout:      DefaultTimeout,
+		reverse:      serviceOptions[reverse.Version, reverse.ConstructorOption]{version: reverse.V1},
+		search:       serviceOptions[search.Version, search.ConstructorOption]{version: search.V1},
+		eta:          serviceOptions[eta.Version, eta.ConstructorOption]{version: eta.V1},
+		matrix:       serviceOptions[matrix.Version, matrix.ConstructorOption]{version: matrix.V1},
+		areaGateways: serviceOptions[areagateways.Version, areagateways.ConstructorOption]{version: areagateways.V1},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	base := o.transport
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport := base
+	for i := len(o.middlewares) - 1; i >= 0; i-- {
+		transport = o.middlewares[i](transport)
+	}
+
+	client := &Client{
+		SmappShot: o.smappShot,
+		base:      base,
+		transport: transport,
+	}
+
+	var err error
+	client.Reverse, err = reverse.NewReverseClient(cfg, o.reverse.version, o.reverse.timeoutOr(o.timeout),
+		withShared(o, transport, sharedSetters[reverse.ConstructorOption]{
+			transport:    reverse.WithTransport,
+			tracing:      reverse.WithRequestOpenTelemetryTracing,
+			metrics:      reverse.WithOpenTelemetryMetrics,
+			interceptors: reverse.WithInterceptors,
+			logger:       reverse.WithLogger,
+			coalescing:   reverse.WithRequestCoalescing,
+		}, o.reverse.opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Search, err = search.NewSearchClient(cfg, o.search.version, o.search.timeoutOr(o.timeout),
+		withShared(o, transport, sharedSetters[search.ConstructorOption]{
+			transport:    search.WithTransport,
+			tracing:      search.WithRequestOpenTelemetryTracing,
+			metrics:      search.WithOpenTelemetryMetrics,
+			interceptors: search.WithInterceptors,
+			logger:       search.WithLogger,
+			coalescing:   search.WithRequestCoalescing,
+		}, o.search.opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ETA, err = eta.NewETAClient(cfg, o.eta.version, o.eta.timeoutOr(o.timeout),
+		withShared(o, transport, sharedSetters[eta.ConstructorOption]{
+			transport:    eta.WithTransport,
+			tracing:      eta.WithRequestOpenTelemetryTracing,
+			metrics:      eta.WithOpenTelemetryMetrics,
+			interceptors: eta.WithInterceptors,
+			logger:       eta.WithLogger,
+			coalescing:   eta.WithRequestCoalescing,
+		}, o.eta.opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Matrix, err = matrix.NewMatrixClient(cfg, o.matrix.version, o.matrix.timeoutOr(o.timeout),
+		withShared(o, transport, sharedSetters[matrix.ConstructorOption]{
+			transport:    matrix.WithTransport,
+			tracing:      matrix.WithRequestOpenTelemetryTracing,
+			metrics:      matrix.WithOpenTelemetryMetrics,
+			interceptors: matrix.WithInterceptors,
+			logger:       matrix.WithLogger,
+			coalescing:   matrix.WithRequestCoalescing,
+		}, o.matrix.opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.AreaGateways, err = areagateways.NewAreaGatewaysClient(cfg, o.areaGateways.version, o.areaGateways.timeoutOr(o.timeout),
+		withShared(o, transport, sharedSetters[areagateways.ConstructorOption]{
+			transport:    areagateways.WithTransport,
+			tracing:      areagateways.WithRequestOpenTelemetryTracing,
+			metrics:      areagateways.WithOpenTelemetryMetrics,
+			interceptors: areagateways.WithInterceptors,
+			logger:       areagateways.WithLogger,
+			coalescing:   areagateways.WithRequestCoalescing,
+		}, o.areaGateways.opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// sharedSetters holds the constructor options of a service used to apply the shared options.
+type sharedSetters[O any] struct {
+	transport    func(http.RoundTripper) O
+	tracing      func(string) O
+	metrics      func(string) O
+	interceptors func(...interceptor.Interceptor) O
+	logger       func(*slog.Logger) O
+	coalescing   func() O
+}
+
+// withShared prepends the shared options to the options of a service.
+func withShared[O any](o *options, transport http.RoundTripper, setters sharedSetters[O], opts []O) []O {
+	shared := []O{setters.transport(transport)}
+	if o.tracerName != "" {
+		shared = append(shared, setters.tracing(o.tracerName))
+	}
+	if o.meterName != "" {
+		shared = append(shared, setters.metrics(o.meterName))
+	}
+	if len(o.interceptors) > 0 {
+		shared = append(shared, setters.interceptors(o.interceptors...))
+	}
+	if o.logger != nil {
+		shared = append(shared, setters.logger(o.logger))
+	}
+	if o.coalescing {
+		shared = append(shared, setters.coalescing())
+	}
+	return append(shared, opts...)
+}
+
+// Transport returns the transport shared by all service clients, including middlewares.
+func (c *Client) Transport() http.RoundTripper {
+	return c.transport
+}
+
+// CloseIdleConnections closes the idle connections of the shared transport, if it supports it.
+func (c *Client) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if transport, ok := c.base.(closeIdler); ok {
+		transport.CloseIdleConnections()
+	}
+}
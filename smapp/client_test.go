@@ -0,0 +1,151 @@
+package smapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/services/smappshot"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("nil_config", func(t *testing.T) {
+		if _, err := NewClient(nil); !errors.Is(err, ErrNilConfig) {
+			t.Fatalf("err should be ErrNilConfig but it is %v", err)
+		}
+	})
+
+	t.Run("without_options", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("could not create client due to: %s", err.Error())
+		}
+
+		if client.Reverse == nil || client.Search == nil || client.ETA == nil || client.Matrix == nil || client.AreaGateways == nil {
+			t.Fatal("all service clients should be created")
+		}
+		if client.SmappShot != nil {
+			t.Fatal("SmappShot should be nil without WithSmappShot")
+		}
+		if _, ok := client.Transport().(*http.Transport); !ok {
+			t.Fatal("shared transport should be of type *http.Transport")
+		}
+		client.CloseIdleConnections()
+	})
+
+	t.Run("with_smappshot", func(t *testing.T) {
+		cfg, err := config.NewDefaultConfig("key")
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		client, err := NewClient(cfg, WithSmappShot("https://smappshot.example.com", "secret", smappshot.V1))
+		if err != nil {
+			t.Fatalf("could not create client due to: %s", err.Error())
+		}
+
+		url, err := client.SmappShot.Preview().WithCenter(smappshot.Location{Lat: 35.7, Lon: 51.4}).Build()
+		if err != nil {
+			t.Fatalf("could not build preview url due to: %s", err.Error())
+		}
+		if !strings.HasPrefix(url, "https://smappshot.example.com/api/v1/photo/preview") {
+			t.Fatalf("unexpected preview url: %s", url)
+		}
+	})
+}
+
+func TestClient_SharedTransport(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer sv.Close()
+
+	cfg, err := config.NewDefaultConfig("key", config.WithAPIBaseURL(sv.URL))
+	if err != nil {
+		t.Fatalf("could not create default config due to: %s", err.Error())
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var paths []string
+	var services []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				if name == "inner" {
+					paths = append(paths, req.URL.Path)
+				}
+				mu.Unlock()
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	client, err := NewClient(cfg,
+		WithTimeout(time.Second),
+		WithRequestOpenTelemetryTracing("smapp-test"),
+		WithMiddlewares(middleware("outer"), middleware("inner")),
+		WithInterceptors(interceptor.Funcs{
+			OnErrorFunc: func(_ context.Context, call interceptor.Call, _ error) {
+				mu.Lock()
+				services = append(services, call.Service)
+				mu.Unlock()
+			},
+		}),
+		WithETA(eta.V1, 0),
+		WithSearch(search.V1, 500*time.Millisecond),
+		WithReverse(reverse.V1, 0, reverse.WithURL(sv.URL+"/custom-reverse")),
+	)
+	if err != nil {
+		t.Fatalf("could not create client due to: %s", err.Error())
+	}
+
+	_, err = client.ETA.GetETA([]eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}, eta.NewDefaultCallOptions())
+	if smapperrors.StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("status code should be 400 but it is %d", smapperrors.StatusCode(err))
+	}
+	_, err = client.Search.AutoComplete("tehran", search.NewDefaultCallOptions())
+	if smapperrors.StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("status code should be 400 but it is %d", smapperrors.StatusCode(err))
+	}
+	_, err = client.Reverse.GetDisplayName(35.7, 51.4, reverse.NewDefaultCallOptions())
+	if smapperrors.StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("status code should be 400 but it is %d", smapperrors.StatusCode(err))
+	}
+
+	expectedOrder := []string{"outer", "inner", "outer", "inner", "outer", "inner"}
+	if strings.Join(order, ",") != strings.Join(expectedOrder, ",") {
+		t.Fatalf("middlewares should be called in order %v but they are called in order %v", expectedOrder, order)
+	}
+	if strings.Join(services, ",") != "eta,search,reverse" {
+		t.Fatalf("shared interceptors should be called for eta, search and reverse but they are called for %v", services)
+	}
+	if !strings.HasPrefix(paths[0], "/eta/v1") {
+		t.Fatalf("eta request path should start with /eta/v1 but it is %s", paths[0])
+	}
+	if paths[2] != "/custom-reverse" {
+		t.Fatalf("reverse request path should be overridden by service options but it is %s", paths[2])
+	}
+}
@@ -0,0 +1,4 @@
+// Package smapp builds the clients of all smapp services from a single config.Config.
+// the clients share one http.Transport (and so one connection pool), tracer name and middlewares, and use the
+// retry policy and rate limiter of the config. each service can still be customized using its own constructor options.
+package smapp
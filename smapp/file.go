@@ -0,0 +1,123 @@
+package smapp
+
+import (
+	"github.com/snapp-incubator/smapp-sdk-go/cache"
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	areagateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+)
+
+// NewClientFromFile builds the config and the clients of all services from file, which can be read using
+// config.LoadFile or config.LoadEnvironment. the given options are applied after the settings of file, so they
+// override them.
+//
+//	file, err := config.LoadFile("smapp.json")
+//	if err != nil {
+//		panic(err)
+//	}
+//	if err := file.ApplyEnvironment("SMAPP"); err != nil {
+//		panic(err)
+//	}
+//	client, err := smapp.NewClientFromFile(file)
+func NewClientFromFile(file *config.File, opts ...Option) (*Client, error) {
+	if file == nil {
+		return nil, ErrNilConfig
+	}
+
+	cfg, err := file.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	var options []Option
+	if file.Timeout > 0 {
+		options = append(options, WithTimeout(file.Timeout.Duration()))
+	}
+	if file.Tracing.TracerName != "" {
+		options = append(options, WithRequestOpenTelemetryTracing(file.Tracing.TracerName))
+	}
+	if file.Tracing.MeterName != "" {
+		options = append(options, WithOpenTelemetryMetrics(file.Tracing.MeterName))
+	}
+
+	services := file.Services
+	options = append(options,
+		WithReverse(version(services.Reverse, reverse.V1), services.Reverse.Timeout.Duration(),
+			fileSetters[reverse.ConstructorOption]{
+				url:         reverse.WithURL,
+				retryPolicy: reverse.WithRetryPolicy,
+				cache:       reverseCache,
+			}.options(services.Reverse)...),
+		WithSearch(version[search.Version](services.Search, search.V1), services.Search.Timeout.Duration(),
+			fileSetters[search.ConstructorOption]{
+				url:         search.WithURL,
+				retryPolicy: search.WithRetryPolicy,
+			}.options(services.Search)...),
+		WithETA(version(services.ETA, eta.V1), services.ETA.Timeout.Duration(),
+			fileSetters[eta.ConstructorOption]{
+				url:         eta.WithURL,
+				retryPolicy: eta.WithRetryPolicy,
+				engine:      eta.WithDefaultEngine,
+			}.options(services.ETA)...),
+		WithMatrix(version(services.Matrix, matrix.V1), services.Matrix.Timeout.Duration(),
+			fileSetters[matrix.ConstructorOption]{
+				url:         matrix.WithURL,
+				retryPolicy: matrix.WithRetryPolicy,
+				engine:      matrix.WithDefaultEngine,
+			}.options(services.Matrix)...),
+		WithAreaGateways(version(services.AreaGateways, areagateways.V1), services.AreaGateways.Timeout.Duration(),
+			fileSetters[areagateways.ConstructorOption]{
+				url:         areagateways.WithURL,
+				retryPolicy: areagateways.WithRetryPolicy,
+			}.options(services.AreaGateways)...),
+	)
+
+	return NewClient(cfg, append(options, opts...)...)
+}
+
+// version returns the version of a service section, or fallback if it is not set.
+func version[V ~string](section config.ServiceSection, fallback V) V {
+	if section.Version == "" {
+		return fallback
+	}
+	return V(section.Version)
+}
+
+// fileSetters holds the constructor options of a service used to apply its section of a config.File. setters of
+// settings the service does not support are nil.
+type fileSetters[O any] struct {
+	url         func(string) O
+	retryPolicy func(retry.Policy) O
+	engine      func(string) O
+	cache       func(*config.CacheSection) O
+}
+
+// options returns the constructor options of a service section.
+func (s fileSetters[O]) options(section config.ServiceSection) []O {
+	var opts []O
+	if section.URL != "" {
+		opts = append(opts, s.url(section.URL))
+	}
+	if section.Retry != nil {
+		opts = append(opts, s.retryPolicy(section.Retry.Policy()))
+	}
+	if section.Engine != "" && s.engine != nil {
+		opts = append(opts, s.engine(section.Engine))
+	}
+	if section.Cache != nil && s.cache != nil {
+		opts = append(opts, s.cache(section.Cache))
+	}
+	return opts
+}
+
+func reverseCache(section *config.CacheSection) reverse.ConstructorOption {
+	settings := reverse.CacheSettings{TTL: section.TTL.Duration()}
+	if section.Precision != nil {
+		settings.Precision = *section.Precision
+	}
+	return reverse.WithCache(cache.NewLRU(section.MaxEntries), settings)
+}
@@ -0,0 +1,116 @@
+package smapp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestNewClientFromFile(t *testing.T) {
+	t.Run("nil_file", func(t *testing.T) {
+		if _, err := NewClientFromFile(nil); !errors.Is(err, ErrNilConfig) {
+			t.Fatalf("err should be ErrNilConfig but it is %v", err)
+		}
+	})
+
+	t.Run("invalid_file", func(t *testing.T) {
+		file := &config.File{APIKey: "key", Services: config.ServiceSections{Search: config.ServiceSection{Engine: "v2"}}}
+		if _, err := NewClientFromFile(file); !errors.Is(err, config.ErrInvalidConfig) {
+			t.Fatalf("err should be ErrInvalidConfig but it is %v", err)
+		}
+	})
+
+	t.Run("service_sections", func(t *testing.T) {
+		var mu sync.Mutex
+		var requests []*http.Request
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests = append(requests, r)
+			mu.Unlock()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer sv.Close()
+
+		file := &config.File{
+			APIKey:     "key",
+			APIKeyName: "X-Test-Key",
+			APIBaseURL: sv.URL,
+			Timeout:    config.Duration(time.Second),
+			Services: config.ServiceSections{
+				ETA: config.ServiceSection{
+					Engine: "nostradamus",
+					Retry:  &config.RetrySection{MaxAttempts: 2, InitialBackoff: config.Duration(time.Millisecond)},
+				},
+				Reverse: config.ServiceSection{
+					URL:   sv.URL + "/custom-reverse",
+					Cache: &config.CacheSection{TTL: config.Duration(time.Minute)},
+				},
+			},
+		}
+
+		client, err := NewClientFromFile(file)
+		if err != nil {
+			t.Fatalf("could not create client due to: %s", err.Error())
+		}
+
+		_, err = client.ETA.GetETA([]eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}, eta.NewDefaultCallOptions())
+		if smapperrors.StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("status code should be 503 but it is %d", smapperrors.StatusCode(err))
+		}
+		_, err = client.Reverse.GetDisplayName(35.7, 51.4, reverse.NewDefaultCallOptions())
+		if smapperrors.StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("status code should be 503 but it is %d", smapperrors.StatusCode(err))
+		}
+
+		if len(requests) != 3 {
+			t.Fatalf("eta should be retried once and reverse should be sent once but %d requests are sent", len(requests))
+		}
+		for _, r := range requests[:2] {
+			if !strings.HasPrefix(r.URL.Path, "/eta/v1") {
+				t.Fatalf("eta request path should start with /eta/v1 but it is %s", r.URL.Path)
+			}
+			if engine := r.URL.Query().Get(eta.EngineQueryParameter); engine != "nostradamus" {
+				t.Fatalf("eta engine should be nostradamus but it is %s", engine)
+			}
+			if r.Header.Get("X-Test-Key") != "key" {
+				t.Fatal("api key should be sent in X-Test-Key header")
+			}
+		}
+		if requests[2].URL.Path != "/custom-reverse" {
+			t.Fatalf("reverse request path should be /custom-reverse but it is %s", requests[2].URL.Path)
+		}
+	})
+
+	t.Run("options_override_file", func(t *testing.T) {
+		var engine string
+		sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			engine = r.URL.Query().Get(eta.EngineQueryParameter)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer sv.Close()
+
+		file := &config.File{
+			APIKey:     "key",
+			APIBaseURL: sv.URL,
+			Services:   config.ServiceSections{ETA: config.ServiceSection{Engine: "nostradamus"}},
+		}
+		client, err := NewClientFromFile(file, WithETA(eta.V1, 0, eta.WithDefaultEngine("ocelot")))
+		if err != nil {
+			t.Fatalf("could not create client due to: %s", err.Error())
+		}
+
+		_, _ = client.ETA.GetETA([]eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}, eta.NewDefaultCallOptions())
+		if engine != "ocelot" {
+			t.Fatalf("eta engine should be overridden by options but it is %s", engine)
+		}
+	})
+}
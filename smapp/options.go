@@ -0,0 +1,168 @@
+package smapp
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	areagateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/services/smappshot"
+)
+
+// Middleware wraps a http.RoundTripper. middlewares are applied to the shared transport of all clients.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Option is a function type for customizing NewClient behaviour in a fluent way.
+type Option func(options *options)
+
+type options struct {
+	timeout      time.Duration
+	transport    http.RoundTripper
+	tracerName   string
+	meterName    string
+	middlewares  []Middleware
+	interceptors []interceptor.Interceptor
+	logger       *slog.Logger
+	coalescing   bool
+
+	reverse      serviceOptions[reverse.Version, reverse.ConstructorOption]
+	search       serviceOptions[search.Version, search.ConstructorOption]
+	eta          serviceOptions[eta.Version, eta.ConstructorOption]
+	matrix       serviceOptions[matrix.Version, matrix.ConstructorOption]
+	areaGateways serviceOptions[areagateways.Version, areagateways.ConstructorOption]
+
+	smappShot *smappshot.Client
+}
+
+type serviceOptions[V any, O any] struct {
+	version V
+	timeout time.Duration
+	opts    []O
+}
+
+func (s serviceOptions[V, O]) timeoutOr(fallback time.Duration) time.Duration {
+	if s.timeout > 0 {
+		return s.timeout
+	}
+	return fallback
+}
+
+// WithTimeout sets the timeout of all service clients. default is 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithTransport sets the transport shared by all service clients. by default a clone of http.DefaultTransport is used.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(options *options) {
+		options.transport = transport
+	}
+}
+
+// WithRequestOpenTelemetryTracing enables opentelemetry tracing of all service clients with the given tracer name.
+func WithRequestOpenTelemetryTracing(tracerName string) Option {
+	return func(options *options) {
+		options.tracerName = tracerName
+	}
+}
+
+// WithOpenTelemetryMetrics enables opentelemetry metrics of all service clients with the given meter name.
+func WithOpenTelemetryMetrics(meterName string) Option {
+	return func(options *options) {
+		options.meterName = meterName
+	}
+}
+
+// WithMiddlewares appends middlewares to the shared transport. the first middleware is the outermost one.
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(options *options) {
+		options.middlewares = append(options.middlewares, middlewares...)
+	}
+}
+
+// WithInterceptors appends interceptors to all service clients. they are called before the interceptors
+// given to each service.
+func WithInterceptors(interceptors ...interceptor.Interceptor) Option {
+	return func(options *options) {
+		options.interceptors = append(options.interceptors, interceptors...)
+	}
+}
+
+// WithLogger sets the logger of all service clients.
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *options) {
+		options.logger = logger
+	}
+}
+
+// WithRequestCoalescing enables request coalescing of all service clients.
+func WithRequestCoalescing() Option {
+	return func(options *options) {
+		options.coalescing = true
+	}
+}
+
+// WithReverse overrides the version and timeout of the reverse client and appends constructor options to it.
+// a zero timeout keeps the shared timeout.
+func WithReverse(version reverse.Version, timeout time.Duration, opts ...reverse.ConstructorOption) Option {
+	return func(options *options) {
+		options.reverse.version = version
+		options.reverse.timeout = timeout
+		options.reverse.opts = append(options.reverse.opts, opts...)
+	}
+}
+
+// WithSearch overrides the version and timeout of the search client and appends constructor options to it.
+// a zero timeout keeps the shared timeout.
+func WithSearch(version search.Version, timeout time.Duration, opts ...search.ConstructorOption) Option {
+	return func(options *options) {
+		options.search.version = version
+		options.search.timeout = timeout
+		options.search.opts = append(options.search.opts, opts...)
+	}
+}
+
+// WithETA overrides the version and timeout of the eta client and appends constructor options to it.
+// a zero timeout keeps the shared timeout.
+func WithETA(version eta.Version, timeout time.Duration, opts ...eta.ConstructorOption) Option {
+	return func(options *options) {
+		options.eta.version = version
+		options.eta.timeout = timeout
+		options.eta.opts = append(options.eta.opts, opts...)
+	}
+}
+
+// WithMatrix overrides the version and timeout of the matrix client and appends constructor options to it.
+// a zero timeout keeps the shared timeout.
+func WithMatrix(version matrix.Version, timeout time.Duration, opts ...matrix.ConstructorOption) Option {
+	return func(options *options) {
+		options.matrix.version = version
+		options.matrix.timeout = timeout
+		options.matrix.opts = append(options.matrix.opts, opts...)
+	}
+}
+
+// WithAreaGateways overrides the version and timeout of the area gateways client and appends constructor options to it.
+// a zero timeout keeps the shared timeout.
+func WithAreaGateways(version areagateways.Version, timeout time.Duration, opts ...areagateways.ConstructorOption) Option {
+	return func(options *options) {
+		options.areaGateways.version = version
+		options.areaGateways.timeout = timeout
+		options.areaGateways.opts = append(options.areaGateways.opts, opts...)
+	}
+}
+
+// WithSmappShot enables the SmappShot client with the given base URL, signing secret and version.
+// SmappShot does not send requests, so it does not use the shared transport.
+func WithSmappShot(baseURL string, secret string, version smappshot.Version) Option {
+	return func(options *options) {
+		options.smappShot = smappshot.NewClient(baseURL, secret, version)
+	}
+}
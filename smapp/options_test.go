@@ -0,0 +1,109 @@
+package smapp
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/interceptor"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/smappshot"
+)
+
+func TestWithTimeout(t *testing.T) {
+	o := &options{}
+	WithTimeout(time.Second)(o)
+	if o.timeout != time.Second {
+		t.Fatalf("timeout should be %s but it is %s", time.Second, o.timeout)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	o := &options{}
+	transport := &http.Transport{}
+	WithTransport(transport)(o)
+	if o.transport != transport {
+		t.Fatal("transport should be the given transport")
+	}
+}
+
+func TestWithRequestOpenTelemetryTracing(t *testing.T) {
+	o := &options{}
+	WithRequestOpenTelemetryTracing("tracer")(o)
+	if o.tracerName != "tracer" {
+		t.Fatalf("tracerName should be tracer but it is %s", o.tracerName)
+	}
+}
+
+func TestWithOpenTelemetryMetrics(t *testing.T) {
+	o := &options{}
+	WithOpenTelemetryMetrics("meter")(o)
+	if o.meterName != "meter" {
+		t.Fatalf("meterName should be meter but it is %s", o.meterName)
+	}
+}
+
+func TestWithMiddlewares(t *testing.T) {
+	o := &options{}
+	identity := func(next http.RoundTripper) http.RoundTripper { return next }
+	WithMiddlewares(identity)(o)
+	WithMiddlewares(identity, identity)(o)
+	if len(o.middlewares) != 3 {
+		t.Fatalf("middlewares should have 3 items but it has %d", len(o.middlewares))
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	o := &options{}
+	WithInterceptors(interceptor.Funcs{})(o)
+	WithInterceptors(interceptor.Funcs{}, interceptor.Funcs{})(o)
+	if len(o.interceptors) != 3 {
+		t.Fatalf("interceptors should have 3 items but it has %d", len(o.interceptors))
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	o := &options{}
+	logger := slog.New(slog.DiscardHandler)
+	WithLogger(logger)(o)
+	if o.logger != logger {
+		t.Fatal("logger should be the given logger")
+	}
+}
+
+func TestWithRequestCoalescing(t *testing.T) {
+	o := &options{}
+	WithRequestCoalescing()(o)
+	if !o.coalescing {
+		t.Fatal("coalescing should be enabled")
+	}
+}
+
+func TestWithMatrix(t *testing.T) {
+	o := &options{}
+	WithMatrix(matrix.V1, 0, matrix.WithURL("http://localhost"))(o)
+
+	if o.matrix.version != matrix.V1 {
+		t.Fatalf("version should be %s but it is %s", matrix.V1, o.matrix.version)
+	}
+	if len(o.matrix.opts) != 1 {
+		t.Fatalf("opts should have 1 item but it has %d", len(o.matrix.opts))
+	}
+	if o.matrix.timeoutOr(time.Second) != time.Second {
+		t.Fatal("zero timeout should fall back to the shared timeout")
+	}
+
+	WithMatrix(matrix.V1, time.Minute)(o)
+	if o.matrix.timeoutOr(time.Second) != time.Minute {
+		t.Fatal("timeout should be overridden")
+	}
+}
+
+func TestWithSmappShot(t *testing.T) {
+	o := &options{}
+	WithSmappShot("http://localhost", "secret", smappshot.V1)(o)
+	if o.smappShot == nil {
+		t.Fatal("smappShot should not be nil")
+	}
+}
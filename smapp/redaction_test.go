@@ -0,0 +1,170 @@
+package smapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	areagateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+)
+
+// recordingTracerProvider keeps everything recorded on spans as strings.
+type recordingTracerProvider struct {
+	embedded.TracerProvider
+
+	mu      sync.Mutex
+	records []string
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+func (p *recordingTracerProvider) record(values ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, v := range values {
+		p.records = append(p.records, fmt.Sprint(v))
+	}
+}
+
+func (p *recordingTracerProvider) dump() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return strings.Join(p.records, "\n")
+}
+
+type recordingTracer struct {
+	embedded.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	t.provider.record(name, cfg.Attributes())
+	span := &recordingSpan{provider: t.provider}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+	provider *recordingTracerProvider
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.provider.record(kv)
+}
+
+func (s *recordingSpan) AddEvent(name string, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+	s.provider.record(name, cfg.Attributes())
+}
+
+func (s *recordingSpan) RecordError(err error, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+	s.provider.record(err, cfg.Attributes())
+}
+
+func (s *recordingSpan) SetStatus(_ codes.Code, description string) {
+	s.provider.record(description)
+}
+
+func (s *recordingSpan) SetName(name string) {
+	s.provider.record(name)
+}
+
+func TestClient_RedactsAPIKey(t *testing.T) {
+	const apiKey = "very-secret-key"
+
+	provider := &recordingTracerProvider{}
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a server echoing the key in its errors must not make the key leak either.
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("invalid key: " + r.Header.Get(config.DefaultHeaderAPIKeyName) + r.URL.Query().Get(config.DefaultQueryParamAPIKeyName)))
+	}))
+	defer sv.Close()
+
+	calls := func(client *Client) []error {
+		var errs []error
+		_, err := client.Reverse.GetDisplayName(35.7, 51.4, reverse.NewDefaultCallOptions())
+		errs = append(errs, err)
+		_, err = client.Search.AutoComplete("tehran", search.NewDefaultCallOptions())
+		errs = append(errs, err)
+		_, err = client.ETA.GetETA([]eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}, eta.NewDefaultCallOptions())
+		errs = append(errs, err)
+		_, err = client.Matrix.GetMatrix([]matrix.Point{{Lat: 35.7, Lon: 51.4}}, []matrix.Point{{Lat: 35.71, Lon: 51.41}}, matrix.NewDefaultCallOptions())
+		errs = append(errs, err)
+		_, err = client.AreaGateways.GetGateways(35.7, 51.4, areagateways.NewDefaultCallOptions())
+		errs = append(errs, err)
+		return errs
+	}
+
+	for _, source := range []config.APIKeySource{config.HeaderSource, config.QueryParamSource} {
+		for _, server := range []struct {
+			name string
+			url  string
+		}{
+			{name: "api_error", url: sv.URL},
+			{name: "network_error", url: "http://127.0.0.1:1"},
+		} {
+			t.Run(string(source)+"/"+server.name, func(t *testing.T) {
+				cfg, err := config.NewDefaultConfig(apiKey, config.WithAPIKeySource(source), config.WithAPIBaseURL(server.url))
+				if err != nil {
+					t.Fatalf("could not create default config due to: %s", err.Error())
+				}
+
+				var logs bytes.Buffer
+				client, err := NewClient(cfg,
+					WithTimeout(time.Second),
+					WithRequestOpenTelemetryTracing("redaction-test"),
+					WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+				)
+				if err != nil {
+					t.Fatalf("could not create client due to: %s", err.Error())
+				}
+
+				for i, err := range calls(client) {
+					if err == nil {
+						t.Fatalf("call %d should return error", i)
+					}
+					if strings.Contains(err.Error(), apiKey) || strings.Contains(fmt.Sprintf("%+v", err), apiKey) {
+						t.Fatalf("error of call %d should not contain the api key: %s", i, err.Error())
+					}
+				}
+
+				if logs.Len() == 0 {
+					t.Fatal("calls should be logged")
+				}
+				if strings.Contains(logs.String(), apiKey) {
+					t.Fatalf("logs should not contain the api key: %s", logs.String())
+				}
+				if spans := provider.dump(); strings.Contains(spans, apiKey) {
+					t.Fatalf("spans should not contain the api key: %s", spans)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package smapperrors contains the error types shared by all service clients.
+// every error returned by a client is either an *Error or an *APIError, so callers can inspect them
+// using errors.Is and errors.As instead of matching error strings.
+package smapperrors
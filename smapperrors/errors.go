@@ -0,0 +1,173 @@
+package smapperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidInput is returned when the arguments passed to a client method are not acceptable.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrNilContext is returned when a nil context.Context is passed to a client method.
+	ErrNilContext = errors.New("nil context")
+	// ErrInvalidAPIKeySource is returned when the config of a client has an unknown config.APIKeySource.
+	ErrInvalidAPIKeySource = errors.New("invalid api key source")
+	// ErrRequest is returned when the http request could not be sent to the server or its response could not be received.
+	ErrRequest = errors.New("request failed")
+	// ErrDecode is returned when the response of the server could not be deserialized.
+	ErrDecode = errors.New("could not decode response")
+	// ErrStatusNotOK is returned when the server answers with 200 but the `status` field of the body is not OK.
+	ErrStatusNotOK = errors.New("status of response is not OK")
+	// ErrCircuitOpen is returned when a request is rejected by an open circuit breaker without being sent.
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrRateLimited is returned when a request is rejected by a client-side rate limiter without being sent.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrInterceptor is returned when an interceptor aborts a call by returning an error.
+	ErrInterceptor = errors.New("aborted by interceptor")
+	// ErrCredentials is returned when the credential provider of the config can not provide an API key.
+	ErrCredentials = errors.New("could not get credentials")
+)
+
+// RequestIDHeader is the response header that smapp servers use for echoing the id of a request.
+const RequestIDHeader = "X-Request-Id"
+
+// MaxBodySnippetLength is the maximum number of bytes of response body that is kept in an APIError.
+const MaxBodySnippetLength = 512
+
+// Error is the error type returned by service clients for every failure that is not a non 200 response.
+// Kind is one of the sentinel errors of this package and Err is the underlying cause (if any),
+// so both of them can be checked using errors.Is and errors.As.
+type Error struct {
+	// Service is the name of the service returning the error. e.g. `eta`.
+	Service string
+	// Operation is the name of the operation returning the error. e.g. `get-eta`.
+	Operation string
+	// Kind is the sentinel error describing the category of the failure.
+	Kind error
+	// Err is the underlying error. it can be nil.
+	Err error
+}
+
+// New creates an Error of the given kind.
+func New(service, operation string, kind error, err error) *Error {
+	return &Error{
+		Service:   service,
+		Operation: operation,
+		Kind:      kind,
+		Err:       err,
+	}
+}
+
+// Newf creates an Error of the given kind with a formatted cause.
+func Newf(service, operation string, kind error, format string, args ...any) *Error {
+	return New(service, operation, kind, fmt.Errorf(format, args...))
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s: %s", prefix(e.Service, e.Operation), e.Kind)
+	}
+	return fmt.Sprintf("%s: %s: %s", prefix(e.Service, e.Operation), e.Kind, e.Err)
+}
+
+// Unwrap returns both Kind and Err, so errors.Is and errors.As can inspect both of them.
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// APIError is returned when a smapp server answers a request with a non 200 status code.
+type APIError struct {
+	// Service is the name of the service returning the error. e.g. `eta`.
+	Service string
+	// Operation is the name of the operation returning the error. e.g. `get-eta`.
+	Operation string
+	// StatusCode is the http status code of the response.
+	StatusCode int
+	// Body is a snippet of response body with at most MaxBodySnippetLength bytes.
+	Body string
+	// RequestID is the value of RequestIDHeader in the response, if any.
+	RequestID string
+	// Retryable reports whether sending the same request again may succeed.
+	Retryable bool
+}
+
+// NewAPIError creates an APIError from the given response. it reads at most MaxBodySnippetLength bytes of the body,
+// but it does not close it.
+func NewAPIError(service, operation string, response *http.Response) *APIError {
+	apiErr := &APIError{
+		Service:    service,
+		Operation:  operation,
+		StatusCode: response.StatusCode,
+		RequestID:  response.Header.Get(RequestIDHeader),
+		Retryable:  IsRetryableStatus(response.StatusCode),
+	}
+
+	if response.Body != nil {
+		snippet, _ := io.ReadAll(io.LimitReader(response.Body, MaxBodySnippetLength))
+		apiErr.Body = strings.ToValidUTF8(string(snippet), string(utf8.RuneError))
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: non 200 status: %d", prefix(e.Service, e.Operation), e.StatusCode)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id: %s)", e.RequestID)
+	}
+	if e.Body != "" {
+		msg += fmt.Sprintf(": %s", e.Body)
+	}
+	return msg
+}
+
+// IsRetryableStatus reports whether a response with the given status code is worth retrying.
+func IsRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsRetryable reports whether the given error is a transient failure. it is true for retryable APIError s
+// and for errors happened while sending the request, unless the context of the request is done or the request
+// is rejected by a circuit breaker or a rate limiter.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	return errors.Is(err, ErrRequest)
+}
+
+// StatusCode returns the http status code of an APIError in the chain of err. it returns 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func prefix(service, operation string) string {
+	if operation == "" {
+		return "smapp " + service
+	}
+	return "smapp " + service + " " + operation
+}
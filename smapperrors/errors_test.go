@@ -0,0 +1,105 @@
+package smapperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	t.Run("with_cause", func(t *testing.T) {
+		cause := errors.New("unexpected EOF")
+		err := New("eta", "get-eta", ErrDecode, cause)
+
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("err should be ErrDecode")
+		}
+		if !errors.Is(err, cause) {
+			t.Fatalf("err should wrap its cause")
+		}
+		if err.Error() != "smapp eta get-eta: could not decode response: unexpected EOF" {
+			t.Fatalf("unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("without_cause", func(t *testing.T) {
+		err := New("eta", "get-eta", ErrNilContext, nil)
+		if !errors.Is(err, ErrNilContext) {
+			t.Fatalf("err should be ErrNilContext")
+		}
+		if err.Error() != "smapp eta get-eta: nil context" {
+			t.Fatalf("unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("as", func(t *testing.T) {
+		wrapped := fmt.Errorf("caller: %w", Newf("search", "details", ErrInvalidInput, "bad place id %q", "x"))
+		var e *Error
+		if !errors.As(wrapped, &e) {
+			t.Fatalf("errors.As should find *Error")
+		}
+		if e.Service != "search" || e.Operation != "details" {
+			t.Fatalf("unexpected service or operation: %s %s", e.Service, e.Operation)
+		}
+	})
+}
+
+func TestNewAPIError(t *testing.T) {
+	response := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{RequestIDHeader: []string{"req-1"}},
+		Body:       io.NopCloser(strings.NewReader(strings.Repeat("a", MaxBodySnippetLength*2))),
+	}
+
+	err := NewAPIError("matrix", "get-matrix", response)
+	if err.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("StatusCode should be %d but it is %d", http.StatusServiceUnavailable, err.StatusCode)
+	}
+	if err.RequestID != "req-1" {
+		t.Fatalf("RequestID should be req-1 but it is %s", err.RequestID)
+	}
+	if len(err.Body) != MaxBodySnippetLength {
+		t.Fatalf("Body length should be %d but it is %d", MaxBodySnippetLength, len(err.Body))
+	}
+	if !err.Retryable {
+		t.Fatalf("503 should be retryable")
+	}
+
+	var wrapped error = fmt.Errorf("caller: %w", err)
+	var apiErr *APIError
+	if !errors.As(wrapped, &apiErr) {
+		t.Fatalf("errors.As should find *APIError")
+	}
+	if StatusCode(wrapped) != http.StatusServiceUnavailable {
+		t.Fatalf("StatusCode should be %d but it is %d", http.StatusServiceUnavailable, StatusCode(wrapped))
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"too_many_requests", &APIError{StatusCode: http.StatusTooManyRequests, Retryable: true}, true},
+		{"bad_request", &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"transport", New("eta", "get-eta", ErrRequest, errors.New("connection reset")), true},
+		{"canceled", New("eta", "get-eta", ErrRequest, context.Canceled), false},
+		{"decode", New("eta", "get-eta", ErrDecode, nil), false},
+		{"circuit_open", New("eta", "get-eta", ErrRequest, ErrCircuitOpen), false},
+		{"rate_limited", New("eta", "get-eta", ErrRequest, ErrRateLimited), false},
+		{"nil", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := IsRetryable(c.err); got != c.want {
+				t.Fatalf("IsRetryable should be %t but it is %t", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package smapptest
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+)
+
+// AddAddress registers the address of a coordinate. reverse requests get the address of the nearest registered
+// coordinate within the tolerance of the server, see WithTolerance. requests with no address get an ERROR status.
+func (s *Server) AddAddress(lat, lon float64, a Address) {
+	s.addresses.add(lat, lon, a)
+}
+
+// AddCity registers cities. all cities are returned by GetCities, and SearchCity returns the ones whose name or
+// description contain the input.
+func (s *Server) AddCity(cities ...search.City) {
+	s.places.addCities(cities...)
+}
+
+// AddPlaces registers the results of autocomplete requests for query. queries are matched case-insensitively. the
+// details of the places are returned by details requests of their place ids, unless they are registered using
+// AddDetail.
+func (s *Server) AddPlaces(query string, places ...search.Result) {
+	s.places.addPlaces(query, places...)
+}
+
+// AddDetail registers the details of a place id.
+func (s *Server) AddDetail(placeID string, detail search.Detail) {
+	s.places.addDetail(placeID, detail)
+}
+
+// AddArea registers an area of area-gateways endpoint. requests get the first registered area containing their
+// point. Coordinates of the area are polygon rings of [lon, lat] pairs, the first of which is its boundary and
+// the others its holes. requests with no area get a 404 response.
+func (s *Server) AddArea(area area_gateways.Area) {
+	s.areas.add(area)
+}
+
+// Address is the address of a coordinate returned by reverse endpoints.
+type Address struct {
+	// Components are returned for requests with display=false. they are used by GetComponents and
+	// GetStructuralResult of reverse clients.
+	Components []reverse.Component
+	// DisplayName is returned for requests with display=true. if it is empty, names of Components are joined.
+	DisplayName string
+	// Frequent is returned for frequent and frequent-v2 requests. if it is nil, DisplayName is used as its address.
+	Frequent *reverse.FrequentAddress
+}
+
+// displayName returns the display name of the address.
+func (a Address) displayName() string {
+	if a.DisplayName != "" {
+		return a.DisplayName
+	}
+	names := make([]string, 0, len(a.Components))
+	for _, component := range a.Components {
+		names = append(names, component.Name)
+	}
+	return strings.Join(names, "، ")
+}
+
+// frequent returns the frequent address of the address.
+func (a Address) frequent() reverse.FrequentAddress {
+	if a.Frequent != nil {
+		return *a.Frequent
+	}
+	return reverse.FrequentAddress{Address: a.displayName()}
+}
+
+// address is a registered Address and its coordinate.
+type address struct {
+	point   Point
+	address Address
+}
+
+// addressStore holds the registered addresses. it is safe for concurrent use.
+type addressStore struct {
+	mu        sync.Mutex
+	addresses []address
+}
+
+// add registers the address of a coordinate.
+func (s *addressStore) add(lat, lon float64, a Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addresses = append(s.addresses, address{point: Point{Lat: lat, Lon: lon}, address: a})
+}
+
+// find returns the registered address nearest to p within tolerance, in degrees.
+func (s *addressStore) find(p Point, tolerance float64) (Address, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, nearest := Address{}, math.Inf(1)
+	for _, a := range s.addresses {
+		d := math.Hypot(a.point.Lat-p.Lat, a.point.Lon-p.Lon)
+		if d <= tolerance && d < nearest {
+			found, nearest = a.address, d
+		}
+	}
+	return found, !math.IsInf(nearest, 1)
+}
+
+// place is a registered place and the query it is registered for.
+type place struct {
+	query  string
+	result search.Result
+}
+
+// placeStore holds the registered cities, places and details. it is safe for concurrent use.
+type placeStore struct {
+	mu      sync.Mutex
+	cities  []search.City
+	places  []place
+	details map[string]search.Detail
+}
+
+// addCities registers cities.
+func (s *placeStore) addCities(cities ...search.City) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cities = append(s.cities, cities...)
+}
+
+// addPlaces registers places for query.
+func (s *placeStore) addPlaces(query string, places ...search.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, result := range places {
+		s.places = append(s.places, place{query: normalizeQuery(query), result: result})
+	}
+}
+
+// addDetail registers the details of a place id.
+func (s *placeStore) addDetail(placeID string, detail search.Detail) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.details == nil {
+		s.details = make(map[string]search.Detail)
+	}
+	s.details[placeID] = detail
+}
+
+// searchCities returns the registered cities whose name or description contain input. all cities are returned for
+// an empty input.
+func (s *placeStore) searchCities(input string) []search.City {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	input = normalizeQuery(input)
+	cities := make([]search.City, 0, len(s.cities))
+	for _, city := range s.cities {
+		if input == "" || contains(input, city.Name, city.Description) {
+			cities = append(cities, city)
+		}
+	}
+	return cities
+}
+
+// findPlaces returns the registered places whose query, name or description contain input.
+func (s *placeStore) findPlaces(input string) []search.Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	input = normalizeQuery(input)
+	results := make([]search.Result, 0)
+	if input == "" {
+		return results
+	}
+	for _, p := range s.places {
+		if contains(input, p.query, p.result.Name, p.result.Description) {
+			results = append(results, p.result)
+		}
+	}
+	return results
+}
+
+// findQueryPlaces returns the places registered for query, which is matched exactly after normalization.
+func (s *placeStore) findQueryPlaces(query string) []search.Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query = normalizeQuery(query)
+	results := make([]search.Result, 0)
+	for _, p := range s.places {
+		if p.query == query {
+			results = append(results, p.result)
+		}
+	}
+	return results
+}
+
+// findDetail returns the details of placeID, which are either registered or derived from a registered place.
+func (s *placeStore) findDetail(placeID string) (search.Detail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if detail, ok := s.details[placeID]; ok {
+		return detail, true
+	}
+	for _, p := range s.places {
+		if p.result.PlaceID != placeID {
+			continue
+		}
+		detail := search.Detail{Name: p.result.Name}
+		detail.Geometry.Location.Lat, _ = strconv.ParseFloat(p.result.Location.Latitude, 64)
+		detail.Geometry.Location.Lng, _ = strconv.ParseFloat(p.result.Location.Longitude, 64)
+		return detail, true
+	}
+	return search.Detail{}, false
+}
+
+// areaStore holds the registered areas. it is safe for concurrent use.
+type areaStore struct {
+	mu    sync.Mutex
+	areas []area_gateways.Area
+}
+
+// add registers an area.
+func (s *areaStore) add(area area_gateways.Area) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.areas = append(s.areas, area)
+}
+
+// find returns the first registered area containing p.
+func (s *areaStore) find(p Point) (area_gateways.Area, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, area := range s.areas {
+		if area.Contains(geo.LatLng{Lat: p.Lat, Lon: p.Lon}) {
+			return area, true
+		}
+	}
+	return area_gateways.Area{}, false
+}
+
+// Place returns a search.Result with the given place id, name and location, for AddPlaces.
+func Place(placeID, name string, lat, lon float64) search.Result {
+	result := search.Result{PlaceID: placeID, Name: name, Description: name}
+	result.StructuredFormatting.MainText = name
+	result.SetLatLng(geo.LatLng{Lat: lat, Lon: lon})
+	return result
+}
+
+// City returns a search.City with the given id, name and centroid, for AddCity.
+func City(id int, name string, lat, lon float64) search.City {
+	city := search.City{ID: id, Name: name, Description: name}
+	city.SetLatLng(geo.LatLng{Lat: lat, Lon: lon})
+	city.Metadata.CityDetail.CityId = int64(id)
+	return city
+}
+
+// contains reports whether any of values contains the normalized input, case-insensitively.
+func contains(input string, values ...string) bool {
+	for _, value := range values {
+		if value != "" && strings.Contains(strings.ToLower(value), input) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeQuery returns the form of a query used for matching.
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
@@ -0,0 +1,73 @@
+package smapptest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestServer_FindAddress(t *testing.T) {
+	sv := NewServer(t, WithTolerance(0.01))
+	sv.AddAddress(35.7, 51.4, Address{DisplayName: "far"})
+	sv.AddAddress(35.705, 51.4, Address{DisplayName: "near"})
+
+	client, err := reverse.NewReverseClient(sv.Config(), reverse.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create reverse client due to: %s", err.Error())
+	}
+
+	if name, err := client.GetDisplayName(35.706, 51.4, reverse.NewDefaultCallOptions()); err != nil || name != "near" {
+		t.Fatalf("nearest address should be found but it is %q %v", name, err)
+	}
+	if _, err := client.GetDisplayName(35.72, 51.4, reverse.NewDefaultCallOptions()); !errors.Is(err, smapperrors.ErrStatusNotOK) {
+		t.Fatalf("addresses out of tolerance should not be found but err is %v", err)
+	}
+}
+
+func TestServer_FindArea(t *testing.T) {
+	sv := NewServer(t)
+	sv.AddArea(area_gateways.Area{
+		ID: "with-hole",
+		Coordinates: [][][]float64{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+		},
+	})
+	sv.AddArea(area_gateways.Area{ID: "hole", Coordinates: [][][]float64{{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}}})
+
+	client, err := area_gateways.NewAreaGatewaysClient(sv.Config(), area_gateways.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create area-gateways client due to: %s", err.Error())
+	}
+
+	tests := []struct {
+		name  string
+		point Point
+		found bool
+		id    string
+	}{
+		{name: "inside", point: Point{Lat: 2, Lon: 2}, found: true, id: "with-hole"},
+		{name: "inside_hole", point: Point{Lat: 5, Lon: 5}, found: true, id: "hole"},
+		{name: "outside", point: Point{Lat: 11, Lon: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, err := client.GetGateways(tt.point.Lat, tt.point.Lon, area_gateways.NewDefaultCallOptions())
+			if !tt.found {
+				var apiErr *smapperrors.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+					t.Fatalf("err should be a 404 APIError but it is %v", err)
+				}
+				return
+			}
+			if err != nil || area.ID != tt.id {
+				t.Fatalf("area should be %q but it is %q %v", tt.id, area.ID, err)
+			}
+		})
+	}
+}
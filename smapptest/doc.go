@@ -0,0 +1,15 @@
+// Package smapptest starts an in-process fake of the smapp APIs, so service clients can be tested end to end, with
+// their real serialization, authentication and URL construction, without the network.
+//
+// the server implements the reverse (single and batch), search, eta, matrix and area-gateways endpoints. its data is
+// registered by tests, e.g. addresses of coordinates and places of queries, and ETAs are computed using a Model.
+// latency and faults can be injected per endpoint.
+//
+//	sv := smapptest.NewServer(t)
+//	sv.AddAddress(35.7, 51.4, smapptest.Address{DisplayName: "Azadi Square"})
+//
+//	client, err := reverse.NewReverseClient(sv.Config(), reverse.V1, time.Second)
+//
+// the fake clients of this package, e.g. FakeReverseClient, implement the Interface of each service with the same
+// in-memory data and behavior, for tests of components that take the interfaces instead of real clients.
+package smapptest
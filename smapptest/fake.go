@@ -0,0 +1,19 @@
+package smapptest
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// checkContext returns the error of a call of a fake client with ctx, like the errors of real clients for nil and
+// done contexts.
+func checkContext(ctx context.Context, service, operation string) error {
+	if ctx == nil {
+		return smapperrors.New(service, operation, smapperrors.ErrNilContext, nil)
+	}
+	if err := ctx.Err(); err != nil {
+		return smapperrors.New(service, operation, smapperrors.ErrRequest, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package smapptest
+
+import (
+	"context"
+
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// FakeAreaGatewaysClient is an in-memory area_gateways.Interface. calls get the first registered area containing
+// their point, or an empty area if there is none. it is safe for concurrent use.
+type FakeAreaGatewaysClient struct {
+	areas areaStore
+}
+
+// Force FakeAreaGatewaysClient to implement area_gateways.Interface at compile time
+var _ area_gateways.Interface = (*FakeAreaGatewaysClient)(nil)
+
+// NewFakeAreaGatewaysClient creates an empty FakeAreaGatewaysClient.
+func NewFakeAreaGatewaysClient() *FakeAreaGatewaysClient {
+	return &FakeAreaGatewaysClient{}
+}
+
+// AddArea registers an area. Coordinates of the area are polygon rings of [lon, lat] pairs, the first of which is
+// its boundary and the others its holes.
+func (f *FakeAreaGatewaysClient) AddArea(area area_gateways.Area) {
+	f.areas.add(area)
+}
+
+// GetGateways implements area_gateways.Interface.
+func (f *FakeAreaGatewaysClient) GetGateways(lat, lon float64, options area_gateways.CallOptions) (area_gateways.Area, error) {
+	return f.GetGatewaysWithContext(context.Background(), lat, lon, options)
+}
+
+// GetGatewaysWithContext implements area_gateways.Interface.
+func (f *FakeAreaGatewaysClient) GetGatewaysWithContext(ctx context.Context, lat, lon float64, _ area_gateways.CallOptions) (area_gateways.Area, error) {
+	const operation = "get-gateways"
+	if err := (area_gateways.Point{Lat: lat, Lon: lon}).Validate(); err != nil {
+		return area_gateways.Area{}, smapperrors.Newf("area-gateways", operation, smapperrors.ErrInvalidInput, "input lat and lon are invalid: %w", err)
+	}
+	if err := checkContext(ctx, "area-gateways", operation); err != nil {
+		return area_gateways.Area{}, err
+	}
+	area, _ := f.areas.find(Point{Lat: lat, Lon: lon})
+	return area, nil
+}
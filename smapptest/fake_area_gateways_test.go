@@ -0,0 +1,44 @@
+package smapptest
+
+import (
+	"errors"
+	"testing"
+
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestFakeAreaGatewaysClient(t *testing.T) {
+	fake := NewFakeAreaGatewaysClient()
+	fake.AddArea(area_gateways.Area{
+		ID: "with-hole",
+		Coordinates: [][][]float64{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+		},
+	})
+	fake.AddArea(area_gateways.Area{ID: "hole", Coordinates: [][][]float64{{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}}})
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		id       string
+	}{
+		{name: "inside", lat: 2, lon: 2, id: "with-hole"},
+		{name: "inside_hole", lat: 5, lon: 5, id: "hole"},
+		{name: "lon_lat_order", lat: 2, lon: 11},
+		{name: "outside", lat: 11, lon: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, err := fake.GetGateways(tt.lat, tt.lon, area_gateways.NewDefaultCallOptions())
+			if err != nil || area.ID != tt.id {
+				t.Fatalf("area should be %q but it is %q, err: %v", tt.id, area.ID, err)
+			}
+		})
+	}
+
+	if _, err := fake.GetGateways(91, 0, area_gateways.NewDefaultCallOptions()); !errors.Is(err, smapperrors.ErrInvalidInput) {
+		t.Fatalf("err should be ErrInvalidInput but it is %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package smapptest
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// FakeETAClient is an in-memory eta.Interface. the ETA has a leg between each pair of consecutive points, computed
+// by Model.
+type FakeETAClient struct {
+	// Model computes the legs of ETAs.
+	Model Model
+}
+
+// Force FakeETAClient to implement eta.Interface at compile time
+var _ eta.Interface = (*FakeETAClient)(nil)
+
+// NewFakeETAClient creates a FakeETAClient with SpeedModel(DefaultSpeed).
+func NewFakeETAClient() *FakeETAClient {
+	return &FakeETAClient{Model: SpeedModel(DefaultSpeed)}
+}
+
+// GetETA implements eta.Interface.
+func (f *FakeETAClient) GetETA(points []eta.Point, options eta.CallOptions) (eta.ETA, error) {
+	return f.GetETAWithContext(context.Background(), points, options)
+}
+
+// GetETAWithContext implements eta.Interface.
+func (f *FakeETAClient) GetETAWithContext(ctx context.Context, points []eta.Point, options eta.CallOptions) (eta.ETA, error) {
+	return f.GetETAWithInputMeta(ctx, points, options, nil)
+}
+
+// GetETAWithInputMeta implements eta.Interface. metadata is ignored.
+func (f *FakeETAClient) GetETAWithInputMeta(ctx context.Context, points []eta.Point, _ eta.CallOptions, _ map[string]string) (eta.ETA, error) {
+	const operation = "get-eta"
+	result, err := computeETA(f.Model, points)
+	if err != nil {
+		return eta.ETA{}, smapperrors.New("eta", operation, smapperrors.ErrInvalidInput, err)
+	}
+	if err := checkContext(ctx, "eta", operation); err != nil {
+		return eta.ETA{}, err
+	}
+	return result, nil
+}
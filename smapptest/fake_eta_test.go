@@ -0,0 +1,35 @@
+package smapptest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestFakeETAClient(t *testing.T) {
+	fake := NewFakeETAClient()
+	options := eta.NewDefaultCallOptions()
+
+	// 0.1 degree of latitude is about 11.1 km, traveled in about 22 minutes with default speed.
+	result, err := fake.GetETA([]eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.8, Lon: 51.4}, {Lat: 35.8, Lon: 51.4}}, options)
+	if err != nil {
+		t.Fatalf("GetETA should not return error: %s", err.Error())
+	}
+	legs := result.Trip.Legs
+	if len(legs) != 2 || legs[0].Length < 11000 || legs[0].Length > 11200 || legs[0].Time < 1320 || legs[0].Time > 1350 || legs[1].Length != 0 {
+		t.Fatalf("legs should be computed by default model: %+v", legs)
+	}
+
+	fake.Model = FixedModel(Route{Duration: 1500 * time.Millisecond, Distance: 10})
+	result, err = fake.GetETA([]eta.Point{{}, {}}, options)
+	if err != nil || result.Trip.Legs[0].Time != 2 || result.Trip.Legs[0].Length != 10 {
+		t.Fatalf("legs should be computed by model and rounded to seconds: %+v %v", result, err)
+	}
+
+	if _, err := fake.GetETA([]eta.Point{{}}, options); !errors.Is(err, smapperrors.ErrInvalidInput) {
+		t.Fatalf("err should be ErrInvalidInput but it is %v", err)
+	}
+}
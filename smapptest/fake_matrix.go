@@ -0,0 +1,45 @@
+package smapptest
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// FakeMatrixClient is an in-memory matrix.Interface. the items of outputs are computed by Model.
+type FakeMatrixClient struct {
+	// Model computes the items of outputs.
+	Model Model
+}
+
+// Force FakeMatrixClient to implement matrix.Interface at compile time
+var _ matrix.Interface = (*FakeMatrixClient)(nil)
+
+// NewFakeMatrixClient creates a FakeMatrixClient with SpeedModel(DefaultSpeed).
+func NewFakeMatrixClient() *FakeMatrixClient {
+	return &FakeMatrixClient{Model: SpeedModel(DefaultSpeed)}
+}
+
+// GetMatrix implements matrix.Interface.
+func (f *FakeMatrixClient) GetMatrix(sources []matrix.Point, targets []matrix.Point, options matrix.CallOptions) (matrix.Output, error) {
+	return f.GetMatrixWithContext(context.Background(), sources, targets, options)
+}
+
+// GetMatrixWithContext implements matrix.Interface.
+func (f *FakeMatrixClient) GetMatrixWithContext(ctx context.Context, sources []matrix.Point, targets []matrix.Point, options matrix.CallOptions) (matrix.Output, error) {
+	return f.GetMatrixWithInputMeta(ctx, sources, targets, options, nil)
+}
+
+// GetMatrixWithInputMeta implements matrix.Interface. metadata is ignored.
+func (f *FakeMatrixClient) GetMatrixWithInputMeta(ctx context.Context, sources []matrix.Point, targets []matrix.Point, _ matrix.CallOptions, _ map[string]string) (matrix.Output, error) {
+	const operation = "get-matrix"
+	output, err := computeMatrix(f.Model, sources, targets)
+	if err != nil {
+		return matrix.Output{}, smapperrors.New("matrix", operation, smapperrors.ErrInvalidInput, err)
+	}
+	if err := checkContext(ctx, "matrix", operation); err != nil {
+		return matrix.Output{}, err
+	}
+	return output, nil
+}
@@ -0,0 +1,34 @@
+package smapptest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestFakeMatrixClient(t *testing.T) {
+	fake := NewFakeMatrixClient()
+	fake.Model = func(from, to Point) Route {
+		return Route{Distance: int((to.Lat - from.Lat) * 1000)}
+	}
+
+	sources := []matrix.Point{{Lat: 1}, {Lat: 2}}
+	targets := []matrix.Point{{Lat: 3}, {Lat: 4}, {Lat: 5}}
+	output, err := fake.GetMatrix(sources, targets, matrix.NewDefaultCallOptions())
+	if err != nil {
+		t.Fatalf("GetMatrix should not return error: %s", err.Error())
+	}
+	if len(output.SourcesToTargets) != 2 || len(output.SourcesToTargets[1]) != 3 {
+		t.Fatalf("output should be 2x3: %+v", output)
+	}
+	item := output.SourcesToTargets[1][2]
+	if item.FromIndex != 1 || item.ToIndex != 2 || item.Distance != 3000 || item.Status != matrixSuccessStatus {
+		t.Fatalf("item should be computed by model: %+v", item)
+	}
+
+	if _, err := fake.GetMatrix(sources, nil, matrix.NewDefaultCallOptions()); !errors.Is(err, smapperrors.ErrInvalidInput) {
+		t.Fatalf("err should be ErrInvalidInput but it is %v", err)
+	}
+}
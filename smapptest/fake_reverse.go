@@ -0,0 +1,174 @@
+package smapptest
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// FakeReverseClient is an in-memory reverse.Interface. calls get the address of the nearest registered coordinate
+// within Tolerance, and fail with smapperrors.ErrStatusNotOK if there is none. batch requests with no address get
+// an empty result. it is safe for concurrent use.
+type FakeReverseClient struct {
+	// Tolerance is the distance, in degrees, within which a registered address matches a coordinate.
+	Tolerance float64
+
+	addresses addressStore
+}
+
+// Force FakeReverseClient to implement reverse.Interface at compile time
+var _ reverse.Interface = (*FakeReverseClient)(nil)
+
+// NewFakeReverseClient creates a FakeReverseClient with DefaultTolerance.
+func NewFakeReverseClient() *FakeReverseClient {
+	return &FakeReverseClient{Tolerance: DefaultTolerance}
+}
+
+// AddAddress registers the address of a coordinate.
+func (f *FakeReverseClient) AddAddress(lat, lon float64, a Address) {
+	f.addresses.add(lat, lon, a)
+}
+
+// find returns the registered address nearest to p within the tolerance.
+func (f *FakeReverseClient) find(p Point) (Address, bool) {
+	return f.addresses.find(p, f.Tolerance)
+}
+
+// lookup is like find, but with the context checks and errors of reverse clients.
+func (f *FakeReverseClient) lookup(ctx context.Context, operation string, lat, lon float64) (Address, error) {
+	if err := checkContext(ctx, "reverse", operation); err != nil {
+		return Address{}, err
+	}
+	a, ok := f.find(Point{Lat: lat, Lon: lon})
+	if !ok {
+		return Address{}, smapperrors.Newf("reverse", operation, smapperrors.ErrStatusNotOK, "no address is registered near %f,%f", lat, lon)
+	}
+	return a, nil
+}
+
+// GetComponents implements reverse.Interface.
+func (f *FakeReverseClient) GetComponents(lat, lon float64, options reverse.CallOptions) ([]reverse.Component, error) {
+	return f.GetComponentsWithContext(context.Background(), lat, lon, options)
+}
+
+// GetComponentsWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetComponentsWithContext(ctx context.Context, lat, lon float64, _ reverse.CallOptions) ([]reverse.Component, error) {
+	a, err := f.lookup(ctx, "get-address-components", lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	return append([]reverse.Component{}, a.Components...), nil
+}
+
+// GetDisplayName implements reverse.Interface.
+func (f *FakeReverseClient) GetDisplayName(lat, lon float64, options reverse.CallOptions) (string, error) {
+	return f.GetDisplayNameWithContext(context.Background(), lat, lon, options)
+}
+
+// GetDisplayNameWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetDisplayNameWithContext(ctx context.Context, lat, lon float64, _ reverse.CallOptions) (string, error) {
+	a, err := f.lookup(ctx, "get-display-name-address", lat, lon)
+	if err != nil {
+		return "", err
+	}
+	return a.displayName(), nil
+}
+
+// GetFrequent implements reverse.Interface.
+func (f *FakeReverseClient) GetFrequent(lat, lon float64, options reverse.CallOptions) (reverse.FrequentAddress, error) {
+	return f.GetFrequentWithContext(context.Background(), lat, lon, options)
+}
+
+// GetFrequentWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetFrequentWithContext(ctx context.Context, lat, lon float64, options reverse.CallOptions) (reverse.FrequentAddress, error) {
+	const operation = "get-frequent-address"
+	if options.UseResponseType && !options.ResponseType.IsValidFrequentType() {
+		return reverse.FrequentAddress{}, smapperrors.Newf("reverse", operation, smapperrors.ErrInvalidInput, "invalid frequent response type: %s", options.ResponseType)
+	}
+	a, err := f.lookup(ctx, operation, lat, lon)
+	if err != nil {
+		return reverse.FrequentAddress{}, err
+	}
+	return a.frequent(), nil
+}
+
+// GetBatch implements reverse.Interface.
+func (f *FakeReverseClient) GetBatch(request reverse.BatchReverseRequest) ([]reverse.Result, error) {
+	return f.GetBatchWithContext(context.Background(), request)
+}
+
+// GetBatchWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetBatchWithContext(ctx context.Context, request reverse.BatchReverseRequest) ([]reverse.Result, error) {
+	if err := checkContext(ctx, "reverse", "get-batch-reverse"); err != nil {
+		return nil, err
+	}
+	results := make([]reverse.Result, 0, len(request.Requests))
+	for _, req := range request.Requests {
+		a, _ := f.find(Point{Lat: req.Lat, Lon: req.Lon})
+		results = append(results, reverse.Result{
+			Result: reverse.Components{Components: append([]reverse.Component{}, a.Components...)},
+			ID:     int(req.ID),
+		})
+	}
+	return results, nil
+}
+
+// GetBatchDisplayName implements reverse.Interface.
+func (f *FakeReverseClient) GetBatchDisplayName(request reverse.BatchReverseRequest) ([]reverse.ResultWithDisplayName, error) {
+	return f.GetBatchDisplayNameWithContext(context.Background(), request)
+}
+
+// GetBatchDisplayNameWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetBatchDisplayNameWithContext(ctx context.Context, request reverse.BatchReverseRequest) ([]reverse.ResultWithDisplayName, error) {
+	if err := checkContext(ctx, "reverse", "get-batch-reverse"); err != nil {
+		return nil, err
+	}
+	results := make([]reverse.ResultWithDisplayName, 0, len(request.Requests))
+	for _, req := range request.Requests {
+		var name string
+		if a, ok := f.find(Point{Lat: req.Lat, Lon: req.Lon}); ok {
+			name = a.displayName()
+		}
+		results = append(results, reverse.ResultWithDisplayName{
+			DisplayName: reverse.DisplayName{DisplayName: name},
+			ID:          int(req.ID),
+		})
+	}
+	return results, nil
+}
+
+// GetStructuralResult implements reverse.Interface.
+func (f *FakeReverseClient) GetStructuralResult(lat, lon float64, options reverse.CallOptions) (*reverse.StructuralComponent, error) {
+	return f.GetStructuralResultWithContext(context.Background(), lat, lon, options)
+}
+
+// GetStructuralResultWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetStructuralResultWithContext(ctx context.Context, lat, lon float64, options reverse.CallOptions) (*reverse.StructuralComponent, error) {
+	components, err := f.GetComponentsWithContext(ctx, lat, lon, options)
+	if err != nil {
+		return nil, err
+	}
+	return reverse.NewStructuralComponent(components), nil
+}
+
+// GetBatchStructuralResults implements reverse.Interface.
+func (f *FakeReverseClient) GetBatchStructuralResults(request reverse.BatchReverseRequest) ([]reverse.StructuralResult, error) {
+	return f.GetBatchStructuralResultsWithContext(context.Background(), request)
+}
+
+// GetBatchStructuralResultsWithContext implements reverse.Interface.
+func (f *FakeReverseClient) GetBatchStructuralResultsWithContext(ctx context.Context, request reverse.BatchReverseRequest) ([]reverse.StructuralResult, error) {
+	results, err := f.GetBatchWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	structuralResults := make([]reverse.StructuralResult, 0, len(results))
+	for _, result := range results {
+		structuralResults = append(structuralResults, reverse.StructuralResult{
+			Result: reverse.NewStructuralComponent(result.Result.Components),
+			ID:     result.ID,
+		})
+	}
+	return structuralResults, nil
+}
@@ -0,0 +1,74 @@
+package smapptest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestFakeReverseClient(t *testing.T) {
+	fake := NewFakeReverseClient()
+	fake.Tolerance = 0.01
+	fake.AddAddress(35.7, 51.4, Address{DisplayName: "far", Components: []reverse.Component{{Name: "Tehran", Type: "city"}}})
+	fake.AddAddress(35.705, 51.4, Address{Components: []reverse.Component{{Name: "Tehran", Type: "city"}, {Name: "Azadi", Type: "primary"}}})
+	options := reverse.NewDefaultCallOptions()
+
+	t.Run("nearest", func(t *testing.T) {
+		name, err := fake.GetDisplayName(35.706, 51.4, options)
+		if err != nil || name != "Tehran، Azadi" {
+			t.Fatalf("display name of nearest address should be joined components: %q %v", name, err)
+		}
+
+		structural, err := fake.GetStructuralResult(35.706, 51.4, options)
+		if err != nil || structural.City != "Tehran" || structural.Primary != "Azadi" {
+			t.Fatalf("structural result is not converted from components: %+v %v", structural, err)
+		}
+	})
+
+	t.Run("out_of_tolerance", func(t *testing.T) {
+		if _, err := fake.GetComponents(35.72, 51.4, options); !errors.Is(err, smapperrors.ErrStatusNotOK) {
+			t.Fatalf("err should be ErrStatusNotOK but it is %v", err)
+		}
+	})
+
+	t.Run("frequent", func(t *testing.T) {
+		frequent, err := fake.GetFrequent(35.7, 51.4, options)
+		if err != nil || frequent.Address != "far" {
+			t.Fatalf("frequent address should be the display name: %+v %v", frequent, err)
+		}
+
+		_, err = fake.GetFrequent(35.7, 51.4, reverse.NewDefaultCallOptions(reverse.WithFrequentResponseVersion(reverse.Driver)))
+		if !errors.Is(err, smapperrors.ErrInvalidInput) {
+			t.Fatalf("err should be ErrInvalidInput but it is %v", err)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		request := reverse.BatchReverseRequest{Requests: []reverse.Request{{Lat: 35.7, Lon: 51.4, ID: 7}, {Lat: 10, Lon: 10, ID: 8}}}
+
+		results, err := fake.GetBatchStructuralResults(request)
+		if err != nil || len(results) != 2 || results[0].ID != 7 || results[0].Result.City != "Tehran" || results[1].Result.City != "" {
+			t.Fatalf("batch results are not registered addresses: %+v %v", results, err)
+		}
+
+		named, err := fake.GetBatchDisplayName(request)
+		if err != nil || named[0].DisplayName.DisplayName != "far" || named[1].DisplayName.DisplayName != "" {
+			t.Fatalf("batch display names are not registered ones: %+v %v", named, err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := fake.GetComponentsWithContext(ctx, 35.7, 51.4, options); !errors.Is(err, smapperrors.ErrRequest) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("err should be ErrRequest and context.Canceled but it is %v", err)
+		}
+		var nilContext context.Context = nil
+		if _, err := fake.GetBatchWithContext(nilContext, reverse.BatchReverseRequest{}); !errors.Is(err, smapperrors.ErrNilContext) {
+			t.Fatalf("err should be ErrNilContext but it is %v", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package smapptest
+
+import (
+	"context"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+// FakeSearchClient is an in-memory search.Interface. it is safe for concurrent use.
+//
+// GetCities returns all registered cities, and SearchCity the ones whose name or description contain the input.
+// AutoComplete returns the registered places whose query, name or description contain the input. Details returns
+// the registered details of a place id, or the name and location of the registered place with that id, and fails
+// with smapperrors.ErrStatusNotOK if there is none. inputs are matched case-insensitively.
+type FakeSearchClient struct {
+	places placeStore
+}
+
+// Force FakeSearchClient to implement search.Interface at compile time
+var _ search.Interface = (*FakeSearchClient)(nil)
+
+// NewFakeSearchClient creates an empty FakeSearchClient.
+func NewFakeSearchClient() *FakeSearchClient {
+	return &FakeSearchClient{}
+}
+
+// AddCity registers cities.
+func (f *FakeSearchClient) AddCity(cities ...search.City) {
+	f.places.addCities(cities...)
+}
+
+// AddPlaces registers places for query. query can be empty, so places are matched by their names only.
+func (f *FakeSearchClient) AddPlaces(query string, places ...search.Result) {
+	f.places.addPlaces(query, places...)
+}
+
+// AddDetail registers the details of a place id.
+func (f *FakeSearchClient) AddDetail(placeID string, detail search.Detail) {
+	f.places.addDetail(placeID, detail)
+}
+
+// GetCities implements search.Interface.
+func (f *FakeSearchClient) GetCities(options search.CallOptions) ([]search.City, error) {
+	return f.GetCitiesWithContext(context.Background(), options)
+}
+
+// GetCitiesWithContext implements search.Interface.
+func (f *FakeSearchClient) GetCitiesWithContext(ctx context.Context, _ search.CallOptions) ([]search.City, error) {
+	if err := checkContext(ctx, "search", "get-cities"); err != nil {
+		return nil, err
+	}
+	return f.places.searchCities(""), nil
+}
+
+// SearchCity implements search.Interface.
+func (f *FakeSearchClient) SearchCity(input string, options search.CallOptions) ([]search.City, error) {
+	return f.SearchCityWithContext(context.Background(), input, options)
+}
+
+// SearchCityWithContext implements search.Interface.
+func (f *FakeSearchClient) SearchCityWithContext(ctx context.Context, input string, _ search.CallOptions) ([]search.City, error) {
+	if err := checkContext(ctx, "search", "search-cities"); err != nil {
+		return nil, err
+	}
+	return f.places.searchCities(input), nil
+}
+
+// AutoComplete implements search.Interface.
+func (f *FakeSearchClient) AutoComplete(input string, options search.CallOptions) ([]search.Result, error) {
+	return f.AutoCompleteWithContext(context.Background(), input, options)
+}
+
+// AutoCompleteWithContext implements search.Interface.
+func (f *FakeSearchClient) AutoCompleteWithContext(ctx context.Context, input string, _ search.CallOptions) ([]search.Result, error) {
+	if err := checkContext(ctx, "search", "autocomplete"); err != nil {
+		return nil, err
+	}
+	return f.places.findPlaces(input), nil
+}
+
+// Details implements search.Interface.
+func (f *FakeSearchClient) Details(placeId string, options search.CallOptions) (search.Detail, error) {
+	return f.DetailsWithContext(context.Background(), placeId, options)
+}
+
+// DetailsWithContext implements search.Interface.
+func (f *FakeSearchClient) DetailsWithContext(ctx context.Context, placeId string, _ search.CallOptions) (search.Detail, error) {
+	const operation = "details"
+	if err := checkContext(ctx, "search", operation); err != nil {
+		return search.Detail{}, err
+	}
+	detail, ok := f.places.findDetail(placeId)
+	if !ok {
+		return search.Detail{}, smapperrors.Newf("search", operation, smapperrors.ErrStatusNotOK, "no place is registered with id %s", placeId)
+	}
+	return detail, nil
+}
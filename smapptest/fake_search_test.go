@@ -0,0 +1,65 @@
+package smapptest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestFakeSearchClient(t *testing.T) {
+	fake := NewFakeSearchClient()
+	fake.AddCity(City(1, "Tehran", 35.7, 51.4), City(2, "Tabriz", 38.08, 46.29))
+	fake.AddPlaces("milad", Place("p1", "برج میلاد", 35.7448, 51.3753))
+	fake.AddPlaces("", Place("p2", "Azadi Tower", 35.6997, 51.338))
+	options := search.NewDefaultCallOptions()
+
+	tests := []struct {
+		input string
+		ids   []string
+	}{
+		{input: "Mil", ids: []string{"p1"}},
+		{input: "میلاد", ids: []string{"p1"}},
+		{input: "TOWER", ids: []string{"p2"}},
+		{input: "unknown"},
+		{input: " "},
+	}
+	for _, tt := range tests {
+		t.Run("autocomplete_"+tt.input, func(t *testing.T) {
+			results, err := fake.AutoComplete(tt.input, options)
+			if err != nil || len(results) != len(tt.ids) {
+				t.Fatalf("results should be %v but they are %+v %v", tt.ids, results, err)
+			}
+			for i, id := range tt.ids {
+				if results[i].PlaceID != id {
+					t.Fatalf("results should be %v but they are %+v", tt.ids, results)
+				}
+			}
+		})
+	}
+
+	cities, err := fake.GetCities(options)
+	if err != nil || len(cities) != 2 {
+		t.Fatalf("GetCities should return all cities: %+v %v", cities, err)
+	}
+	cities, err = fake.SearchCity("RAN", options)
+	if err != nil || len(cities) != 1 || cities[0].Name != "Tehran" {
+		t.Fatalf("SearchCity should return matching cities: %+v %v", cities, err)
+	}
+
+	detail, err := fake.Details("p2", options)
+	if err != nil || detail.Name != "Azadi Tower" || detail.Geometry.Location.Lng != 51.338 {
+		t.Fatalf("Details should be derived from registered place: %+v %v", detail, err)
+	}
+
+	override := search.Detail{Name: "Azadi"}
+	fake.AddDetail("p2", override)
+	if detail, err := fake.Details("p2", options); err != nil || detail != override {
+		t.Fatalf("Details should return registered detail: %+v %v", detail, err)
+	}
+
+	if _, err := fake.Details("p3", options); !errors.Is(err, smapperrors.ErrStatusNotOK) {
+		t.Fatalf("err should be ErrStatusNotOK but it is %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package smapptest
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Endpoint identifies an endpoint of the server, for injecting faults and counting requests.
+type Endpoint string
+
+const (
+	// AllEndpoints matches every endpoint.
+	AllEndpoints Endpoint = ""
+
+	EndpointReverse      Endpoint = "reverse"
+	EndpointReverseBatch Endpoint = "reverse-batch"
+	EndpointCities       Endpoint = "search-cities"
+	EndpointSearchCity   Endpoint = "search-city"
+	EndpointAutoComplete Endpoint = "search-autocomplete"
+	EndpointDetails      Endpoint = "search-details"
+	EndpointETA          Endpoint = "eta"
+	EndpointMatrix       Endpoint = "matrix"
+	EndpointAreaGateways Endpoint = "area-gateways"
+)
+
+// Fault is an error response injected into requests of an endpoint.
+type Fault struct {
+	// StatusCode is the status of the response. default is 500.
+	StatusCode int
+	// Body is the body of the response. default is a JSON error message.
+	Body string
+	// Latency delays the response, in addition to the latency of the server.
+	Latency time.Duration
+	// Count is the number of requests that fail. once they fail the fault is removed. zero fails all requests until
+	// Server.ClearFaults is called. requests with an invalid api key get 401 and are not counted.
+	Count int
+	// Rate is the probability of each request failing, between 0 and 1. zero fails all requests.
+	Rate float64
+	// Abort closes the connection without any response, instead of responding with StatusCode.
+	Abort bool
+}
+
+// fault is an injected Fault, its endpoint and the number of requests it can fail yet.
+type fault struct {
+	Fault
+	endpoint  Endpoint
+	remaining int
+}
+
+// InjectFault makes requests of endpoint fail with f. use AllEndpoints to inject it into every endpoint. faults are
+// applied in the order they are injected.
+//
+//	// fail the next 2 eta requests with 503
+//	sv.InjectFault(smapptest.EndpointETA, smapptest.Fault{StatusCode: http.StatusServiceUnavailable, Count: 2})
+func (s *Server) InjectFault(endpoint Endpoint, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f.StatusCode == 0 {
+		f.StatusCode = http.StatusInternalServerError
+	}
+	if f.Body == "" {
+		f.Body = `{"status":"ERROR","message":"injected fault"}`
+	}
+	s.faults = append(s.faults, &fault{Fault: f, endpoint: endpoint, remaining: f.Count})
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// fault returns the fault that a request of endpoint should fail with, if any.
+func (s *Server) fault(endpoint Endpoint) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.endpoint != AllEndpoints && f.endpoint != endpoint {
+			continue
+		}
+		if f.Rate > 0 && rand.Float64() >= f.Rate {
+			continue
+		}
+		if f.Count > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f.Fault, true
+	}
+	return Fault{}, false
+}
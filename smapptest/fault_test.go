@@ -0,0 +1,51 @@
+package smapptest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServer_InjectFault(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(EndpointETA, Fault{})
+
+		f, ok := sv.fault(EndpointETA)
+		if !ok || f.StatusCode != http.StatusInternalServerError || f.Body == "" {
+			t.Fatalf("fault should have default status and body but it is %+v", f)
+		}
+	})
+
+	t.Run("order_and_count", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(AllEndpoints, Fault{StatusCode: http.StatusBadGateway, Count: 1})
+		sv.InjectFault(EndpointETA, Fault{StatusCode: http.StatusServiceUnavailable, Count: 1})
+
+		for _, want := range []int{http.StatusBadGateway, http.StatusServiceUnavailable} {
+			if f, ok := sv.fault(EndpointETA); !ok || f.StatusCode != want {
+				t.Fatalf("fault should have status %d but it is %+v", want, f)
+			}
+		}
+		if _, ok := sv.fault(EndpointETA); ok {
+			t.Fatal("faults should be removed after Count requests")
+		}
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(EndpointETA, Fault{Rate: 0.5})
+
+		failed := 0
+		for i := 0; i < 1000; i++ {
+			if _, ok := sv.fault(EndpointETA); ok {
+				failed++
+			}
+		}
+		if failed < 400 || failed > 600 {
+			t.Fatalf("about half of requests should fail but %d of 1000 fail", failed)
+		}
+		if _, ok := sv.fault(EndpointMatrix); ok {
+			t.Fatal("faults should not be applied to other endpoints")
+		}
+	})
+}
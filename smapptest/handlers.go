@@ -0,0 +1,167 @@
+package smapptest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+)
+
+const (
+	okStatus    = "OK"
+	errorStatus = "ERROR"
+)
+
+// badRequest responds to invalid requests.
+func badRequest(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"status": errorStatus, "message": message})
+}
+
+// handleReverse handles single reverse requests.
+func (s *Server) handleReverse(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, latErr := strconv.ParseFloat(query.Get(reverse.Lat), 64)
+	lon, lonErr := strconv.ParseFloat(query.Get(reverse.Lon), 64)
+	if latErr != nil || lonErr != nil {
+		badRequest(w, "lat and lon should be numbers")
+		return
+	}
+
+	a, found := s.addresses.find(Point{Lat: lat, Lon: lon}, s.tolerance)
+
+	switch reverse.ResponseType(query.Get(reverse.Type)) {
+	case reverse.Frequent, reverse.Frequent_V2:
+		if !found {
+			writeJSON(w, http.StatusNotFound, map[string]string{"status": errorStatus, "message": "address not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, a.frequent())
+		return
+	}
+
+	if !found {
+		writeJSON(w, http.StatusOK, map[string]any{"status": errorStatus, "result": map[string]any{}})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"status": okStatus, "result": reverseResult(a, query.Get(reverse.Display) == "true")})
+}
+
+// handleReverseBatch handles batch reverse requests. requests with no address get an empty result.
+func (s *Server) handleReverseBatch(w http.ResponseWriter, r *http.Request) {
+	var request reverse.BatchReverseRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		badRequest(w, "could not decode request: "+err.Error())
+		return
+	}
+
+	results := make([]map[string]any, 0, len(request.Requests))
+	for _, req := range request.Requests {
+		a, found := s.addresses.find(Point{Lat: req.Lat, Lon: req.Lon}, s.tolerance)
+		result := map[string]any{}
+		if found {
+			result = reverseResult(a, req.Display == "true")
+		}
+		results = append(results, map[string]any{"result": result, "id": req.ID})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+// reverseResult returns the result of a reverse response for a.
+func reverseResult(a Address, display bool) map[string]any {
+	if display {
+		return map[string]any{"displayName": a.displayName()}
+	}
+	components := a.Components
+	if components == nil {
+		components = []reverse.Component{}
+	}
+	return map[string]any{"components": components}
+}
+
+// handleCities handles GetCities requests.
+func (s *Server) handleCities(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": okStatus, "predictions": s.places.searchCities("")})
+}
+
+// handleSearchCity handles SearchCity requests.
+func (s *Server) handleSearchCity(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": okStatus, "predictions": s.places.searchCities(r.URL.Query().Get(search.Input))})
+}
+
+// handleAutoComplete handles AutoComplete requests.
+func (s *Server) handleAutoComplete(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": okStatus, "predictions": s.places.findQueryPlaces(r.URL.Query().Get(search.Input))})
+}
+
+// handleDetails handles Details requests.
+func (s *Server) handleDetails(w http.ResponseWriter, r *http.Request) {
+	detail, found := s.places.findDetail(r.URL.Query().Get(search.PlaceID))
+	if !found {
+		writeJSON(w, http.StatusOK, map[string]any{"status": errorStatus, "result": map[string]any{}})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"status": okStatus, "result": detail})
+}
+
+// handleETA handles eta requests of all versions.
+func (s *Server) handleETA(w http.ResponseWriter, r *http.Request) {
+	var request eta.ETARequest
+	if err := json.Unmarshal([]byte(r.URL.Query().Get(eta.JSONInputQueryParam)), &request); err != nil {
+		badRequest(w, "could not decode json param: "+err.Error())
+		return
+	}
+
+	result, err := computeETA(s.model, request.Locations)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleMatrix handles GET and POST matrix requests of all versions.
+func (s *Server) handleMatrix(w http.ResponseWriter, r *http.Request) {
+	var input matrix.Input
+	if r.Method == http.MethodPost {
+		var post matrix.PostInput
+		if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+			badRequest(w, "could not decode request: "+err.Error())
+			return
+		}
+		input = post.Json
+	} else if err := json.Unmarshal([]byte(r.URL.Query().Get(matrix.JSONInputQueryParam)), &input); err != nil {
+		badRequest(w, "could not decode json param: "+err.Error())
+		return
+	}
+
+	output, err := computeMatrix(s.model, input.Sources, input.Targets)
+	if err != nil {
+		badRequest(w, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// handleAreaGateways handles area-gateways requests.
+func (s *Server) handleAreaGateways(w http.ResponseWriter, r *http.Request) {
+	var point area_gateways.Point
+	if err := json.NewDecoder(r.Body).Decode(&point); err != nil {
+		badRequest(w, "could not decode request: "+err.Error())
+		return
+	}
+
+	area, found := s.areas.find(Point{Lat: point.Lat, Lon: point.Lon})
+	if !found {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "area not found"})
+		return
+	}
+	if area.Type == "" {
+		area.Type = "Polygon"
+	}
+	writeJSON(w, http.StatusOK, area)
+}
@@ -0,0 +1,110 @@
+package smapptest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/geo"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+)
+
+// DefaultSpeed is the speed, in kilometers per hour, of the default Model.
+const DefaultSpeed = 30
+
+// matrixSuccessStatus is the status of each item of matrix outputs.
+const matrixSuccessStatus = "Success"
+
+// Point is a location in a map.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Route is the ETA of traveling between two points.
+type Route struct {
+	// Duration is the time of traveling. it is rounded to seconds in responses.
+	Duration time.Duration
+	// Distance is the length of the route in meters.
+	Distance int
+}
+
+// Model computes the Route between two points for eta and matrix endpoints.
+type Model func(from, to Point) Route
+
+// SpeedModel returns a Model that travels the great-circle distance between points with the given speed in
+// kilometers per hour.
+func SpeedModel(speed float64) Model {
+	return func(from, to Point) Route {
+		distance := haversine(from, to)
+		return Route{
+			Duration: time.Duration(distance / (speed / 3.6) * float64(time.Second)),
+			Distance: int(math.Round(distance)),
+		}
+	}
+}
+
+// FixedModel returns a Model that returns route for all pairs of points.
+func FixedModel(route Route) Model {
+	return func(Point, Point) Route {
+		return route
+	}
+}
+
+// haversine returns the great-circle distance between a and b in meters.
+func haversine(a, b Point) float64 {
+	return geo.Distance(geo.LatLng{Lat: a.Lat, Lon: a.Lon}, geo.LatLng{Lat: b.Lat, Lon: b.Lon})
+}
+
+// computeETA returns the ETA of points, which has a leg between each pair of consecutive points computed by model.
+func computeETA(model Model, points []eta.Point) (eta.ETA, error) {
+	if len(points) < 2 {
+		return eta.ETA{}, fmt.Errorf("at least 2 points are required but %d is given", len(points))
+	}
+
+	var result eta.ETA
+	for i := 1; i < len(points); i++ {
+		route := model(Point{Lat: points[i-1].Lat, Lon: points[i-1].Lon}, Point{Lat: points[i].Lat, Lon: points[i].Lon})
+		result.Trip.Legs = append(result.Trip.Legs, struct {
+			Time   int `json:"time"`
+			Length int `json:"length"`
+		}{Time: seconds(route), Length: route.Distance})
+	}
+	return result, nil
+}
+
+// computeMatrix returns the matrix output of sources and targets, whose items are computed by model.
+func computeMatrix(model Model, sources, targets []matrix.Point) (matrix.Output, error) {
+	if len(sources) == 0 || len(targets) == 0 {
+		return matrix.Output{}, errors.New("both sources and targets should not be empty")
+	}
+
+	var output matrix.Output
+	output.SourcesToTargets = make([][]struct {
+		Distance  int    `json:"distance"`
+		Time      int    `json:"time"`
+		FromIndex int    `json:"from_index"`
+		ToIndex   int    `json:"to_index"`
+		Status    string `json:"status"`
+	}, len(sources))
+	for i, source := range sources {
+		for j, target := range targets {
+			route := model(Point{Lat: source.Lat, Lon: source.Lon}, Point{Lat: target.Lat, Lon: target.Lon})
+			output.SourcesToTargets[i] = append(output.SourcesToTargets[i], struct {
+				Distance  int    `json:"distance"`
+				Time      int    `json:"time"`
+				FromIndex int    `json:"from_index"`
+				ToIndex   int    `json:"to_index"`
+				Status    string `json:"status"`
+			}{Distance: route.Distance, Time: seconds(route), FromIndex: i, ToIndex: j, Status: matrixSuccessStatus})
+		}
+	}
+	return output, nil
+}
+
+// seconds returns the duration of route in whole seconds.
+func seconds(route Route) int {
+	return int(math.Round(route.Duration.Seconds()))
+}
@@ -0,0 +1,28 @@
+package smapptest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpeedModel(t *testing.T) {
+	// 1 degree of longitude on the equator is about 111.2 km.
+	route := SpeedModel(60)(Point{}, Point{Lon: 1})
+	if route.Distance < 111000 || route.Distance > 111300 {
+		t.Fatalf("distance should be about 111.2 km but it is %d", route.Distance)
+	}
+	if route.Duration < 111*time.Minute || route.Duration > 112*time.Minute {
+		t.Fatalf("duration should be about 111 minutes but it is %s", route.Duration)
+	}
+
+	if route := SpeedModel(60)(Point{Lat: 35.7, Lon: 51.4}, Point{Lat: 35.7, Lon: 51.4}); route != (Route{}) {
+		t.Fatalf("route between equal points should be zero but it is %+v", route)
+	}
+}
+
+func TestFixedModel(t *testing.T) {
+	want := Route{Duration: time.Minute, Distance: 500}
+	if route := FixedModel(want)(Point{}, Point{Lat: 10}); route != want {
+		t.Fatalf("route should be %+v but it is %+v", want, route)
+	}
+}
@@ -0,0 +1,43 @@
+package smapptest
+
+import "time"
+
+// DefaultAPIKey is the API key accepted by servers that are not given any keys.
+const DefaultAPIKey = "smapptest-key"
+
+// DefaultTolerance is the default distance, in degrees, within which a registered address matches a coordinate.
+// it is about 50 meters.
+const DefaultTolerance = 0.0005
+
+// Option is a function type for customizing the Server.
+type Option func(s *Server)
+
+// WithAPIKeys sets the API keys accepted by the server. the first one is used by Server.Config. default is
+// DefaultAPIKey.
+func WithAPIKeys(keys ...string) Option {
+	return func(s *Server) {
+		s.keys = append([]string{}, keys...)
+	}
+}
+
+// WithLatency delays all responses of the server by latency.
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+// WithModel sets the Model computing ETAs of eta and matrix endpoints. default is SpeedModel(DefaultSpeed).
+func WithModel(model Model) Option {
+	return func(s *Server) {
+		s.model = model
+	}
+}
+
+// WithTolerance sets the distance, in degrees, within which a registered address matches a coordinate of reverse
+// requests. default is DefaultTolerance.
+func WithTolerance(tolerance float64) Option {
+	return func(s *Server) {
+		s.tolerance = tolerance
+	}
+}
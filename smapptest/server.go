@@ -0,0 +1,196 @@
+package smapptest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+)
+
+// Server is a fake smapp API server. its data is registered using AddAddress, AddCity, AddPlaces, AddDetail and
+// AddArea, and it is safe for concurrent use.
+//
+// requests are authenticated using the `X-Smapp-Key` header or the `monshi_key` query param, and get a 401
+// response if they have none of the keys of the server.
+type Server struct {
+	tb      testing.TB
+	server  *httptest.Server
+	keys    []string
+	latency time.Duration
+
+	tolerance float64
+	model     Model
+	addresses addressStore
+	places    placeStore
+	areas     areaStore
+
+	mu       sync.Mutex
+	faults   []*fault
+	requests map[Endpoint]int
+}
+
+// NewServer starts a Server. it is closed when the test and its subtests complete.
+func NewServer(tb testing.TB, opts ...Option) *Server {
+	tb.Helper()
+
+	s := &Server{
+		tb:        tb,
+		keys:      []string{DefaultAPIKey},
+		tolerance: DefaultTolerance,
+		model:     SpeedModel(DefaultSpeed),
+		requests:  make(map[Endpoint]int),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.handle(mux, EndpointReverse, s.handleReverse, "GET /reverse/{version}")
+	s.handle(mux, EndpointReverseBatch, s.handleReverseBatch, "POST /reverse/{version}")
+	s.handle(mux, EndpointCities, s.handleCities, "GET /search/{version}/place/cities")
+	s.handle(mux, EndpointSearchCity, s.handleSearchCity, "GET /search/{version}/place/search/city")
+	s.handle(mux, EndpointAutoComplete, s.handleAutoComplete, "GET /search/{version}/place/autocomplete/json")
+	s.handle(mux, EndpointDetails, s.handleDetails, "GET /search/{version}/place/details/json")
+	s.handle(mux, EndpointETA, s.handleETA, "GET /eta/{version}", "GET /api/{version}/eta")
+	s.handle(mux, EndpointMatrix, s.handleMatrix, "GET /matrix/{version}", "POST /matrix/{version}", "GET /api/{version}/matrix", "POST /api/{version}/matrix")
+	s.handle(mux, EndpointAreaGateways, s.handleAreaGateways, "GET /area-gateways/{version}", "POST /area-gateways/{version}")
+
+	s.server = httptest.NewServer(mux)
+	tb.Cleanup(s.server.Close)
+	return s
+}
+
+// URL returns the base url of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Client returns a http.Client that sends requests to the server.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// Close shuts down the server. it is called automatically when the test completes.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Config returns a config.Config whose base url is the server and whose API key is the first key of the server.
+// opts are applied after them.
+//
+//	cfg := sv.Config(config.WithAPIKeySource(config.QueryParamSource))
+func (s *Server) Config(opts ...config.Option) *config.Config {
+	s.tb.Helper()
+
+	opts = append([]config.Option{config.WithAPIBaseURL(s.server.URL)}, opts...)
+	cfg, err := config.NewDefaultConfig(s.keys[0], opts...)
+	if err != nil {
+		s.tb.Fatalf("smapptest: could not create config: %s", err.Error())
+	}
+	// NewDefaultConfig names the key for the header source, even if the query param source is set.
+	if cfg.APIKeySource == config.QueryParamSource && cfg.APIKeyName == config.DefaultHeaderAPIKeyName {
+		cfg.APIKeyName = config.DefaultQueryParamAPIKeyName
+	}
+	return cfg
+}
+
+// Requests returns the number of requests received by endpoint, including unauthorized and failed ones. use
+// AllEndpoints to get the number of all requests.
+func (s *Server) Requests(endpoint Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if endpoint != AllEndpoints {
+		return s.requests[endpoint]
+	}
+	total := 0
+	for _, n := range s.requests {
+		total += n
+	}
+	return total
+}
+
+// handle registers handler of endpoint for patterns, behind authentication, latency and fault injection.
+func (s *Server) handle(mux *http.ServeMux, endpoint Endpoint, handler http.HandlerFunc, patterns ...string) {
+	wrapped := func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[endpoint]++
+		s.mu.Unlock()
+
+		// unauthorized requests are rejected before faults are applied, so they do not use up the failures of a
+		// fault with Count.
+		if !s.authorized(r) {
+			if sleep(r, s.latency) {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"status": "ERROR", "message": "invalid api key"})
+			}
+			return
+		}
+		f, failed := s.fault(endpoint)
+		if !sleep(r, s.latency+f.Latency) {
+			return
+		}
+		if failed {
+			if f.Abort {
+				abort(w)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.StatusCode)
+			_, _ = w.Write([]byte(f.Body))
+			return
+		}
+		handler(w, r)
+	}
+	for _, pattern := range patterns {
+		mux.HandleFunc(pattern, wrapped)
+	}
+}
+
+// authorized reports whether r has one of the keys of the server.
+func (s *Server) authorized(r *http.Request) bool {
+	if key := r.Header.Get(config.DefaultHeaderAPIKeyName); key != "" {
+		return slices.Contains(s.keys, key)
+	}
+	return slices.Contains(s.keys, r.URL.Query().Get(config.DefaultQueryParamAPIKeyName))
+}
+
+// sleep waits for d, unless the request is canceled first. it reports whether the request is still alive.
+func sleep(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// abort closes the connection of w without writing a response.
+func abort(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	_ = conn.Close()
+}
+
+// writeJSON writes v as the JSON body of a response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
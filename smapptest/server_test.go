@@ -0,0 +1,356 @@
+package smapptest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/retry"
+	area_gateways "github.com/snapp-incubator/smapp-sdk-go/services/area-gateways"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+	"github.com/snapp-incubator/smapp-sdk-go/services/matrix"
+	"github.com/snapp-incubator/smapp-sdk-go/services/reverse"
+	"github.com/snapp-incubator/smapp-sdk-go/services/search"
+	"github.com/snapp-incubator/smapp-sdk-go/smapperrors"
+)
+
+func TestServer_Reverse(t *testing.T) {
+	sv := NewServer(t)
+	sv.AddAddress(35.7, 51.4, Address{
+		Components: []reverse.Component{{Name: "تهران", Type: "city"}, {Name: "آزادی", Type: "primary"}},
+	})
+	sv.AddAddress(35.8, 51.5, Address{
+		DisplayName: "Tajrish",
+		Frequent:    &reverse.FrequentAddress{Address: "Tajrish Square", Strategy: reverse.PopularPOI},
+	})
+
+	client, err := reverse.NewReverseClient(sv.Config(), reverse.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create reverse client due to: %s", err.Error())
+	}
+
+	t.Run("components", func(t *testing.T) {
+		components, err := client.GetComponents(35.7001, 51.4001, reverse.NewDefaultCallOptions())
+		if err != nil {
+			t.Fatalf("GetComponents should not return error: %s", err.Error())
+		}
+		if len(components) != 2 || components[1].Name != "آزادی" {
+			t.Fatalf("components are not registered ones: %+v", components)
+		}
+
+		structural, err := client.GetStructuralResult(35.7, 51.4, reverse.NewDefaultCallOptions())
+		if err != nil {
+			t.Fatalf("GetStructuralResult should not return error: %s", err.Error())
+		}
+		if structural.City != "تهران" || structural.Primary != "آزادی" {
+			t.Fatalf("structural result is not converted from registered components: %+v", structural)
+		}
+	})
+
+	t.Run("display_name", func(t *testing.T) {
+		name, err := client.GetDisplayName(35.7, 51.4, reverse.NewDefaultCallOptions())
+		if err != nil {
+			t.Fatalf("GetDisplayName should not return error: %s", err.Error())
+		}
+		if name != "تهران، آزادی" {
+			t.Fatalf("display name should be joined components but it is %s", name)
+		}
+	})
+
+	t.Run("frequent", func(t *testing.T) {
+		frequent, err := client.GetFrequent(35.8, 51.5, reverse.NewDefaultCallOptions(reverse.WithFrequentResponseVersion(reverse.Frequent_V2)))
+		if err != nil {
+			t.Fatalf("GetFrequent should not return error: %s", err.Error())
+		}
+		if frequent.Address != "Tajrish Square" || frequent.Strategy != reverse.PopularPOI {
+			t.Fatalf("frequent address is not the registered one: %+v", frequent)
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, err := client.GetComponents(30, 50, reverse.NewDefaultCallOptions())
+		if !errors.Is(err, smapperrors.ErrStatusNotOK) {
+			t.Fatalf("err should be ErrStatusNotOK but it is %v", err)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		request := reverse.BatchReverseRequest{Requests: []reverse.Request{
+			{Lat: 35.7, Lon: 51.4, ID: 1, Display: "false"},
+			{Lat: 30, Lon: 50, ID: 2, Display: "false"},
+		}}
+		results, err := client.GetBatch(request)
+		if err != nil {
+			t.Fatalf("GetBatch should not return error: %s", err.Error())
+		}
+		if len(results) != 2 || results[0].ID != 1 || len(results[0].Result.Components) != 2 || len(results[1].Result.Components) != 0 {
+			t.Fatalf("batch results are not registered addresses: %+v", results)
+		}
+
+		request.Requests[0].Display = "true"
+		named, err := client.GetBatchDisplayName(request)
+		if err != nil {
+			t.Fatalf("GetBatchDisplayName should not return error: %s", err.Error())
+		}
+		if named[0].DisplayName.DisplayName != "تهران، آزادی" {
+			t.Fatalf("batch display name is not registered one: %+v", named)
+		}
+	})
+}
+
+func TestServer_Search(t *testing.T) {
+	sv := NewServer(t)
+	sv.AddCity(City(1, "Tehran", 35.7, 51.4), City(2, "Tabriz", 38.08, 46.29))
+	sv.AddPlaces("Milad", Place("p1", "Milad Tower", 35.7448, 51.3753))
+
+	detail := search.Detail{Name: "Azadi Tower"}
+	detail.Geometry.Location.Lat, detail.Geometry.Location.Lng = 35.6997, 51.338
+	sv.AddDetail("p2", detail)
+
+	// search client uses the query param source for the api key.
+	client, err := search.NewSearchClient(sv.Config(config.WithAPIKeySource(config.QueryParamSource)), search.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create search client due to: %s", err.Error())
+	}
+	options := search.NewDefaultCallOptions(search.WithLocation(35.7, 51.4))
+
+	cities, err := client.GetCities(options)
+	if err != nil || len(cities) != 2 {
+		t.Fatalf("GetCities should return registered cities: %+v %v", cities, err)
+	}
+
+	cities, err = client.SearchCity("tab", options)
+	if err != nil || len(cities) != 1 || cities[0].ID != 2 || cities[0].Centroid.Latitude != "38.08" {
+		t.Fatalf("SearchCity should return matching cities: %+v %v", cities, err)
+	}
+
+	places, err := client.AutoComplete(" milad ", options)
+	if err != nil || len(places) != 1 || places[0].PlaceID != "p1" {
+		t.Fatalf("AutoComplete should return registered places: %+v %v", places, err)
+	}
+
+	places, err = client.AutoComplete("unknown", options)
+	if err != nil || len(places) != 0 {
+		t.Fatalf("AutoComplete should return no places for unknown queries: %+v %v", places, err)
+	}
+
+	got, err := client.Details("p1", options)
+	if err != nil || got.Name != "Milad Tower" || got.Geometry.Location.Lat != 35.7448 {
+		t.Fatalf("Details should be derived from registered place: %+v %v", got, err)
+	}
+
+	got, err = client.Details("p2", options)
+	if err != nil || got != detail {
+		t.Fatalf("Details should return registered detail: %+v %v", got, err)
+	}
+
+	if _, err := client.Details("p3", options); !errors.Is(err, smapperrors.ErrStatusNotOK) {
+		t.Fatalf("err should be ErrStatusNotOK but it is %v", err)
+	}
+}
+
+func TestServer_ETA(t *testing.T) {
+	sv := NewServer(t, WithModel(FixedModel(Route{Duration: 90 * time.Second, Distance: 1200})))
+	points := []eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}, {Lat: 35.72, Lon: 51.42}}
+
+	for _, version := range []eta.Version{eta.V1, eta.V2} {
+		client, err := eta.NewETAClient(sv.Config(), version, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+
+		result, err := client.GetETA(points, eta.NewDefaultCallOptions())
+		if err != nil {
+			t.Fatalf("%s: GetETA should not return error: %s", version, err.Error())
+		}
+		if len(result.Trip.Legs) != 2 || result.Trip.Legs[1].Time != 90 || result.Trip.Legs[1].Length != 1200 {
+			t.Fatalf("%s: legs should be computed by model: %+v", version, result)
+		}
+	}
+
+	if sv.Requests(EndpointETA) != 2 {
+		t.Fatalf("eta endpoint should receive 2 requests but it receives %d", sv.Requests(EndpointETA))
+	}
+}
+
+func TestServer_Matrix(t *testing.T) {
+	sv := NewServer(t)
+	sources := []matrix.Point{{Lat: 35.7, Lon: 51.4}}
+	targets := []matrix.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.8, Lon: 51.4}}
+
+	for _, version := range []matrix.Version{matrix.V1, matrix.V2} {
+		client, err := matrix.NewMatrixClient(sv.Config(), version, time.Second)
+		if err != nil {
+			t.Fatalf("could not create matrix client due to: %s", err.Error())
+		}
+
+		for _, options := range []matrix.CallOptions{matrix.NewDefaultCallOptions(), matrix.NewDefaultCallOptions(matrix.WithUsePost())} {
+			output, err := client.GetMatrix(sources, targets, options)
+			if err != nil {
+				t.Fatalf("%s: GetMatrix should not return error: %s", version, err.Error())
+			}
+			if len(output.SourcesToTargets) != 1 || len(output.SourcesToTargets[0]) != 2 {
+				t.Fatalf("%s: output should be 1x2: %+v", version, output)
+			}
+			// 0.1 degree of latitude is about 11.1 km, traveled in about 22 minutes with default speed.
+			item := output.SourcesToTargets[0][1]
+			if item.ToIndex != 1 || item.Distance < 11000 || item.Distance > 11200 || item.Time < 1320 || item.Time > 1350 {
+				t.Fatalf("%s: item should be computed by default model: %+v", version, item)
+			}
+		}
+	}
+}
+
+func TestServer_AreaGateways(t *testing.T) {
+	sv := NewServer(t)
+	sv.AddArea(area_gateways.Area{
+		ID:          "1",
+		Name:        "Hospital",
+		Coordinates: [][][]float64{{{51.40, 35.70}, {51.42, 35.70}, {51.42, 35.72}, {51.40, 35.72}, {51.40, 35.70}}},
+		Gates:       []area_gateways.Gate{{Name: "Main", Type: "Point", Coordinates: []float64{51.41, 35.70}}},
+	})
+
+	client, err := area_gateways.NewAreaGatewaysClient(sv.Config(), area_gateways.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create area-gateways client due to: %s", err.Error())
+	}
+
+	area, err := client.GetGateways(35.71, 51.41, area_gateways.NewDefaultCallOptions())
+	if err != nil {
+		t.Fatalf("GetGateways should not return error: %s", err.Error())
+	}
+	if area.ID != "1" || area.Type != "Polygon" || len(area.Gates) != 1 {
+		t.Fatalf("area is not the registered one: %+v", area)
+	}
+
+	var apiErr *smapperrors.APIError
+	if _, err := client.GetGateways(35.75, 51.41, area_gateways.NewDefaultCallOptions()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err should be a 404 APIError but it is %v", err)
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	sv := NewServer(t, WithAPIKeys("first", "second"))
+
+	for _, key := range []string{"first", "second"} {
+		client, err := eta.NewETAClient(sv.Config(config.WithAPIKey(key)), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		if _, err := client.GetETA([]eta.Point{{}, {}}, eta.NewDefaultCallOptions()); err != nil {
+			t.Fatalf("key %s should be accepted but err is %s", key, err.Error())
+		}
+	}
+
+	client, err := eta.NewETAClient(sv.Config(config.WithAPIKey("wrong")), eta.V1, time.Second)
+	if err != nil {
+		t.Fatalf("could not create eta client due to: %s", err.Error())
+	}
+	var apiErr *smapperrors.APIError
+	if _, err := client.GetETA([]eta.Point{{}, {}}, eta.NewDefaultCallOptions()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("err should be a 401 APIError but it is %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	points := []eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}
+
+	t.Run("count_with_retry", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(EndpointETA, Fault{StatusCode: http.StatusServiceUnavailable, Count: 2})
+
+		policy := retry.DefaultPolicy()
+		policy.InitialBackoff, policy.MaxBackoff = time.Millisecond, time.Millisecond
+		client, err := eta.NewETAClient(sv.Config(), eta.V1, time.Second, eta.WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); err != nil {
+			t.Fatalf("GetETA should succeed after 2 failures but err is %s", err.Error())
+		}
+		if sv.Requests(EndpointETA) != 3 {
+			t.Fatalf("eta endpoint should receive 3 requests but it receives %d", sv.Requests(EndpointETA))
+		}
+	})
+
+	t.Run("count_ignores_unauthorized_requests", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(EndpointETA, Fault{StatusCode: http.StatusServiceUnavailable, Count: 2})
+
+		probe, err := eta.NewETAClient(sv.Config(config.WithAPIKey("wrong")), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		var apiErr *smapperrors.APIError
+		if _, err := probe.GetETA(points, eta.NewDefaultCallOptions()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("err should be a 401 APIError but it is %v", err)
+		}
+
+		client, err := eta.NewETAClient(sv.Config(), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("request %d should fail with 503 but err is %v", i+1, err)
+			}
+		}
+		if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); err != nil {
+			t.Fatalf("GetETA should succeed after 2 failures but err is %s", err.Error())
+		}
+	})
+
+	t.Run("all_endpoints_until_cleared", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(AllEndpoints, Fault{StatusCode: http.StatusTooManyRequests})
+
+		client, err := eta.NewETAClient(sv.Config(), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		for i := 0; i < 2; i++ {
+			var apiErr *smapperrors.APIError
+			if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+				t.Fatalf("err should be a 429 APIError but it is %v", err)
+			}
+		}
+
+		sv.ClearFaults()
+		if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); err != nil {
+			t.Fatalf("GetETA should succeed after faults are cleared but err is %s", err.Error())
+		}
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		sv := NewServer(t)
+		sv.InjectFault(EndpointETA, Fault{Abort: true, Count: 1})
+
+		client, err := eta.NewETAClient(sv.Config(), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		if _, err := client.GetETA(points, eta.NewDefaultCallOptions()); !errors.Is(err, smapperrors.ErrRequest) {
+			t.Fatalf("err should be ErrRequest but it is %v", err)
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		sv := NewServer(t, WithLatency(20*time.Millisecond))
+		sv.InjectFault(EndpointETA, Fault{StatusCode: http.StatusOK, Body: `{"trip":{"legs":[]}}`, Latency: time.Second})
+
+		client, err := eta.NewETAClient(sv.Config(), eta.V1, time.Second)
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+		start := time.Now()
+		_, err = client.GetETA(points, eta.NewDefaultCallOptions(eta.WithTimeout(100*time.Millisecond)))
+		if err == nil {
+			t.Fatal("GetETA should time out")
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Fatalf("GetETA should time out after 100ms but it takes %s", elapsed)
+		}
+	})
+}
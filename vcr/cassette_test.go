@@ -0,0 +1,72 @@
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBody_JSON(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		data, err := json.Marshal(Body(`{"a":1}`))
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if string(data) != `"{\"a\":1}"` {
+			t.Fatalf("text body should be marshalled as a string but it is %s", data)
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		body := Body{0xff, 0xfe, 0x00}
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if !strings.Contains(string(data), "base64") {
+			t.Fatalf("binary body should be marshalled as base64 but it is %s", data)
+		}
+
+		var decoded Body
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("should not return error: %s", err.Error())
+		}
+		if !bytes.Equal(decoded, body) {
+			t.Fatalf("body should be %v but it is %v", body, decoded)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var decoded Body
+		if err := json.Unmarshal([]byte(`42`), &decoded); err == nil {
+			t.Fatal("should return error")
+		}
+	})
+}
+
+func TestCassette_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cassette.json")
+	c := &Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: http.MethodPost, URL: "http://example.com/a?x=1", Body: Body(`{}`)},
+		Response: Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: Body(`[]`)},
+	}}}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("could not save cassette due to: %s", err.Error())
+	}
+
+	loaded, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("could not load cassette due to: %s", err.Error())
+	}
+	if len(loaded.Interactions) != 1 {
+		t.Fatalf("cassette should have 1 interaction but it has %d", len(loaded.Interactions))
+	}
+	got := loaded.Interactions[0]
+	if got.Request.Method != http.MethodPost || string(got.Request.Body) != `{}` || string(got.Response.Body) != `[]` ||
+		got.Response.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("loaded interaction is not equal to the saved one: %+v", got)
+	}
+}
@@ -0,0 +1,7 @@
+// Package vcr contains a http.RoundTripper that records the requests and responses of service clients into fixture
+// files, called cassettes, and replays them in tests. it is plugged into a client using the `WithTransport`
+// constructor option of each service, or `smapp.WithTransport` for all of them.
+//
+// API keys are scrubbed from recorded requests and responses, and requests are matched by their method, path, body
+// and query params in a normalized order, so replays are deterministic.
+package vcr
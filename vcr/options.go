@@ -0,0 +1,130 @@
+package vcr
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/deadline"
+)
+
+// Mode specifies whether a Recorder sends requests or replays them.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails requests that are not recorded. the cassette must exist.
+	ModeReplay Mode = iota
+	// ModeRecord sends all requests and replaces the cassette with them when the Recorder is stopped.
+	ModeRecord
+	// ModeAuto serves recorded requests from the cassette, if it exists, and records the others.
+	ModeAuto
+)
+
+// ModeEnvironmentVariable is the environment variable setting the default Mode of recorders, so fixtures can be
+// recorded again without changing tests, e.g. `SMAPP_VCR_MODE=record go test ./...`.
+const ModeEnvironmentVariable = "SMAPP_VCR_MODE"
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeAuto:
+		return "auto"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the Mode with the given name.
+func ParseMode(name string) (Mode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "auto":
+		return ModeAuto, nil
+	}
+	return 0, fmt.Errorf("vcr: unknown mode %q, should be replay, record or auto", name)
+}
+
+// defaultKeyNames are the header and query param names of API keys scrubbed by default.
+var defaultKeyNames = []string{
+	config.DefaultHeaderAPIKeyName,
+	config.DefaultQueryParamAPIKeyName,
+	"Authorization",
+}
+
+// defaultIgnoredHeaders are request headers that change between runs, so they are neither recorded nor matched.
+var defaultIgnoredHeaders = []string{
+	"User-Agent",
+	"Traceparent",
+	"Tracestate",
+	"Baggage",
+	"Accept-Encoding",
+	"Content-Length",
+	deadline.Header,
+}
+
+// Option is a function type for customizing the Recorder.
+type Option func(r *Recorder)
+
+// WithMode sets the mode of the recorder. default is the mode in ModeEnvironmentVariable, or ModeReplay.
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport sets the transport used to send requests while recording. default is http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.base = transport
+	}
+}
+
+// WithAPIKeys scrubs the header or query param with the given name, and the given keys wherever they appear, e.g.
+// `vcr.WithAPIKeys(cfg.APIKeyName, cfg.APIKey)`. the default key names of config are always scrubbed.
+func WithAPIKeys(keyName string, keys ...string) Option {
+	return func(r *Recorder) {
+		r.keyNames = append(r.keyNames, keyName)
+		r.keys = append(r.keys, keys...)
+	}
+}
+
+// WithIgnoredQueryParams excludes query params that change between runs, like timestamps, from recorded requests and
+// from matching.
+func WithIgnoredQueryParams(names ...string) Option {
+	return func(r *Recorder) {
+		r.ignoredParams = append(r.ignoredParams, names...)
+	}
+}
+
+// WithIgnoredHeaders excludes request headers that change between runs from recorded requests. `User-Agent`,
+// trace context headers and the deadline budget header are always ignored.
+func WithIgnoredHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		r.ignoredHeaders = append(r.ignoredHeaders, names...)
+	}
+}
+
+// WithMatchHost makes requests match only if their hosts are equal too. by default hosts are ignored, so cassettes
+// recorded against one region can be replayed with the base url of another, or of a local server.
+func WithMatchHost() Option {
+	return func(r *Recorder) {
+		r.matchHost = true
+	}
+}
+
+// modeFromEnvironment returns the mode in ModeEnvironmentVariable, or ModeReplay if it is not set.
+func modeFromEnvironment() (Mode, error) {
+	value := os.Getenv(ModeEnvironmentVariable)
+	if value == "" {
+		return ModeReplay, nil
+	}
+	return ParseMode(value)
+}
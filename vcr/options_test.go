@@ -0,0 +1,22 @@
+package vcr
+
+import "testing"
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range []Mode{ModeReplay, ModeRecord, ModeAuto} {
+		parsed, err := ParseMode(" " + mode.String() + " ")
+		if err != nil {
+			t.Fatalf("could not parse %s due to: %s", mode, err.Error())
+		}
+		if parsed != mode {
+			t.Fatalf("mode should be %s but it is %s", mode, parsed)
+		}
+	}
+
+	if _, err := ParseMode("rewind"); err == nil {
+		t.Fatal("unknown mode should return error")
+	}
+	if Mode(9).String() != "Mode(9)" {
+		t.Fatalf("unknown mode string should be Mode(9) but it is %s", Mode(9))
+	}
+}
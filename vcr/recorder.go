@@ -0,0 +1,280 @@
+package vcr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/snapp-incubator/smapp-sdk-go/redact"
+)
+
+// ErrNotRecorded is returned by replaying recorders for requests that are not in the cassette.
+var ErrNotRecorded = errors.New("vcr: request is not recorded")
+
+// Recorder is a http.RoundTripper that records requests and their responses into a cassette, or replays them from
+// it, according to its Mode.
+//
+// requests match recorded ones if their methods, paths, bodies and query params are equal, regardless of the order of
+// query params and of API keys. identical requests are replayed in the order they are recorded, and the last one is
+// replayed again once all of them are used.
+type Recorder struct {
+	path           string
+	mode           Mode
+	base           http.RoundTripper
+	keyNames       []string
+	keys           []string
+	ignoredParams  []string
+	ignoredHeaders []string
+	matchHost      bool
+	redactor       redact.Redactor
+
+	mu       sync.Mutex
+	cassette *Cassette
+	index    map[string][]int
+	next     map[string]int
+	changed  bool
+}
+
+// New creates a Recorder using the cassette at path. replaying recorders fail if the cassette can not be read.
+// call Stop to save the cassette of recording recorders.
+//
+//	rec, err := vcr.New("testdata/eta.json", vcr.WithAPIKeys(cfg.APIKeyName, cfg.APIKey))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client, err := eta.NewETAClient(cfg, eta.V1, time.Second, eta.WithTransport(rec))
+func New(path string, opts ...Option) (*Recorder, error) {
+	mode, err := modeFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		path:           path,
+		mode:           mode,
+		keyNames:       append([]string{}, defaultKeyNames...),
+		ignoredHeaders: append([]string{}, defaultIgnoredHeaders...),
+		cassette:       &Cassette{},
+		index:          make(map[string][]int),
+		next:           make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.redactor = redact.New("", r.keys...)
+
+	switch r.mode {
+	case ModeReplay:
+		if r.cassette, err = LoadCassette(path); err != nil {
+			return nil, err
+		}
+	case ModeAuto:
+		if r.cassette, err = LoadCassette(path); errors.Is(err, os.ErrNotExist) {
+			r.cassette = &Cassette{}
+		} else if err != nil {
+			return nil, err
+		}
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("vcr: unknown mode %s", r.mode)
+	}
+
+	for i, interaction := range r.cassette.Interactions {
+		key := r.key(interaction.Request)
+		r.index[key] = append(r.index[key], i)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop saves the cassette if any request is recorded. the recorder should not be used afterwards.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.changed && r.mode != ModeRecord {
+		return nil
+	}
+	r.changed = false
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := r.request(req, body)
+	key := r.key(recorded)
+
+	if r.mode != ModeRecord {
+		if response, ok := r.replay(key, req); ok {
+			return response, nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, recorded.Method, recorded.URL)
+		}
+	}
+
+	return r.record(key, req, body, recorded)
+}
+
+// replay returns the next recorded response of the requests with the given key.
+func (r *Recorder) replay(key string, req *http.Request) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	indexes := r.index[key]
+	if len(indexes) == 0 {
+		return nil, false
+	}
+	n := min(r.next[key], len(indexes)-1)
+	r.next[key]++
+
+	recorded := r.cassette.Interactions[indexes[n]].Response
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, true
+}
+
+// record sends req and adds it and its response to the cassette.
+func (r *Recorder) record(key string, req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	base := r.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	sent := req.Clone(req.Context())
+	if body != nil {
+		sent.Body = io.NopCloser(bytes.NewReader(body))
+		sent.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	response, err := base.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	header := response.Header.Clone()
+	for _, values := range header {
+		for i := range values {
+			values[i] = r.redactor.String(values[i])
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     header,
+			Body:       Body(r.redactor.String(string(responseBody))),
+		},
+	})
+	r.index[key] = append(r.index[key], len(r.cassette.Interactions)-1)
+	r.next[key] = len(r.index[key])
+	r.changed = true
+	r.mu.Unlock()
+
+	return response, nil
+}
+
+// request returns the scrubbed form of req, which is recorded.
+func (r *Recorder) request(req *http.Request, body []byte) Request {
+	header := req.Header.Clone()
+	for _, name := range r.ignoredHeaders {
+		header.Del(name)
+	}
+	for _, name := range r.keyNames {
+		if header.Get(name) != "" {
+			header.Set(name, redact.Placeholder)
+		}
+	}
+	for _, values := range header {
+		for i := range values {
+			values[i] = r.redactor.String(values[i])
+		}
+	}
+	if len(header) == 0 {
+		header = nil
+	}
+
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = r.query(u.Query())
+
+	var recordedBody Body
+	if body != nil {
+		recordedBody = Body(r.redactor.String(string(body)))
+	}
+
+	return Request{
+		Method: req.Method,
+		URL:    r.redactor.String(u.String()),
+		Header: header,
+		Body:   recordedBody,
+	}
+}
+
+// query returns the normalized form of query: ignored params are removed, API keys are scrubbed and params are
+// sorted by name.
+func (r *Recorder) query(query url.Values) string {
+	for _, name := range r.ignoredParams {
+		query.Del(name)
+	}
+	for _, name := range r.keyNames {
+		if query.Has(name) {
+			query.Set(name, redact.Placeholder)
+		}
+	}
+	return query.Encode()
+}
+
+// key returns a key that is equal for matching requests.
+func (r *Recorder) key(req Request) string {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return req.Method + " " + req.URL + "\n" + string(req.Body)
+	}
+
+	target := u.EscapedPath() + "?" + r.query(u.Query())
+	if r.matchHost {
+		target = u.Scheme + "://" + u.Host + target
+	}
+	return req.Method + " " + target + "\n" + string(req.Body)
+}
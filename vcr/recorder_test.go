@@ -0,0 +1,251 @@
+package vcr
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snapp-incubator/smapp-sdk-go/config"
+	"github.com/snapp-incubator/smapp-sdk-go/services/eta"
+)
+
+func get(t *testing.T, client *http.Client, url string, header http.Header) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request should not fail: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecorder(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `,"echo":"` + r.Header.Get(config.DefaultHeaderAPIKeyName) + `"}`))
+	}))
+	defer sv.Close()
+
+	path := filepath.Join(t.TempDir(), "fixtures", "cassette.json")
+
+	// record
+	rec, err := New(path, WithMode(ModeRecord), WithAPIKeys(config.DefaultHeaderAPIKeyName, "secret-key"))
+	if err != nil {
+		t.Fatalf("could not create recorder due to: %s", err.Error())
+	}
+	client := &http.Client{Transport: rec}
+	header := http.Header{config.DefaultHeaderAPIKeyName: {"secret-key"}, "User-Agent": {"test/1.0"}}
+
+	if _, body := get(t, client, sv.URL+"/a?b=2&a=1", header); body != `{"call":1,"echo":"secret-key"}` {
+		t.Fatalf("recording should return the real response but it is %s", body)
+	}
+	get(t, client, sv.URL+"/a?a=1&b=2", header)
+	get(t, client, sv.URL+"/missing", header)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("could not save cassette due to: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read cassette due to: %s", err.Error())
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Fatalf("cassette should not contain the api key: %s", data)
+	}
+	if strings.Contains(string(data), "test/1.0") {
+		t.Fatal("cassette should not contain ignored headers")
+	}
+	if !strings.Contains(string(data), "/a?a=1\\u0026b=2") {
+		t.Fatalf("query params should be sorted: %s", data)
+	}
+
+	// replay
+	sv.Close()
+	rec, err = New(path, WithMode(ModeReplay))
+	if err != nil {
+		t.Fatalf("could not create recorder due to: %s", err.Error())
+	}
+	client = &http.Client{Transport: rec}
+	otherKey := http.Header{config.DefaultHeaderAPIKeyName: {"another-key"}}
+
+	t.Run("in_order", func(t *testing.T) {
+		if _, body := get(t, client, "http://replay.local/a?b=2&a=1", otherKey); body != `{"call":1,"echo":"REDACTED"}` {
+			t.Fatalf("first recorded response should be replayed but it is %s", body)
+		}
+		if _, body := get(t, client, "http://replay.local/a?a=1&b=2", otherKey); body != `{"call":2,"echo":"REDACTED"}` {
+			t.Fatalf("second recorded response should be replayed but it is %s", body)
+		}
+		if _, body := get(t, client, "http://replay.local/a?a=1&b=2", otherKey); body != `{"call":2,"echo":"REDACTED"}` {
+			t.Fatalf("last recorded response should be replayed again but it is %s", body)
+		}
+	})
+
+	t.Run("status_and_headers", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "http://replay.local/missing", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request should not fail: %s", err.Error())
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound || resp.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("recorded status and headers should be replayed but they are %d %v", resp.StatusCode, resp.Header)
+		}
+	})
+
+	t.Run("not_recorded", func(t *testing.T) {
+		_, err := client.Get("http://replay.local/a?a=2")
+		if !errors.Is(err, ErrNotRecorded) {
+			t.Fatalf("err should be ErrNotRecorded but it is %v", err)
+		}
+	})
+
+	if calls != 3 {
+		t.Fatalf("replaying should not send requests but server is called %d times", calls)
+	}
+}
+
+func TestRecorder_Modes(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	defer sv.Close()
+
+	t.Run("replay_without_cassette", func(t *testing.T) {
+		if _, err := New(filepath.Join(t.TempDir(), "missing.json"), WithMode(ModeReplay)); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("err should be os.ErrNotExist but it is %v", err)
+		}
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "auto.json")
+		for i := 0; i < 2; i++ {
+			rec, err := New(path, WithMode(ModeAuto), WithTransport(http.DefaultTransport))
+			if err != nil {
+				t.Fatalf("could not create recorder due to: %s", err.Error())
+			}
+			get(t, &http.Client{Transport: rec}, sv.URL+"/auto", nil)
+			if err := rec.Stop(); err != nil {
+				t.Fatalf("could not save cassette due to: %s", err.Error())
+			}
+		}
+		if calls != 1 {
+			t.Fatalf("auto mode should record once and replay afterwards but server is called %d times", calls)
+		}
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(ModeEnvironmentVariable, "record")
+		rec, err := New(filepath.Join(t.TempDir(), "env.json"))
+		if err != nil {
+			t.Fatalf("could not create recorder due to: %s", err.Error())
+		}
+		if rec.Mode() != ModeRecord {
+			t.Fatalf("mode should be record but it is %s", rec.Mode())
+		}
+
+		t.Setenv(ModeEnvironmentVariable, "rewind")
+		if _, err := New(filepath.Join(t.TempDir(), "env.json")); err == nil {
+			t.Fatal("unknown mode should return error")
+		}
+	})
+}
+
+func TestRecorder_IgnoredQueryParamsAndHost(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer sv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := New(path, WithMode(ModeRecord), WithIgnoredQueryParams("ts"))
+	if err != nil {
+		t.Fatalf("could not create recorder due to: %s", err.Error())
+	}
+	get(t, &http.Client{Transport: rec}, sv.URL+"/q?ts=1&x=1", nil)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("could not save cassette due to: %s", err.Error())
+	}
+
+	rec, err = New(path, WithMode(ModeReplay), WithIgnoredQueryParams("ts"))
+	if err != nil {
+		t.Fatalf("could not create recorder due to: %s", err.Error())
+	}
+	if _, body := get(t, &http.Client{Transport: rec}, "http://other.local/q?x=1&ts=2", nil); body != "ts=1&x=1" {
+		t.Fatalf("ignored params and hosts should not be matched but response is %s", body)
+	}
+
+	rec, err = New(path, WithMode(ModeReplay), WithIgnoredQueryParams("ts"), WithMatchHost())
+	if err != nil {
+		t.Fatalf("could not create recorder due to: %s", err.Error())
+	}
+	if _, err := (&http.Client{Transport: rec}).Get("http://other.local/q?x=1"); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("err should be ErrNotRecorded with WithMatchHost but it is %v", err)
+	}
+}
+
+func TestRecorder_ServiceClient(t *testing.T) {
+	var calls int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"trip":{"legs":[{"time":120,"length":1000}]}}`))
+	}))
+	defer sv.Close()
+
+	path := filepath.Join(t.TempDir(), "eta.json")
+	points := []eta.Point{{Lat: 35.7, Lon: 51.4}, {Lat: 35.71, Lon: 51.41}}
+
+	for _, mode := range []Mode{ModeRecord, ModeReplay} {
+		cfg, err := config.NewDefaultConfig("eta-key", config.WithAPIBaseURL(sv.URL), config.WithAPIKeySource(config.QueryParamSource), config.WithAPIKeyName(config.DefaultQueryParamAPIKeyName))
+		if err != nil {
+			t.Fatalf("could not create default config due to: %s", err.Error())
+		}
+		rec, err := New(path, WithMode(mode), WithAPIKeys(cfg.APIKeyName, cfg.APIKey))
+		if err != nil {
+			t.Fatalf("could not create recorder due to: %s", err.Error())
+		}
+		client, err := eta.NewETAClient(cfg, eta.V1, time.Second, eta.WithTransport(rec))
+		if err != nil {
+			t.Fatalf("could not create eta client due to: %s", err.Error())
+		}
+
+		result, err := client.GetETA(points, eta.NewDefaultCallOptions(eta.WithTimeout(500*time.Millisecond)))
+		if err != nil {
+			t.Fatalf("%s: GetETA should not return error: %s", mode, err.Error())
+		}
+		if len(result.Trip.Legs) != 1 || result.Trip.Legs[0].Length != 1000 {
+			t.Fatalf("%s: length should be 1000 but result is %+v", mode, result)
+		}
+		if err := rec.Stop(); err != nil {
+			t.Fatalf("could not save cassette due to: %s", err.Error())
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("server should be called once but it is called %d times", calls)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "eta-key") {
+		t.Fatalf("cassette should not contain the api key: %s", data)
+	}
+}